@@ -14,14 +14,24 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/metadata"
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
 var (
 	// Directory to save the user story
 	intoDir string
+	// Title of a story to scaffold without the interactive form
+	scaffoldTitle string
+	// Write the story from the saved feature request draft
+	fromDraft bool
+	// Path of an existing story to copy
+	duplicatePath string
+	// Story fields given as flags instead of the form; the title comes from --title
+	storyValues io.FormValues
 )
 
 // addCmd represents the add command
@@ -43,18 +53,89 @@ or in the default directory (docs/user-stories) if not specified.
 Example:
   usm add user-story
   usm add user-story --into docs/user-stories/my-feature
+
+Use --title to skip the form and write a story skeleton to fill in later:
+  usm add user-story --title "Reset password"
+
+Use --from-draft to turn the feature request drafted with 'usm ask feature'
+into a story, keeping its description, user story and acceptance criteria:
+  usm add user-story --from-draft
+
+Use --duplicate to base a new story on an existing one. The copy is numbered
+in the target directory and gets fresh metadata; it is never marked implemented:
+  usm add user-story --duplicate docs/user-stories/01-login.md
+
+Give the other fields as flags to write a complete story without the form,
+as --non-interactive requires:
+  usm add user-story --title "Reset password" --as user --want "to reset my password" \
+    --so-that "I can log in again" --criterion "A reset link is emailed"
+
+Use --sort-criteria to write the acceptance criteria in alphabetical order
+rather than in the order they were entered, for stable diffs:
+  usm add user-story --sort-criteria
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Create filesystem and IO interfaces
 		fs := io.NewOSFileSystem()
-		terminal := io.NewTerminalIO()
+		terminal := newTerminalIO()
 		
 		// Get the target directory
-		targetDir := "docs/user-stories"
+		targetDir := config.UserStoriesDir()
 		if intoDir != "" {
 			targetDir = intoDir
 		}
 		
+		// Fields given as flags replace the form
+		fromFlags := storyValues.Description != "" || storyValues.As != "" || storyValues.Want != "" ||
+			storyValues.SoThat != "" || len(storyValues.Criteria) > 0
+		
+		// Scaffold the story without the form when only the title is given
+		if scaffoldTitle != "" && !fromFlags {
+			filePath, err := metadata.ScaffoldUserStory(scaffoldTitle, targetDir, fs)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to create user story: %s", err))
+				return
+			}
+			terminal.PrintSuccess(fmt.Sprintf("User story created: %s", filePath))
+			return
+		}
+		
+		// Copy an existing story without the form
+		if duplicatePath != "" {
+			filePath, err := metadata.DuplicateStory(duplicatePath, targetDir, fs)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to duplicate user story: %s", err))
+				return
+			}
+			terminal.PrintSuccess(fmt.Sprintf("User story created: %s", filePath))
+			return
+		}
+		
+		// Convert the saved feature request draft without the form
+		if fromDraft {
+			fr, found, err := io.LoadDraft(fs)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to load feature request draft: %s", err))
+				return
+			}
+			if !found {
+				terminal.PrintError("No feature request draft found, start one with 'usm ask feature'")
+				return
+			}
+			filePath, err := metadata.FeatureRequestToUserStory(fr, targetDir, fs)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to create user story: %s", err))
+				return
+			}
+			terminal.PrintSuccess(fmt.Sprintf("User story created: %s", filePath))
+			return
+		}
+		
+		if (fromFlags || nonInteractive) && scaffoldTitle == "" {
+			terminal.PrintError("--title is required to create a user story without the form")
+			return
+		}
+		
 		// Ensure the target directory exists
 		if !fs.Exists(targetDir) {
 			if err := fs.MkdirAll(targetDir, 0755); err != nil {
@@ -79,8 +160,33 @@ Example:
 			LastUpdated: time.Now(),
 		}
 		
+		// Build the story from the flags without the form
+		if fromFlags || nonInteractive {
+			values := storyValues
+			values.Title = scaffoldTitle
+			filePath := filepath.Join(targetDir, models.GenerateFilename(sequentialNumber, values.Title))
+			if fs.Exists(filePath) {
+				terminal.PrintError(fmt.Sprintf("File already exists: %s", filePath))
+				return
+			}
+			us.FilePath = filePath
+			
+			built, err := io.BuildUserStory(us, values)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to create user story: %s", err))
+				return
+			}
+			if err := fs.WriteFile(filePath, []byte(built.Content), 0644); err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to write file: %s", err))
+				return
+			}
+			terminal.PrintSuccess(fmt.Sprintf("User story created: %s", filePath))
+			return
+		}
+		
 		// Create and run the form
 		form := io.NewUserStoryForm(us)
+		form.SetSortCriteria(storyValues.SortCriteria)
 		p := tea.NewProgram(form)
 		result, err := p.Run()
 		if err != nil {
@@ -144,4 +250,13 @@ func init() {
 	
 	// Add flags
 	addUserStoryCmd.Flags().StringVar(&intoDir, "into", "", "Directory to save the user story (default is docs/user-stories)")
+	addUserStoryCmd.Flags().StringVar(&scaffoldTitle, "title", "", "Create a story skeleton with this title instead of opening the form")
+	addUserStoryCmd.Flags().BoolVar(&fromDraft, "from-draft", false, "Create the story from the feature request draft saved by 'usm ask feature'")
+	addUserStoryCmd.Flags().StringVar(&duplicatePath, "duplicate", "", "Create the story as a copy of an existing story")
+	addUserStoryCmd.Flags().StringVar(&storyValues.Description, "description", "", "Description of the story, instead of the form")
+	addUserStoryCmd.Flags().StringVar(&storyValues.As, "as", "", "User type of the story (As a ...), instead of the form")
+	addUserStoryCmd.Flags().StringVar(&storyValues.Want, "want", "", "Desired capability of the story (I want ...), instead of the form")
+	addUserStoryCmd.Flags().StringVar(&storyValues.SoThat, "so-that", "", "Benefit of the story (so that ...), instead of the form")
+	addUserStoryCmd.Flags().StringArrayVar(&storyValues.Criteria, "criterion", nil, "Acceptance criterion of the story, instead of the form (repeatable)")
+	addUserStoryCmd.Flags().BoolVar(&storyValues.SortCriteria, "sort-criteria", false, "Write the acceptance criteria in alphabetical order")
 } 
\ No newline at end of file
@@ -6,8 +6,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/user-story-matrix/usm/internal/io"
@@ -15,7 +17,16 @@ import (
 	"github.com/user-story-matrix/usm/internal/workflow"
 )
 
-var resetFlag bool
+var (
+	resetFlag         bool
+	yesFlag           bool
+	inlineStoriesFlag bool
+	explainFlag       bool
+	testsFailedFlag   bool
+	showOutputsFlag   bool
+	lockFlag          bool
+	unlockFlag        bool
+)
 
 // codeCmd represents the code command
 var codeCmd = &cobra.Command{
@@ -42,13 +53,33 @@ resume where you left off.
 Example:
   usm code docs/changes-request/2025-03-26-020055-code-command.blueprint.md
 
-Use the --reset flag to start the workflow from the beginning:
-  usm code --reset docs/changes-request/2025-03-26-020055-code-command.blueprint.md`,
+Use the --reset flag to start the workflow from the beginning. You will be asked
+to confirm before any progress is discarded; add --yes to skip the confirmation:
+  usm code --reset docs/changes-request/2025-03-26-020055-code-command.blueprint.md
+  usm code --reset --yes docs/changes-request/2025-03-26-020055-code-command.blueprint.md
+
+Use the --inline-stories flag to embed the referenced user stories in prompts
+that use the ${user_stories_content} variable:
+  usm code --inline-stories docs/changes-request/2025-03-26-020055-code-command.blueprint.md
+
+When the tests of the last testing step fail, use the --tests-failed flag to go
+back to the implementation step it tests and display that step again:
+  usm code --tests-failed docs/changes-request/2025-03-26-020055-code-command.blueprint.md
+
+Use the --show-outputs flag to display the output recorded by each step so far,
+without running any step:
+  usm code --show-outputs docs/changes-request/2025-03-26-020055-code-command.blueprint.md
+
+Once the workflow is complete, use the --lock flag to protect the finished
+change request: its steps cannot be run or reset again and its references are
+no longer updated until --unlock is used:
+  usm code --lock docs/changes-request/2025-03-26-020055-code-command.blueprint.md
+  usm code --unlock docs/changes-request/2025-03-26-020055-code-command.blueprint.md`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Create filesystem and IO interfaces
 		fs := io.NewOSFileSystem()
-		term := io.NewTerminalIOWithDebug(debug)
+		term := newTerminalIO()
 
 		// Create workflow manager
 		wm := workflow.NewWorkflowManager(fs, term)
@@ -62,15 +93,84 @@ Use the --reset flag to start the workflow from the beginning:
 			os.Exit(1)
 		}
 
+		// Lock or unlock the workflow without running any step
+		if lockFlag || unlockFlag {
+			if lockFlag && unlockFlag {
+				term.PrintError("--lock and --unlock cannot be used together")
+				os.Exit(1)
+			}
+			if lockFlag {
+				if err := wm.LockWorkflow(changeRequestPath); err != nil {
+					term.PrintError(fmt.Sprintf("Failed to lock workflow: %s", err))
+					os.Exit(1)
+				}
+				term.PrintSuccess(fmt.Sprintf(workflow.SuccessWorkflowLocked, changeRequestPath))
+			} else {
+				if err := wm.UnlockWorkflow(changeRequestPath); err != nil {
+					term.PrintError(fmt.Sprintf("Failed to unlock workflow: %s", err))
+					os.Exit(1)
+				}
+				term.PrintSuccess(fmt.Sprintf(workflow.SuccessWorkflowUnlocked, changeRequestPath))
+			}
+			return
+		}
+
 		// Handle reset flag
 		if resetFlag {
-			if err := wm.ResetWorkflow(changeRequestPath); err != nil {
-				term.PrintError(fmt.Sprintf("Failed to reset workflow: %s", err))
+			if nonInteractive && !yesFlag {
+				term.PrintError("--reset asks for confirmation, add --yes with --non-interactive")
 				os.Exit(1)
 			}
+			if yesFlag {
+				if err := wm.ResetWorkflow(changeRequestPath); err != nil {
+					term.PrintError(fmt.Sprintf("Failed to reset workflow: %s", err))
+					os.Exit(1)
+				}
+			} else {
+				reset, err := wm.ResetWorkflowWithConfirmation(changeRequestPath, func(state workflow.WorkflowState) (bool, error) {
+					answer, err := term.Prompt(workflow.ResetConfirmationMessage(state))
+					if err != nil {
+						return false, err
+					}
+					answer = strings.ToLower(strings.TrimSpace(answer))
+					return answer == "y" || answer == "yes", nil
+				})
+				if err != nil {
+					term.PrintError(fmt.Sprintf("Failed to reset workflow: %s", err))
+					os.Exit(1)
+				}
+				if !reset {
+					term.Print("Reset cancelled, workflow progress kept")
+					os.Exit(0)
+				}
+			}
 			// Success message is shown by the ResetWorkflow method in debug mode
 		}
 
+		// Display the recorded step outputs without touching the workflow
+		if showOutputsFlag {
+			printStepOutputs(wm, changeRequestPath, term)
+			return
+		}
+
+		// Send the workflow back when the tests of the last completed step failed
+		if testsFailedFlag {
+			state, err := wm.LoadState(changeRequestPath)
+			if err != nil {
+				term.PrintError(fmt.Sprintf("Failed to load workflow state: %s", err))
+				os.Exit(1)
+			}
+			last := state.CurrentStepIndex - 1
+			if last < 0 || !workflow.StandardWorkflowSteps[last].IsTestStep() {
+				term.PrintError("The last completed step is not a testing step.")
+				os.Exit(1)
+			}
+			if err := wm.UpdateStateWithOutcome(changeRequestPath, state.CurrentStepIndex, workflow.StepFailed); err != nil {
+				term.PrintError(fmt.Sprintf("Failed to update workflow state: %s", err))
+				os.Exit(1)
+			}
+		}
+
 		// Check if workflow is already complete
 		complete, err := wm.IsWorkflowComplete(changeRequestPath)
 		if err != nil {
@@ -79,77 +179,89 @@ Use the --reset flag to start the workflow from the beginning:
 		}
 
 		if complete {
-			// Only show completion message in debug mode
-			if term.IsDebugEnabled() {
+			// Only show completion message in verbose mode
+			if term.Verbosity() >= io.VerbosityVerbose {
 				term.PrintSuccess(fmt.Sprintf("✅ All steps completed successfully for change request: %s", changeRequestPath))
+				if locked, err := wm.IsWorkflowLocked(changeRequestPath); err == nil && locked {
+					term.Print("The workflow is locked, use --unlock to change it again.")
+				} else {
+					printLockSuggestion(changeRequestPath, term)
+				}
 			}
 			os.Exit(0)
 		}
 
-		// Determine which step to execute
-		nextStepIndex, err := wm.DetermineNextStep(changeRequestPath)
-		if err != nil {
-			term.PrintError(fmt.Sprintf("Failed to determine next step: %s", err))
-			os.Exit(1)
-		}
+		executor := workflow.NewStepExecutor(fs, term)
+		executor.SetInlineUserStories(inlineStoriesFlag)
 
-		// Special case: workflow is complete
-		if nextStepIndex == -1 {
-			// Only show completion message in debug mode
-			if term.IsDebugEnabled() {
-				term.PrintSuccess(fmt.Sprintf("✅ All steps completed successfully for change request: %s", changeRequestPath))
+		// Describe the next step without running it or touching the state
+		if explainFlag {
+			nextStep, complete, err := wm.CurrentStep(changeRequestPath)
+			if err != nil {
+				term.PrintError(fmt.Sprintf("Failed to determine next step: %s", err))
+				os.Exit(1)
 			}
-			os.Exit(0)
-		}
-
-		// Get the step details
-		if nextStepIndex >= len(workflow.StandardWorkflowSteps) {
-			term.PrintError("Invalid step index. This should not happen.")
-			os.Exit(1)
+			if complete {
+				term.PrintError("Invalid step index. This should not happen.")
+				os.Exit(1)
+			}
+			explanation, err := executor.ExplainStep(changeRequestPath, nextStep)
+			if err != nil {
+				term.PrintError(fmt.Sprintf("Failed to explain step: %s", err))
+				os.Exit(1)
+			}
+			term.Print(explanation)
+			return
 		}
 
-		currentStep := workflow.StandardWorkflowSteps[nextStepIndex]
-
-		// Generate output filename (still needed for state tracking)
-		outputFile := wm.GenerateOutputFilename(changeRequestPath, currentStep)
-
-		// Execute the step - now just prints the prompt to stdout
-		success, err := executeStep(changeRequestPath, currentStep, outputFile, fs, term)
+		// Execute the next step (it just prints the prompt to stdout) and advance the state
+		done, err := wm.RunNext(changeRequestPath, executor)
 		if err != nil {
 			term.PrintError(fmt.Sprintf("Failed to execute step: %s", err))
 			os.Exit(1)
 		}
 
-		if !success {
-			term.PrintError("Step execution failed.")
-			os.Exit(1)
-		}
-
-		// Update state
-		if err := wm.UpdateState(changeRequestPath, nextStepIndex+1); err != nil {
-			term.PrintError(fmt.Sprintf("Failed to update workflow state: %s", err))
-			os.Exit(1)
-		}
-
-		// Only show success messages if debug is enabled
-		if term.IsDebugEnabled() {
-			term.PrintSuccess(fmt.Sprintf("Completed step %d: %s", nextStepIndex+1, currentStep.Description))
-
-			// Check if we've completed all steps
-			if nextStepIndex+1 >= len(workflow.StandardWorkflowSteps) {
+		// Only show success messages in verbose mode
+		if term.Verbosity() >= io.VerbosityVerbose {
+			if done {
 				term.PrintSuccess(fmt.Sprintf("✅ All steps completed successfully for change request: %s", changeRequestPath))
-			} else {
-				nextStep := workflow.StandardWorkflowSteps[nextStepIndex+1]
+			} else if state, err := wm.LoadState(changeRequestPath); err == nil {
+				nextStep := workflow.StandardWorkflowSteps[state.CurrentStepIndex]
 				term.Print(fmt.Sprintf("\nNext step: %s", nextStep.Description))
 			}
 		}
+
+		// Suggest protecting the deliverable once the last step is done
+		if done {
+			printLockSuggestion(changeRequestPath, term)
+		}
 	},
 }
 
-// executeStep executes a workflow step and prints the processed prompt
-func executeStep(changeRequestPath string, step workflow.WorkflowStep, outputFile string, fs io.FileSystem, term io.UserOutput) (bool, error) {
-	executor := workflow.NewStepExecutor(fs, term)
-	return executor.ExecuteStep(changeRequestPath, step, outputFile)
+// printLockSuggestion suggests locking a complete workflow
+func printLockSuggestion(changeRequestPath string, term io.UserOutput) {
+	term.Print(fmt.Sprintf("Lock the finished workflow to protect it: usm code --lock %s", changeRequestPath))
+}
+
+// printStepOutputs prints the recorded output of every step of a change
+// request, in workflow order, skipping the steps not run yet
+func printStepOutputs(wm *workflow.WorkflowManager, changeRequestPath string, term io.UserOutput) {
+	found := false
+	for i, step := range workflow.StandardWorkflowSteps {
+		output, err := wm.ReadStepOutput(changeRequestPath, step)
+		if errors.Is(err, workflow.ErrStepOutputNotFound) {
+			continue
+		}
+		if err != nil {
+			term.PrintError(fmt.Sprintf("Failed to read the output of step %s: %s", step.ID, err))
+			continue
+		}
+		found = true
+		term.Print(fmt.Sprintf("## Step %d: %s (%s)\n\n%s", i+1, step.Description, wm.GenerateOutputFilename(changeRequestPath, step), output))
+	}
+	if !found {
+		term.Print("No step output recorded yet.")
+	}
 }
 
 // getDirectoryPath extracts the directory part of a file path
@@ -170,5 +282,12 @@ func getFileName(filePath string) string {
 func init() {
 	rootCmd.AddCommand(codeCmd)
 	codeCmd.Flags().BoolVar(&resetFlag, "reset", false, "Reset the workflow and start from the beginning")
+	codeCmd.Flags().BoolVar(&yesFlag, "yes", false, "Reset without asking for confirmation (use with --reset)")
+	codeCmd.Flags().BoolVar(&inlineStoriesFlag, "inline-stories", false, "Resolve ${user_stories_content} by inlining the referenced user stories")
+	codeCmd.Flags().BoolVar(&explainFlag, "explain", false, "Describe the next step without executing it or updating the workflow state")
+	codeCmd.Flags().BoolVar(&showOutputsFlag, "show-outputs", false, "Display the output recorded by each step without running any step")
+	codeCmd.Flags().BoolVar(&testsFailedFlag, "tests-failed", false, "Record that the tests of the last testing step failed and go back to the step it tests")
+	codeCmd.Flags().BoolVar(&lockFlag, "lock", false, "Lock the complete workflow so its steps and references can no longer change")
+	codeCmd.Flags().BoolVar(&unlockFlag, "unlock", false, "Unlock a workflow locked with --lock")
 	logger.Debug("Code command added to root command")
 } 
\ No newline at end of file
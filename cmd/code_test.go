@@ -107,6 +107,13 @@ func (m *mockUserOutput) PrintTable(headers []string, rows [][]string) {
 	// Not needed for these tests
 }
 
+func (m *mockUserOutput) Verbosity() io.Verbosity {
+	if m.debugEnabled {
+		return io.VerbosityDebug
+	}
+	return io.VerbosityNormal
+}
+
 func (m *mockUserOutput) IsDebugEnabled() bool {
 	return m.debugEnabled
 }
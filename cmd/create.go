@@ -9,14 +9,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/implementation"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
 	"github.com/user-story-matrix/usm/internal/models"
 	"github.com/user-story-matrix/usm/internal/ui"
+	uimodels "github.com/user-story-matrix/usm/internal/ui/models"
 )
 
 // Program interface for testing
@@ -41,6 +44,22 @@ var (
 	fromUserStoriesDir string
 	// Show all user stories, including implemented ones
 	showAll bool
+	// Read the user stories to choose from on stdin
+	readStoriesFromStdin bool
+	// Print the chosen stories once the picker exits
+	printSelectionSummary bool
+	// Maximum number of stories that can be picked, 0 for unlimited
+	maxSelections int
+	// Print the chosen paths for other tools instead of creating a change request
+	printSelectedPaths bool
+	// Print the chosen paths NUL-delimited, for xargs -0
+	printSelectedPaths0 bool
+	// Only offer the stories changed since this git ref
+	changedSinceRef string
+	// Search query choosing the stories without the picker
+	selectQuery string
+	// Name of the change request, asked for when empty
+	changeRequestName string
 	// Program creator for testing
 	newProgram programCreator = func(m tea.Model, opts ...tea.ProgramOption) program {
 		return &teaProgram{tea.NewProgram(m, opts...)}
@@ -56,7 +75,7 @@ var createCmd = &cobra.Command{
 
 // createChangeRequestCmd represents the create change-request command
 var createChangeRequestCmd = &cobra.Command{
-	Use:   "change-request",
+	Use:   "change-request [directories...]",
 	Short: "Create a new change request",
 	Long: `Create a new change request based on existing user stories.
 
@@ -66,104 +85,131 @@ The selected user stories will be included in the change request.
 Example:
   usm create change-request
   usm create change-request --from docs/user-stories/my-feature
+
+Pass several directories to choose from the stories of all of them:
+  usm create change-request docs/user-stories services/billing/user-stories
+
+Use --stdin to choose from stories piped in, either as one path per line
+or as a JSON array of user stories:
+  find docs/user-stories -name '*login*.md' | usm create change-request --stdin
+
+Use --changed to only offer the stories changed since the last commit, or
+since a given ref, including uncommitted and new ones:
+  usm create change-request --changed
+  usm create change-request --changed=main
+
+Use --print-paths (or --print0 for NUL-delimited output) to only print the
+paths of the chosen stories, e.g. to feed them to other tools:
+  usm create change-request --print-paths | xargs grep -n "password"
+
+With --non-interactive, the stories matching the --select search query are
+chosen without the picker (all unimplemented ones for an empty query), and
+--name gives the change request name:
+  usm create change-request --non-interactive --select "tag:auth" --name "Login"
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Create filesystem and IO interfaces
 		fs := io.NewOSFileSystem()
-		terminal := io.NewTerminalIO()
+		terminal := newTerminalIO()
 
-		// Get the source directory for user stories
-		userStoriesDir := "docs/user-stories"
-		if fromUserStoriesDir != "" {
-			userStoriesDir = fromUserStoriesDir
+		// Collect all user stories, either from stdin or from the source directory
+		var userStories []models.UserStory
+		source := "stdin"
+		if len(args) > 0 && (readStoriesFromStdin || fromUserStoriesDir != "") {
+			terminal.PrintError("Directories given as arguments cannot be combined with --from or --stdin")
+			return
 		}
-
-		// Check if the source directory exists
-		if !fs.Exists(userStoriesDir) {
-			terminal.PrintError(fmt.Sprintf("Directory not found: %s", userStoriesDir))
+		if changedSinceRef != "" && (len(args) > 0 || readStoriesFromStdin || fromUserStoriesDir != "") {
+			terminal.PrintError("--changed cannot be combined with directories, --from or --stdin")
 			return
 		}
-
-		// Collect all user stories
-		var userStories []models.UserStory
-
-		err := fs.WalkDir(userStoriesDir, func(path string, d os.DirEntry, err error) error {
+		if changedSinceRef != "" {
+			source = "the stories changed since " + changedSinceRef
+			stories, err := ui.LoadChangedStories(changedSinceRef, ".", fs)
 			if err != nil {
-				return err
-			}
-
-			// Skip directories
-			if d.IsDir() {
-				return nil
+				terminal.PrintError(fmt.Sprintf("Failed to list changed user stories: %s", err))
+				return
 			}
-
-			// Skip non-markdown files
-			if filepath.Ext(path) != ".md" {
-				return nil
+			for i := range stories {
+				if err := implementation.UpdateImplementationStatus(&stories[i], fs); err != nil {
+					logger.Debug("Failed to check implementation status: " + err.Error())
+				}
 			}
-
-			// Read the file
-			content, err := fs.ReadFile(path)
+			userStories = stories
+		} else if len(args) > 0 {
+			source = strings.Join(args, ", ")
+			stories, err := ui.LoadStoriesFromDirs(args, fs)
 			if err != nil {
-				logger.Debug("Failed to read file: " + err.Error())
-				return nil
+				terminal.PrintError(fmt.Sprintf("Failed to load user stories: %s", err))
+				return
 			}
-
-			// Parse the user story
-			userStory, err := models.LoadUserStoryFromFile(path, content)
+			for i := range stories {
+				if err := implementation.UpdateImplementationStatus(&stories[i], fs); err != nil {
+					logger.Debug("Failed to check implementation status: " + err.Error())
+				}
+			}
+			userStories = stories
+		} else if readStoriesFromStdin {
+			stories, err := ui.LoadStoriesFromReader(cmd.InOrStdin(), fs)
 			if err != nil {
-				logger.Debug("Failed to parse user story: " + err.Error())
-				return nil
+				terminal.PrintError(fmt.Sprintf("Failed to load user stories: %s", err))
+				return
 			}
-
-			// Check if the user story is implemented
-			if err := implementation.UpdateImplementationStatus(&userStory, fs); err != nil {
-				logger.Debug("Failed to check implementation status: " + err.Error())
+			for i := range stories {
+				if err := implementation.UpdateImplementationStatus(&stories[i], fs); err != nil {
+					logger.Debug("Failed to check implementation status: " + err.Error())
+				}
+			}
+			userStories = stories
+		} else {
+			// Get the source directory for user stories
+			source = config.UserStoriesDir()
+			if fromUserStoriesDir != "" {
+				source = fromUserStoriesDir
 			}
 
-			userStories = append(userStories, userStory)
-			return nil
-		})
+			// Check if the source directory exists
+			if !fs.Exists(source) {
+				terminal.PrintError(fmt.Sprintf("Directory not found: %s", source))
+				return
+			}
 
-		if err != nil {
-			terminal.PrintError(fmt.Sprintf("Failed to walk directory: %s", err))
-			return
+			stories, err := collectUserStories(source, fs)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to walk directory: %s", err))
+				return
+			}
+			userStories = stories
 		}
 
 		// Check if any user stories were found
 		if len(userStories) == 0 {
-			terminal.PrintError(fmt.Sprintf("No user stories found in: %s", userStoriesDir))
+			terminal.PrintError(fmt.Sprintf("No user stories found in: %s", source))
 			return
 		}
 
-		// Print available user stories
-		terminal.Print("Available user stories:")
-
-		// Create a selection UI with the showAll flag
-		selectionUI := ui.CurrentNewSelectionUI(userStories, showAll)
-
-		// Create a program with more options
-		p := newProgram(selectionUI,
-			// Add option to capture the terminal window size on startup
-			tea.WithAltScreen(),
-			// Send an initial window size event to ensure the UI is properly sized
-			tea.WithMouseCellMotion(),
-		)
-
-		// Run the program
-		model, err := p.Run()
-		if err != nil {
-			terminal.PrintError(fmt.Sprintf("Failed to run selection UI: %s", err))
-			return
+		pathsOnly := printSelectedPaths || printSelectedPaths0
+		var selected []int
+		if nonInteractive {
+			if changeRequestName == "" && !pathsOnly {
+				terminal.PrintError("--name is required with --non-interactive")
+				return
+			}
+			selected = ui.SelectMatching(userStories, selectQuery, showAll, maxSelections)
+			if printSelectionSummary {
+				terminal.Print(ui.FormatSelectionSummary(userStories, selected))
+			}
+		} else {
+			var ok bool
+			if selected, ok = pickStories(userStories, terminal, fs); !ok {
+				return
+			}
 		}
 
-		// Get the selected stories
-		selAdapter, ok := model.(*ui.SelectionAdapter)
-		if !ok {
-			terminal.PrintError("Error: could not get selection result")
+		if pathsOnly {
+			fmt.Print(ui.FormatSelectionAsPaths(userStories, selected, printSelectedPaths0))
 			return
 		}
-		selected := selAdapter.GetSelected()
 
 		// Check if any user stories were selected
 		if len(selected) == 0 {
@@ -172,10 +218,14 @@ Example:
 		}
 
 		// Ask for the change request name
-		name, err := terminal.Prompt("Enter the change request name:")
-		if err != nil {
-			terminal.PrintError(fmt.Sprintf("Failed to read input: %s", err))
-			return
+		name := changeRequestName
+		if name == "" {
+			var err error
+			name, err = terminal.Prompt("Enter the change request name:")
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to read input: %s", err))
+				return
+			}
 		}
 
 		if name == "" {
@@ -198,7 +248,7 @@ Example:
 		template := models.GenerateChangeRequestTemplate(name, references)
 
 		// Ensure the change requests directory exists
-		changeRequestsDir := "docs/changes-request"
+		changeRequestsDir := config.ChangesDir()
 		if !fs.Exists(changeRequestsDir) {
 			if err := fs.MkdirAll(changeRequestsDir, 0755); err != nil {
 				terminal.PrintError(fmt.Sprintf("Failed to create directory: %s", err))
@@ -235,6 +285,134 @@ Example:
 	},
 }
 
+// pickStories runs the picker over userStories and returns the indices of the
+// chosen ones. It reports false when the picker failed or was cancelled, after
+// telling the user.
+func pickStories(userStories []models.UserStory, terminal *io.TerminalIO, fs io.FileSystem) ([]int, bool) {
+	// Print available user stories
+	terminal.Print("Available user stories:")
+
+	// Create a selection UI with the showAll flag
+	selectionUI := ui.CurrentNewSelectionUI(userStories, showAll)
+
+	// Cap how many stories can be picked
+	if adapter, ok := selectionUI.(*ui.SelectionAdapter); ok && maxSelections > 0 {
+		adapter.SetMaxSelections(maxSelections)
+	}
+
+	// Restore the search history from previous sessions
+	historyPath, historyErr := uimodels.DefaultSearchHistoryPath()
+	if adapter, ok := selectionUI.(*ui.SelectionAdapter); ok && historyErr == nil {
+		history, err := uimodels.LoadSearchHistory(fs, historyPath)
+		if err != nil {
+			logger.Debug("Failed to load search history: " + err.Error())
+		}
+		adapter.SetSearchHistory(history)
+	}
+
+	// Create a program with more options
+	opts := []tea.ProgramOption{
+		// Add option to capture the terminal window size on startup
+		tea.WithAltScreen(),
+		// Send an initial window size event to ensure the UI is properly sized
+		tea.WithMouseCellMotion(),
+	}
+	if readStoriesFromStdin {
+		// Stdin carries the story list, so keyboard input comes from the terminal
+		opts = append(opts, tea.WithInputTTY())
+	}
+	pathsOnly := printSelectedPaths || printSelectedPaths0
+	if pathsOnly {
+		// Stdout carries the chosen paths, so the picker is drawn on stderr
+		opts = append(opts, tea.WithOutput(os.Stderr))
+	}
+	p := newProgram(selectionUI, opts...)
+
+	// Run the program
+	model, err := p.Run()
+	if err != nil {
+		terminal.PrintError(fmt.Sprintf("Failed to run selection UI: %s", err))
+		return nil, false
+	}
+
+	// Get the selected stories
+	selAdapter, ok := model.(*ui.SelectionAdapter)
+	if !ok {
+		terminal.PrintError("Error: could not get selection result")
+		return nil, false
+	}
+	selected := selAdapter.GetSelected()
+
+	// Show what was chosen, since the alt-screen is gone once the picker exits
+	if printSelectionSummary && !selAdapter.WasCancelled() {
+		terminal.Print(ui.FormatSelectionSummary(userStories, selected))
+	}
+
+	// Persist the search history for the next session
+	if historyErr == nil {
+		if err := selAdapter.SearchHistory().Save(fs, historyPath); err != nil {
+			logger.Debug("Failed to save search history: " + err.Error())
+		}
+	}
+
+	// Quitting the picker aborts, unlike confirming an empty selection
+	if selAdapter.WasCancelled() {
+		if pathsOnly {
+			// Scripts reading the paths can tell an abort by the exit status
+			os.Exit(1)
+		}
+		terminal.Print("Change request creation canceled by user.")
+		return nil, false
+	}
+
+	return selected, true
+}
+
+// collectUserStories loads every markdown user story below dir
+func collectUserStories(dir string, fs io.FileSystem) ([]models.UserStory, error) {
+	var userStories []models.UserStory
+
+	err := fs.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip directories
+		if d.IsDir() {
+			return nil
+		}
+
+		// Skip non-markdown files
+		if filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		// Read the file
+		content, err := fs.ReadFile(path)
+		if err != nil {
+			logger.Debug("Failed to read file: " + err.Error())
+			return nil
+		}
+
+		// Parse the user story
+		userStory, err := models.LoadUserStoryFromFile(path, content)
+		if err != nil {
+			logger.Debug("Failed to parse user story: " + err.Error())
+			return nil
+		}
+
+		// Check if the user story is implemented
+		if err := implementation.UpdateImplementationStatus(&userStory, fs); err != nil {
+			logger.Debug("Failed to check implementation status: " + err.Error())
+		}
+
+		userStories = append(userStories, userStory)
+		return nil
+	})
+
+	return userStories, err
+}
+
 func init() {
 	rootCmd.AddCommand(createCmd)
 
@@ -244,6 +422,16 @@ func init() {
 	// Add flags
 	createChangeRequestCmd.Flags().StringVar(&fromUserStoriesDir, "from", "", "Directory to read user stories from (default is docs/user-stories)")
 	createChangeRequestCmd.Flags().BoolVar(&showAll, "show-all", false, "Show all user stories, including implemented ones")
+	createChangeRequestCmd.Flags().BoolVar(&readStoriesFromStdin, "stdin", false, "Read story paths (one per line) or a JSON array of stories from stdin")
+	createChangeRequestCmd.Flags().BoolVar(&printSelectionSummary, "summary", false, "Print the titles and paths of the selected user stories when the picker exits")
+	createChangeRequestCmd.Flags().IntVar(&maxSelections, "max", 0, "Maximum number of user stories that can be selected (0 for no limit)")
+	createChangeRequestCmd.Flags().BoolVar(&printSelectedPaths, "print-paths", false, "Print the paths of the selected user stories, one per line, instead of creating a change request")
+	createChangeRequestCmd.Flags().BoolVar(&printSelectedPaths0, "print0", false, "Like --print-paths, but NUL-delimited for xargs -0")
+	createChangeRequestCmd.Flags().StringVar(&changedSinceRef, "changed", "", "Only offer the user stories changed since a git ref (HEAD when no ref is given)")
+	createChangeRequestCmd.Flags().Lookup("changed").NoOptDefVal = "HEAD"
+	createChangeRequestCmd.Flags().StringVar(&selectQuery, "select", "", "With --non-interactive, choose the user stories matching this search query")
+	createChangeRequestCmd.Flags().StringVar(&changeRequestName, "name", "", "Name of the change request (asked for when not given)")
+	createChangeRequestCmd.MarkFlagsMutuallyExclusive("from", "stdin")
 
 	// Register the new selection UI implementation
 	ui.RegisterNewSelectionUIMaker()
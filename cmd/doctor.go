@@ -0,0 +1,125 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/changerequest"
+	"github.com/user-story-matrix/usm/internal/doctor"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// Delete orphaned workflow files before running the checks
+var removeOrphans bool
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the health of the user stories and change requests",
+	Long: `Run every workspace check at once and report the problems found:
+stale metadata, broken references, reference paths that are not
+repo-relative, outdated reference hashes, duplicate titles, stories without
+acceptance criteria, corrupt workflow state files and workflow files left
+behind by deleted or renamed change requests.
+
+The command exits with a non-zero status when an error is found.
+
+Example:
+  usm doctor
+
+Use --remove-orphans to delete the workflow state files and step outputs whose
+change request no longer exists:
+  usm doctor --remove-orphans
+
+Use --format json to get the findings as a JSON document, e.g. in CI:
+  usm doctor --format json | jq '.findings[] | select(.severity == "error")'
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+
+		if removeOrphans {
+			removeOrphanedWorkflowFiles(fs, terminal)
+		}
+
+		report, err := doctor.RunDiagnostics(".", fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to run diagnostics: %s", err))
+			os.Exit(1)
+		}
+
+		printResult(diagnosticResult{report}, terminal)
+
+		if report.HasErrors() {
+			os.Exit(1)
+		}
+	},
+}
+
+// removeOrphanedWorkflowFiles deletes the workflow files of change requests
+// that no longer exist, reporting each one
+func removeOrphanedWorkflowFiles(fs io.FileSystem, terminal io.UserOutput) {
+	orphans, err := changerequest.FindOrphanedWorkflowFiles(".", fs)
+	if err != nil {
+		terminal.PrintError(fmt.Sprintf("Failed to find orphaned workflow files: %s", err))
+		os.Exit(1)
+	}
+	for _, orphan := range orphans {
+		if err := fs.Remove(orphan); err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to remove %s: %s", orphan, err))
+			continue
+		}
+		terminal.Print(fmt.Sprintf("Removed %s", orphan))
+	}
+}
+
+// diagnosticResult prints a diagnostic report with the --format formatter
+type diagnosticResult struct {
+	doctor.DiagnosticReport
+}
+
+// WriteText prints each finding followed by a one-line summary
+func (r diagnosticResult) WriteText(terminal io.UserOutput) {
+	report := r.DiagnosticReport
+	for _, finding := range report.Findings {
+		line := fmt.Sprintf("[%s] %s: %s", finding.Check, finding.Location(), finding.Message)
+		switch finding.Severity {
+		case doctor.SeverityError:
+			terminal.PrintError(line)
+		case doctor.SeverityWarning:
+			terminal.PrintWarning(line)
+		default:
+			terminal.Print(line)
+		}
+	}
+
+	summary := fmt.Sprintf("Checked %d user stories and %d change requests: %d errors, %d warnings",
+		report.StoriesChecked, report.ChangeRequestsChecked,
+		report.Count(doctor.SeverityError), report.Count(doctor.SeverityWarning))
+	if report.Healthy() {
+		terminal.PrintSuccess(summary)
+	} else {
+		terminal.Print(summary)
+	}
+}
+
+// Table returns one row per finding
+func (r diagnosticResult) Table() ([]string, [][]string) {
+	rows := make([][]string, len(r.Findings))
+	for i, finding := range r.Findings {
+		rows[i] = []string{finding.Severity.String(), finding.Check, finding.Location(), finding.Message}
+	}
+	return []string{"Severity", "Check", "Location", "Message"}, rows
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&removeOrphans, "remove-orphans", false, "Delete the workflow files of change requests that no longer exist before checking")
+	addFormatFlag(doctorCmd)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/catalog"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+var (
+	// Directory to export user stories from
+	exportFromDir string
+	// File to write the export to, stdout when empty
+	exportOutput string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export user stories",
+	Long:  `Export user stories for external tools.`,
+}
+
+// exportCatalogCmd represents the export catalog command
+var exportCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Export all user stories as a JSON catalog",
+	Long: `Export the user stories of a directory as a single JSON document, with their
+title, description, acceptance criteria, tags, implementation status, content
+hash and dates. The document carries a schema_version field so consumers can
+detect format changes.
+
+Example:
+  usm export catalog > catalog.json
+  usm export catalog --from docs/user-stories/my-feature --output catalog.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+
+		data, err := catalog.ExportCatalog(exportDir(), fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to export catalog: %s", err))
+			os.Exit(1)
+		}
+
+		writeExport(data, terminal)
+	},
+}
+
+// exportCSVCmd represents the export csv command
+var exportCSVCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Export all user stories as CSV",
+	Long: `Export the user stories of a directory as CSV, one row per story, with the
+columns title, path, implemented, created_at, last_updated and
+acceptance_criteria_count. Fields are quoted as described in RFC 4180, so the
+file opens as-is in a spreadsheet.
+
+Example:
+  usm export csv > stories.csv
+  usm export csv --from docs/user-stories/my-feature --output stories.csv
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+
+		data, err := catalog.ExportCatalogCSV(exportDir(), fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to export CSV: %s", err))
+			os.Exit(1)
+		}
+
+		writeExport(data, terminal)
+	},
+}
+
+// exportNDJSONCmd represents the export ndjson command
+var exportNDJSONCmd = &cobra.Command{
+	Use:   "ndjson",
+	Short: "Export all user stories as newline-delimited JSON",
+	Long: `Export the user stories of a directory as newline-delimited JSON, one catalog
+entry per line with the same fields as 'usm export catalog'. Stories are
+written as they are read, so very large catalogs are exported without
+holding them in memory.
+
+Example:
+  usm export ndjson > stories.ndjson
+  usm export ndjson | jq -c 'select(.implemented == false)'
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+
+		out := os.Stdout
+		if exportOutput != "" {
+			file, err := os.Create(exportOutput)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to write %s: %s", exportOutput, err))
+				os.Exit(1)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		if err := catalog.WriteCatalogNDJSON(exportDir(), fs, out); err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to export NDJSON: %s", err))
+			os.Exit(1)
+		}
+		if exportOutput != "" {
+			terminal.PrintSuccess(fmt.Sprintf("Exported to %s", exportOutput))
+		}
+	},
+}
+
+// exportDir returns the --from directory, or the configured user stories directory
+func exportDir() string {
+	if exportFromDir != "" {
+		return exportFromDir
+	}
+	return config.UserStoriesDir()
+}
+
+// writeExport writes exported data to the --output file, or to stdout
+func writeExport(data []byte, terminal io.UserOutput) {
+	if exportOutput == "" {
+		os.Stdout.Write(data)
+		return
+	}
+
+	if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+		terminal.PrintError(fmt.Sprintf("Failed to write %s: %s", exportOutput, err))
+		os.Exit(1)
+	}
+	terminal.PrintSuccess(fmt.Sprintf("Exported to %s", exportOutput))
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportCatalogCmd)
+	exportCmd.AddCommand(exportCSVCmd)
+	exportCmd.AddCommand(exportNDJSONCmd)
+
+	exportCmd.PersistentFlags().StringVar(&exportFromDir, "from", "", "Directory to export user stories from (default is docs/user-stories)")
+	exportCmd.PersistentFlags().StringVarP(&exportOutput, "output", "o", "", "File to write the export to (default is stdout)")
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// Output format of the commands with a --format flag
+var outputFormat string
+
+// addFormatFlag adds the --format flag to a command printing its result with
+// printResult
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&outputFormat, "format", string(io.FormatText), "Output format: text, json or table")
+}
+
+// printResult prints a command result in the format selected with --format
+func printResult(result io.FormattedResult, terminal io.UserOutput) {
+	format, err := io.ParseOutputFormat(outputFormat)
+	if err != nil {
+		terminal.PrintError(fmt.Sprintf("Error: --format: %s", err))
+		os.Exit(1)
+	}
+	if err := io.NewOutputFormatter(format, terminal, os.Stdout).Format(result); err != nil {
+		terminal.PrintError(fmt.Sprintf("Failed to print result: %s", err))
+		os.Exit(1)
+	}
+}
@@ -34,7 +34,7 @@ Example:
 	Run: func(cmd *cobra.Command, args []string) {
 		// Create filesystem and IO interfaces
 		fs := io.NewOSFileSystem()
-		terminal := io.NewTerminalIO()
+		terminal := newTerminalIO()
 
 		// Find incomplete change requests
 		incompleteChangeRequests, err := changerequest.FindIncomplete(fs)
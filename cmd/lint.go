@@ -0,0 +1,164 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+var (
+	// Directory to lint user stories from
+	lintFromDir string
+
+	// Only report stories lacking acceptance criteria
+	lintRequireAC bool
+)
+
+// storyProblems holds the validation problems found in a single user story
+type storyProblems struct {
+	FilePath string
+	Problems []error
+}
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check that user stories follow the expected structure",
+	Long: `Check that every user story contains the "As a ... I want ... so that ..."
+statement and at least one acceptance criterion, and that no two stories
+have identical content.
+
+The command exits with a non-zero status when any story has problems, so it
+can be used in CI or a pre-commit hook.
+
+Example:
+  usm lint
+  usm lint --from docs/user-stories/my-feature
+  usm lint --require-ac
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+
+		targetDir := config.UserStoriesDir()
+		if lintFromDir != "" {
+			targetDir = lintFromDir
+		}
+
+		if lintRequireAC {
+			lintAcceptanceCriteria(targetDir, fs, terminal)
+			return
+		}
+
+		results, total, err := lintUserStories(targetDir, fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to lint user stories: %s", err))
+			os.Exit(1)
+		}
+
+		for _, result := range results {
+			terminal.PrintWarning(result.FilePath)
+			for _, problem := range result.Problems {
+				terminal.Print(fmt.Sprintf("  - %s", problem))
+			}
+		}
+
+		duplicates, err := metadata.FindDuplicateContent(targetDir, fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to check for duplicate user stories: %s", err))
+			os.Exit(1)
+		}
+		groups := make([][]string, 0, len(duplicates))
+		for _, paths := range duplicates {
+			groups = append(groups, paths)
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+		for _, paths := range groups {
+			terminal.PrintWarning("Identical content, probably copy-pasted:")
+			for _, path := range paths {
+				terminal.Print(fmt.Sprintf("  - %s", path))
+			}
+		}
+
+		if len(results) > 0 || len(duplicates) > 0 {
+			if len(results) > 0 {
+				terminal.PrintError(fmt.Sprintf("%d of %d user stories have problems", len(results), total))
+			}
+			if len(duplicates) > 0 {
+				terminal.PrintError(fmt.Sprintf("%d groups of user stories share the same content", len(duplicates)))
+			}
+			os.Exit(1)
+		}
+		terminal.PrintSuccess(fmt.Sprintf("All %d user stories are well-formed", total))
+	},
+}
+
+// lintUserStories validates every user story below dir and returns the stories
+// with problems along with the number of stories checked
+func lintUserStories(dir string, fs io.FileSystem) ([]storyProblems, int, error) {
+	files, err := metadata.FindMarkdownFiles(dir, fs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []storyProblems
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Debug("Failed to read file: " + err.Error())
+			results = append(results, storyProblems{FilePath: file, Problems: []error{err}})
+			continue
+		}
+
+		story, err := models.LoadUserStoryFromFile(file, content)
+		if err != nil {
+			results = append(results, storyProblems{FilePath: file, Problems: []error{err}})
+			continue
+		}
+
+		if problems := models.ValidateUserStory(story); len(problems) > 0 {
+			results = append(results, storyProblems{FilePath: file, Problems: problems})
+		}
+	}
+
+	return results, len(files), nil
+}
+
+// lintAcceptanceCriteria reports the stories below dir without any acceptance
+// criteria and exits with a non-zero status if there are any
+func lintAcceptanceCriteria(dir string, fs io.FileSystem, terminal io.UserOutput) {
+	stories, err := metadata.FindStoriesWithoutAcceptanceCriteria(dir, fs)
+	if err != nil {
+		terminal.PrintError(fmt.Sprintf("Failed to lint user stories: %s", err))
+		os.Exit(1)
+	}
+
+	for _, story := range stories {
+		terminal.PrintWarning(fmt.Sprintf("%s (%s)", story.FilePath, story.Title))
+	}
+
+	if len(stories) > 0 {
+		terminal.PrintError(fmt.Sprintf("%d user stories have no acceptance criteria", len(stories)))
+		os.Exit(1)
+	}
+	terminal.PrintSuccess("All user stories have acceptance criteria")
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVar(&lintFromDir, "from", "", "Directory to lint user stories from (default is docs/user-stories)")
+	lintCmd.Flags().BoolVar(&lintRequireAC, "require-ac", false, "Only report user stories without acceptance criteria")
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestLintUserStories(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+	mockFS.AddDirectory("docs/user-stories")
+	mockFS.AddFile("docs/user-stories/01-good.md",
+		[]byte(models.GenerateUserStoryTemplate("Good", "docs/user-stories/01-good.md")))
+	mockFS.AddFile("docs/user-stories/02-bad.md", []byte("# Bad\n\nJust some notes.\n"))
+
+	results, total, err := lintUserStories("docs/user-stories", mockFS)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, "docs/user-stories/02-bad.md", results[0].FilePath)
+	assert.Equal(t, []error{models.ErrMissingUserStoryStatement, models.ErrMissingAcceptanceCriteria}, results[0].Problems)
+}
+
+func TestLintUserStories_MissingDirectory(t *testing.T) {
+	_, _, err := lintUserStories("docs/user-stories", io.NewMockFileSystem())
+
+	assert.Error(t, err)
+}
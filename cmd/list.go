@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/catalog"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
 	"github.com/user-story-matrix/usm/internal/models"
@@ -37,10 +40,10 @@ Example:
 	Run: func(cmd *cobra.Command, args []string) {
 		// Create filesystem and IO interfaces
 		fs := io.NewOSFileSystem()
-		terminal := io.NewTerminalIO()
+		terminal := newTerminalIO()
 		
 		// Get the target directory
-		targetDir := "docs/user-stories"
+		targetDir := config.UserStoriesDir()
 		if fromDir != "" {
 			targetDir = fromDir
 		}
@@ -107,12 +110,139 @@ Example:
 	},
 }
 
+// listTagsCmd represents the list tags command
+var listTagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List the tags used by user stories",
+	Long: `List every tag used by the user stories, with the number of stories
+carrying it, most used first. Tags are compared case-insensitively, like the
+tag: search filter.
+
+Example:
+  usm list tags
+  usm list tags --from docs/user-stories/my-feature
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+		
+		targetDir := config.UserStoriesDir()
+		if fromDir != "" {
+			targetDir = fromDir
+		}
+		
+		counts, err := catalog.CollectTags(targetDir, fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to collect tags: %s", err))
+			return
+		}
+		if len(counts) == 0 {
+			terminal.Print(fmt.Sprintf("No tags found in: %s", targetDir))
+			return
+		}
+		
+		rows := make([][]string, 0, len(counts))
+		for _, tag := range catalog.SortTags(counts) {
+			rows = append(rows, []string{tag, fmt.Sprintf("%d", counts[tag])})
+		}
+		terminal.PrintTable([]string{"Tag", "Stories"}, rows)
+	},
+}
+
+// listProgressCmd represents the list progress command
+var listProgressCmd = &cobra.Command{
+	Use:   "progress",
+	Short: "Show how many user stories are implemented and list the others",
+	Long: `Count the implemented user stories and list the ones left to do. A story is
+implemented when its front matter says so or when an implemented change
+request references it, as shown in the story picker.
+
+Example:
+  usm list progress
+  usm list progress --from docs/user-stories/my-feature
+  usm list progress --format json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+		
+		targetDir := config.UserStoriesDir()
+		if fromDir != "" {
+			targetDir = fromDir
+		}
+		
+		done, todo, err := catalog.ImplementationReport(targetDir, fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to read user stories: %s", err))
+			os.Exit(1)
+		}
+		printResult(progressResult{done: done, todo: todo}, terminal)
+	},
+}
+
+// progressResult prints an implementation report with the --format formatter
+type progressResult struct {
+	done []models.UserStory
+	todo []models.UserStory
+}
+
+// progressStoryJSON is the JSON schema of a story left to do in
+// usm list progress --format json
+type progressStoryJSON struct {
+	Title    string `json:"title"`
+	FilePath string `json:"file_path"`
+}
+
+// MarshalJSON encodes the counts and the stories left to do
+func (r progressResult) MarshalJSON() ([]byte, error) {
+	remaining := make([]progressStoryJSON, len(r.todo))
+	for i, story := range r.todo {
+		remaining[i] = progressStoryJSON{Title: story.Title, FilePath: story.FilePath}
+	}
+	return json.Marshal(struct {
+		Total       int                 `json:"total"`
+		Implemented int                 `json:"implemented"`
+		Remaining   []progressStoryJSON `json:"remaining"`
+	}{len(r.done) + len(r.todo), len(r.done), remaining})
+}
+
+// Table returns one row per story left to do
+func (r progressResult) Table() ([]string, [][]string) {
+	rows := make([][]string, len(r.todo))
+	for i, story := range r.todo {
+		rows[i] = []string{story.Title, story.FilePath}
+	}
+	return []string{"Title", "File"}, rows
+}
+
+// WriteText prints the counts followed by the stories left to do
+func (r progressResult) WriteText(terminal io.UserOutput) {
+	total := len(r.done) + len(r.todo)
+	if total == 0 {
+		terminal.Print("No user stories found")
+		return
+	}
+	
+	summary := fmt.Sprintf("%d of %d user stories implemented", len(r.done), total)
+	if len(r.todo) == 0 {
+		terminal.PrintSuccess(summary)
+		return
+	}
+	terminal.Print(fmt.Sprintf("%s, %d remaining:", summary, len(r.todo)))
+	terminal.PrintTable(r.Table())
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	
 	// Add user-stories subcommand
 	listCmd.AddCommand(listUserStoriesCmd)
+	listCmd.AddCommand(listTagsCmd)
+	listCmd.AddCommand(listProgressCmd)
 	
 	// Add flags
 	listUserStoriesCmd.Flags().StringVar(&fromDir, "from", "", "Directory to list user stories from (default is docs/user-stories)")
+	listTagsCmd.Flags().StringVar(&fromDir, "from", "", "Directory to list tags from (default is docs/user-stories)")
+	listProgressCmd.Flags().StringVar(&fromDir, "from", "", "Directory to report on (default is docs/user-stories)")
+	addFormatFlag(listProgressCmd)
 } 
\ No newline at end of file
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/implementation"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+// markCmd represents the mark command
+var markCmd = &cobra.Command{
+	Use:   "mark",
+	Short: "Change the status of user stories",
+	Long:  `Change the status recorded in the front matter of user stories.`,
+}
+
+// markImplementedCmd represents the mark implemented command
+var markImplementedCmd = &cobra.Command{
+	Use:   "implemented [user-story-file...]",
+	Short: "Mark user stories as implemented",
+	Long: `Mark user stories as implemented by setting "implemented: true" in their front matter.
+The story metadata is refreshed and the change requests referencing the stories are updated.
+Implemented stories are hidden from the change request picker unless --show-all is used.
+
+Example:
+  usm mark implemented docs/user-stories/auth/01-login.md
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runMark(args, implementation.MarkImplemented, "Marked as implemented")
+	},
+}
+
+// markUnimplementedCmd represents the mark unimplemented command
+var markUnimplementedCmd = &cobra.Command{
+	Use:   "unimplemented [user-story-file...]",
+	Short: "Remove the implemented mark from user stories",
+	Long: `Remove the "implemented" front-matter field set by "usm mark implemented".
+A story referenced by an implemented change request is still considered implemented.
+
+Example:
+  usm mark unimplemented docs/user-stories/auth/01-login.md
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runMark(args, implementation.UnmarkImplemented, "Removed implemented mark from")
+	},
+}
+
+// markReviewedCmd represents the mark reviewed command
+var markReviewedCmd = &cobra.Command{
+	Use:   "reviewed [user-story-file...]",
+	Short: "Refresh the last_updated date of user stories without changing them",
+	Long: `Set the last_updated date of user stories to now, to record that they were
+re-read and approved. The content, its hash and the other fields are kept,
+so change requests referencing the stories stay up to date.
+
+Example:
+  usm mark reviewed docs/user-stories/auth/01-login.md
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runMark(args, func(path, root string, fs io.FileSystem) error {
+			return metadata.TouchStory(path, fs)
+		}, "Marked as reviewed")
+	},
+}
+
+// runMark applies mark to every story and exits with a non-zero status if any failed
+func runMark(paths []string, mark func(string, string, io.FileSystem) error, done string) {
+	fs := io.NewOSFileSystem()
+	terminal := newTerminalIO()
+
+	failed := false
+	for _, path := range paths {
+		if err := mark(path, ".", fs); err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to update %s: %s", path, err))
+			failed = true
+			continue
+		}
+		terminal.PrintSuccess(fmt.Sprintf("%s %s", done, path))
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(markCmd)
+	markCmd.AddCommand(markImplementedCmd)
+	markCmd.AddCommand(markUnimplementedCmd)
+	markCmd.AddCommand(markReviewedCmd)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/changerequest"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// previewCmd represents the preview command
+var previewCmd = &cobra.Command{
+	Use:   "preview <change-request-file>",
+	Short: "Show the user stories a change request would affect",
+	Long: `List the user stories referenced by a change request, grouped by status:
+missing (the file is gone), changed (edited since it was referenced),
+pending and implemented.
+
+The command exits with a non-zero status when a story is missing or has
+changed, as the change request should then be reviewed before working on it.
+
+Example:
+  usm preview docs/changes-request/2025-03-17-login.blueprint.md
+  usm preview --format json docs/changes-request/2025-03-17-login.blueprint.md`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+
+		summary, err := changerequest.SummarizeChangeRequest(args[0], fs)
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to summarize change request: %s", err))
+			os.Exit(1)
+		}
+		printResult(previewResult{summary}, terminal)
+
+		if !summary.Ready() {
+			os.Exit(1)
+		}
+	},
+}
+
+// previewResult prints a change request summary with the --format formatter
+type previewResult struct {
+	summary changerequest.CRSummary
+}
+
+// previewStoryJSON is the JSON schema of a story in usm preview --format json
+type previewStoryJSON struct {
+	Title       string `json:"title"`
+	FilePath    string `json:"file_path"`
+	Status      string `json:"status"`
+	Implemented bool   `json:"implemented"`
+	HashCurrent bool   `json:"hash_current"`
+	Missing     bool   `json:"missing"`
+}
+
+// MarshalJSON encodes the summary with the status of each story
+func (r previewResult) MarshalJSON() ([]byte, error) {
+	stories := make([]previewStoryJSON, len(r.summary.Stories))
+	for i, story := range r.summary.Stories {
+		stories[i] = previewStoryJSON{
+			Title:       story.Title,
+			FilePath:    story.FilePath,
+			Status:      story.Status().String(),
+			Implemented: story.Implemented,
+			HashCurrent: story.HashCurrent,
+			Missing:     story.Missing,
+		}
+	}
+	return json.Marshal(struct {
+		Path    string             `json:"path"`
+		Name    string             `json:"name,omitempty"`
+		Ready   bool               `json:"ready"`
+		Stories []previewStoryJSON `json:"stories"`
+	}{r.summary.Path, r.summary.Name, r.summary.Ready(), stories})
+}
+
+// Table returns one row per referenced story
+func (r previewResult) Table() ([]string, [][]string) {
+	return previewTableHeaders, previewTableRows(r.summary)
+}
+
+// WriteText prints the referenced stories as a table followed by a one-line
+// verdict
+func (r previewResult) WriteText(terminal io.UserOutput) {
+	summary := r.summary
+	if summary.Name != "" {
+		terminal.Print(summary.Name)
+	}
+	if len(summary.Stories) == 0 {
+		terminal.PrintWarning(fmt.Sprintf("%s references no user stories", summary.Path))
+		return
+	}
+
+	terminal.PrintTable(previewTableHeaders, previewTableRows(summary))
+
+	counts := fmt.Sprintf("%d pending, %d implemented, %d changed, %d missing",
+		summary.Count(changerequest.StoryPending), summary.Count(changerequest.StoryImplemented),
+		summary.Count(changerequest.StoryChanged), summary.Count(changerequest.StoryMissing))
+	if summary.Ready() {
+		terminal.PrintSuccess("Ready to work on: " + counts)
+	} else {
+		terminal.PrintWarning("Review the change request first: " + counts)
+	}
+}
+
+var previewTableHeaders = []string{"Status", "Title", "File", "Implemented", "Hash"}
+
+// previewTableRows returns the table rows of the referenced stories
+func previewTableRows(summary changerequest.CRSummary) [][]string {
+	rows := make([][]string, len(summary.Stories))
+	for i, story := range summary.Stories {
+		implemented, hash := "no", "current"
+		if story.Implemented {
+			implemented = "yes"
+		}
+		switch {
+		case story.Missing:
+			implemented, hash = "-", "-"
+		case !story.HashCurrent:
+			hash = "outdated"
+		}
+		rows[i] = []string{story.Status().String(), story.Title, story.FilePath, implemented, hash}
+	}
+	return rows
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+	addFormatFlag(previewCmd)
+}
@@ -29,7 +29,7 @@ Example:
 	Run: func(cmd *cobra.Command, args []string) {
 		// Create filesystem and IO interfaces
 		fs := io.NewOSFileSystem()
-		terminal := io.NewTerminalIO()
+		terminal := newTerminalIO()
 
 		// Find incomplete change requests
 		incompleteChangeRequests, err := changerequest.FindIncomplete(fs)
@@ -84,8 +84,8 @@ func displayRecapMessage(term io.UserOutput, cr models.ChangeRequest) {
 	baseFilename := filepath.Base(cr.FilePath)
 	baseFilename = strings.TrimSuffix(baseFilename, ".blueprint.md")
 	
-	// Create the implementation filename
-	implementationFilename := fmt.Sprintf("docs/changes-request/%s.implementation.md", baseFilename)
+	// Create the implementation filename, next to the blueprint
+	implementationFilename := filepath.Join(filepath.Dir(cr.FilePath), baseFilename+".implementation.md")
 	
 	// Display the message
 	message := fmt.Sprintf("Recap what you did in a file in %s", implementationFilename)
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/changerequest"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+)
+
+// resumeCmd represents the resume command
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Execute the next step of the most recently worked on change request",
+	Long: `Find the change request whose implementation workflow was updated last and
+execute its next step, as 'usm code' would.
+
+Completed workflows are not resumed.
+
+Example:
+  usm resume`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		term := newTerminalIO()
+
+		changeRequestPath, err := changerequest.FindMostRecentChangeRequest(".", fs)
+		if errors.Is(err, changerequest.ErrNoActiveWorkflow) {
+			term.PrintError("No workflow in progress. Start one with: usm code <change-request-file>")
+			os.Exit(1)
+		}
+		if err != nil {
+			term.PrintError(fmt.Sprintf("Failed to find the most recent change request: %s", err))
+			os.Exit(1)
+		}
+
+		if term.Verbosity() >= io.VerbosityVerbose {
+			term.Print(fmt.Sprintf("Resuming %s", changeRequestPath))
+		}
+		codeCmd.Run(cmd, []string{changeRequestPath})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resumeCmd)
+	logger.Debug("Resume command added to root command")
+}
@@ -8,13 +8,25 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/ui/styles"
 )
 
 var (
-	debug bool
+	debug   bool
+	quiet   bool
+	verbose bool
+	// Never open forms, pickers or prompts; take every input from flags
+	nonInteractive bool
+	// Colors of the interactive screens, overriding USM_THEME and NO_COLOR
+	theme string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -33,6 +45,83 @@ any AI-powered coding assistant.`,
 		if debug {
 			logger.Debug("Debug mode enabled")
 		}
+		
+		// Write metadata timestamps in the layout chosen by the user
+		models.SetTimestampFormat(config.TimestampFormat())
+
+		// Write acceptance criteria with the bullets chosen by the user
+		style, err := models.ParseBulletStyle(config.BulletStyle())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", config.BulletStyleEnv, err)
+			os.Exit(1)
+		}
+		models.SetBulletStyle(style)
+
+		// Bump last_updated on the edits chosen by the user
+		policy, err := metadata.ParseLastUpdatedPolicy(config.LastUpdatedPolicy())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", config.LastUpdatedPolicyEnv, err)
+			os.Exit(1)
+		}
+		metadata.SetLastUpdatedPolicy(policy)
+
+		// Write metadata at the top or the bottom of files
+		placement, err := metadata.ParseMetadataPlacement(config.MetadataPlacement())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", config.MetadataPlacementEnv, err)
+			os.Exit(1)
+		}
+		metadata.SetMetadataPlacement(placement)
+
+		// Keep scans out of deeply nested directories when asked to
+		depth, err := metadata.ParseMaxScanDepth(config.MaxDepth())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", config.MaxDepthEnv, err)
+			os.Exit(1)
+		}
+		metadata.SetMaxScanDepth(depth)
+
+		// Pick the colors of the interactive screens: --theme, then USM_THEME,
+		// then no colors at all when NO_COLOR is set
+		themeSource, themeValue := "--theme", theme
+		if themeValue == "" {
+			themeSource, themeValue = config.ThemeEnv, config.Theme()
+		}
+		selectedTheme, err := styles.ParseTheme(themeValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", themeSource, err)
+			os.Exit(1)
+		}
+		if themeValue == "" && config.NoColor() {
+			selectedTheme = styles.MonochromeTheme
+		}
+		styles.SetTheme(selectedTheme)
+
+		// Checkbox glyphs of the story picker, for fonts without "✓"
+		checkbox, err := styles.ParseCheckboxGlyphs(config.Checkbox())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", config.CheckboxEnv, err)
+			os.Exit(1)
+		}
+		styles.SetCheckboxGlyphs(checkbox)
+
+		// Only descend into symbolic links to directories when asked to
+		if value := config.FollowSymlinks(); value != "" {
+			follow, err := strconv.ParseBool(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s: invalid value %q, use true or false\n", config.FollowSymlinksEnv, value)
+				os.Exit(1)
+			}
+			metadata.SetFollowSymlinks(follow)
+		}
+		if value := config.SafeMode(); value != "" {
+			safe, err := strconv.ParseBool(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s: invalid value %q, use true or false\n", config.SafeModeEnv, value)
+				os.Exit(1)
+			}
+			metadata.SetSafeMode(safe)
+		}
 	},
 }
 
@@ -49,7 +138,17 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// newTerminalIO creates the terminal output for a command at the verbosity
+// selected by the --quiet, --verbose and --debug flags
+func newTerminalIO() *io.TerminalIO {
+	return io.NewTerminalIOWithVerbosity(io.VerbosityFromFlags(quiet, verbose, debug))
+}
+
 func init() {
 	// Add persistent flags that will be available to all commands
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug mode with verbose logging")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only show primary output, warnings and errors")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show progress messages")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Take every input from flags instead of forms, pickers and prompts, for scripts")
+	rootCmd.PersistentFlags().StringVar(&theme, "theme", "", "Colors of the interactive screens: default, high-contrast or monochrome")
 } 
\ No newline at end of file
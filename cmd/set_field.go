@@ -0,0 +1,95 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/ui"
+)
+
+// setFieldCmd represents the set-field command
+var setFieldCmd = &cobra.Command{
+	Use:   "set-field <key> <value> [user-story-file...]",
+	Short: "Set a front-matter field on several user stories",
+	Long: `Add or update a custom front-matter field on each given user story, keeping
+its other fields, and refresh the story metadata. The fields managed by usm
+(file_path, created_at, last_updated, _content_hash) cannot be set.
+
+Without story files, pick them in the selection UI. With --non-interactive,
+the stories matching the --select search query are used instead.
+
+Example:
+  usm set-field team security docs/user-stories/auth/*.md
+  usm set-field priority high
+  usm set-field --non-interactive --select "tag:auth" team security
+`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		fs := io.NewOSFileSystem()
+		terminal := newTerminalIO()
+		key, value, paths := args[0], args[1], args[2:]
+
+		// Pick the stories when none are given
+		if len(paths) == 0 {
+			if nonInteractive && setFieldSelect == "" {
+				terminal.PrintError("Give the user story files, or --select, with --non-interactive")
+				os.Exit(1)
+			}
+			stories, err := ui.LoadStoriesFromDirs([]string{config.UserStoriesDir()}, fs)
+			if err != nil {
+				terminal.PrintError(fmt.Sprintf("Failed to load user stories: %s", err))
+				os.Exit(1)
+			}
+			if nonInteractive {
+				for _, i := range ui.SelectMatching(stories, setFieldSelect, true, 0) {
+					paths = append(paths, stories[i].FilePath)
+				}
+				if len(paths) == 0 {
+					terminal.Print(fmt.Sprintf("No user story matches %q", setFieldSelect))
+					return
+				}
+			} else {
+				selected, cancelled, err := ui.RunSelection(stories, true)
+				if err != nil {
+					terminal.PrintError(fmt.Sprintf("Failed to run selection UI: %s", err))
+					os.Exit(1)
+				}
+				if cancelled {
+					return
+				}
+				for _, story := range selected {
+					paths = append(paths, story.FilePath)
+				}
+			}
+		}
+
+		changed, err := metadata.SetFieldForStories(paths, key, value, fs)
+		for _, path := range changed {
+			terminal.PrintSuccess(fmt.Sprintf("Set %s: %s in %s", key, value, path))
+		}
+		if len(changed) == 0 && err == nil {
+			terminal.Print(fmt.Sprintf("No user story needed %s: %s", key, value))
+		}
+		if err != nil {
+			terminal.PrintError(fmt.Sprintf("Failed to set %s: %s", key, err))
+			os.Exit(1)
+		}
+	},
+}
+
+// setFieldSelect is the search query choosing the stories with --non-interactive
+var setFieldSelect string
+
+func init() {
+	rootCmd.AddCommand(setFieldCmd)
+	setFieldCmd.Flags().StringVar(&setFieldSelect, "select", "", "With --non-interactive, set the field on the user stories matching this search query")
+}
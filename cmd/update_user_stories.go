@@ -6,16 +6,22 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
 	"github.com/user-story-matrix/usm/internal/metadata"
 	"github.com/user-story-matrix/usm/internal/ui/styles"
+	"github.com/user-story-matrix/usm/internal/utils"
 	"go.uber.org/zap"
 )
 
@@ -35,6 +41,17 @@ and ensures each has an up-to-date metadata section containing:
 By default, it also updates content hash references in change request files when user story
 content changes. Use the --skip-references flag to disable this behavior.
 
+Use the --watch flag to keep the command running and update each user story shortly
+after it is saved, together with the change request references to it.
+
+Use the --staged flag in a git pre-commit hook to update only the user stories staged
+for the commit, and the change request references to them. Stage the updated files
+again before the commit proceeds. Outside a git repository, every user story is updated.
+
+A user story that cannot be read or written is skipped, and the stories that failed are
+listed at the end. Use the --fail-fast flag to stop at the first one instead and exit with
+an error; the change request references to the stories already updated are still updated.
+
 Directories like node_modules, .git, dist, build, vendor, tmp, .cache, and .github are automatically skipped.
 
 The command preserves original creation dates if they exist, and only updates last_updated dates
@@ -69,15 +86,14 @@ when content has actually changed, making it safe to run as part of automated wo
 		}
 		if testRoot != "" {
 			// For testing, use the specified directory
-			userStoriesDir = filepath.Join(testRoot, "docs", "user-stories")
+			userStoriesDir = config.UserStoriesDirIn(testRoot)
 			logger.Debug("Using test root directory",
 				zap.String("test_root", testRoot),
 				zap.String("user_stories_dir", userStoriesDir))
 			root = testRoot
 		} else {
 			// Normal operation: use current directory
-			docsDir := filepath.Join(root, "docs")
-			userStoriesDir = filepath.Join(docsDir, "user-stories")
+			userStoriesDir = config.UserStoriesDirIn(root)
 		}
 		
 		// Verify user stories directory exists
@@ -89,17 +105,49 @@ when content has actually changed, making it safe to run as part of automated wo
 			zap.String("dir", userStoriesDir),
 			zap.String("root", root))
 		
-		// Update all user story metadata
-		updatedFiles, unchangedFiles, hashMap, err := metadata.UpdateAllUserStoryMetadata(userStoriesDir, root, fs)
-		if err != nil {
-			return fmt.Errorf("failed to update user story metadata: %w", err)
+		// Stop scanning promptly when the user interrupts the command
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		
+		// Only update the stories about to be committed, when asked and possible
+		if staged, _ := cmd.Flags().GetBool("staged"); staged {
+			updatedStaged, stagedHashMap, updatedRefs, err := metadata.UpdateStagedMetadata(root, fs)
+			if err == nil {
+				if len(updatedStaged) > 0 {
+					fmt.Println("📋 Updated staged user story metadata:")
+					headers, rows := utils.FormatContentChangeTable(stagedHashMap)
+					newTerminalIO().PrintTable(headers, rows)
+				} else {
+					fmt.Println("📋 No staged user story files needed updating")
+				}
+				if len(updatedRefs) > 0 {
+					fmt.Println("✅ Updated references in these change requests:")
+					printGroupedFiles(updatedRefs, "  ")
+				}
+				return nil
+			}
+			if !errors.Is(err, metadata.ErrNotGitRepository) {
+				return fmt.Errorf("failed to update staged user story metadata: %w", err)
+			}
+			fmt.Println("ℹ️ Not a git repository, updating all user stories")
 		}
 		
+		// Update all user story metadata
+		// On an error, the stories already rewritten keep their new hashes, so
+		// the references to them are still updated before returning it
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		result, updateErr := metadata.UpdateAllUserStoryMetadataDetailed(ctx, userStoriesDir, root, fs, !failFast)
+		updatedFiles, unchangedFiles, hashMap := result.UpdatedFiles, result.UnchangedFiles, result.HashMap
+		
 		// Print summary of user story updates
 		if len(updatedFiles) > 0 {
 			fmt.Println("📋 Updated user story metadata:")
-			// Group files by directory for better readability
-			printGroupedFiles(updatedFiles, "  ")
+			headers, rows := utils.FormatContentChangeTable(hashMap)
+			newTerminalIO().PrintTable(headers, rows)
 		} else {
 			fmt.Println("📋 No user story files needed updating")
 		}
@@ -130,21 +178,44 @@ when content has actually changed, making it safe to run as part of automated wo
 				fmt.Println("🔄 Updating references in change requests...")
 				
 				// Update change request references
-				updatedRefs, unchangedRefs, referencesUpdated, mismatchedReferences, err = metadata.UpdateAllChangeRequestReferences(root, changedHashMap, fs)
+				refResult, err := metadata.UpdateAllChangeRequestReferencesDetailed(root, changedHashMap, fs)
 				if err != nil {
 					return fmt.Errorf("failed to update change request references: %w", err)
 				}
+				updatedRefs, unchangedRefs = refResult.UpdatedFiles, refResult.UnchangedFiles
+				referencesUpdated, mismatchedReferences = refResult.ReferencesUpdated, refResult.Mismatched
 				
 				// Print mismatched references with nice formatting
 				if len(mismatchedReferences) > 0 {
 					printMismatchedReferences(mismatchedReferences)
 				}
+
+				if len(refResult.Locked) > 0 {
+					fmt.Println("🔒 Skipped these locked change requests, unlock them with 'usm code --unlock' to update their references:")
+					for _, path := range refResult.Locked {
+						fmt.Printf("   - %s\n", path)
+					}
+				}
 				
 				// Print summary of reference updates
 				if len(updatedRefs) > 0 {
 					fmt.Println("✅ Updated references in these change requests:")
-					printGroupedFiles(updatedRefs, "  ")
+					headers, rows := utils.FormatReferenceCountTable(refResult.ReferencesPerFile)
+					term := newTerminalIO()
+					term.PrintTable(headers, rows)
 					fmt.Printf("   📊 Total references updated: %d\n", referencesUpdated)
+
+					// Tell reviewers which stories changed, not just their hashes
+					if term.Verbosity() >= io.VerbosityVerbose {
+						changeRequest := ""
+						for _, change := range refResult.Changes {
+							if change.ChangeRequest != changeRequest {
+								changeRequest = change.ChangeRequest
+								term.Print(fmt.Sprintf("   %s:", changeRequest))
+							}
+							term.Print("     - " + utils.FormatReferenceChange(change))
+						}
+					}
 				} else {
 					fmt.Println("ℹ️ No change requests needed reference updates")
 				}
@@ -172,10 +243,43 @@ when content has actually changed, making it safe to run as part of automated wo
 				referencesUpdated)
 		}
 		
+		// List the stories that were skipped
+		if len(result.Failed) > 0 {
+			printFailedFiles(result.Failed)
+		}
+		if updateErr != nil {
+			return fmt.Errorf("failed to update user story metadata: %w", updateErr)
+		}
+		
+		// Keep updating metadata as stories are saved until interrupted
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			fmt.Printf("\n👀 Watching %s for changes (press Ctrl+C to stop)\n", userStoriesDir)
+			if err := metadata.WatchAndUpdate(userStoriesDir, root, fs, ctx.Done()); err != nil {
+				return fmt.Errorf("failed to watch user stories: %w", err)
+			}
+		}
+		
 		return nil
 	},
 }
 
+// printFailedFiles prints the user stories that could not be updated, sorted by path
+func printFailedFiles(failed map[string]error) {
+	paths := make([]string, 0, len(failed))
+	for path := range failed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	rows := make([][]string, len(paths))
+	for i, path := range paths {
+		rows[i] = []string{path, failed[path].Error()}
+	}
+	term := newTerminalIO()
+	term.PrintWarning(fmt.Sprintf("⚠️ %d user stories could not be updated:", len(paths)))
+	term.PrintTable([]string{"File", "Error"}, rows)
+}
+
 // printMismatchedReferences prints a nicely formatted list of mismatched references
 func printMismatchedReferences(mismatchedRefs []metadata.MismatchedReference) {
 	if len(mismatchedRefs) == 0 {
@@ -260,6 +364,11 @@ func init() {
 	// Add flags
 	updateUserStoriesCmd.Flags().Bool("skip-references", false, "Skip updating references in change request files")
 	updateUserStoriesCmd.Flags().Bool("debug", false, "Enable debug mode with detailed logging")
+	updateUserStoriesCmd.Flags().Bool("watch", false, "Keep running and update metadata whenever a user story is saved")
+	updateUserStoriesCmd.Flags().Bool("staged", false, "Only update the user stories staged in git, e.g. from a pre-commit hook")
+	updateUserStoriesCmd.Flags().Bool("fail-fast", false, "Stop at the first user story that cannot be updated, instead of skipping it")
+	updateUserStoriesCmd.MarkFlagsMutuallyExclusive("staged", "skip-references")
+	updateUserStoriesCmd.MarkFlagsMutuallyExclusive("staged", "watch")
 	
 	// Hidden flag for testing
 	updateUserStoriesCmd.Flags().String("test-root", "", "Test root directory (for testing only)")
@@ -280,6 +389,11 @@ func resetUpdateUserStoriesCmd() {
 	// Add flags
 	updateUserStoriesCmd.Flags().Bool("skip-references", false, "Skip updating references in change request files")
 	updateUserStoriesCmd.Flags().Bool("debug", false, "Enable debug mode with detailed logging")
+	updateUserStoriesCmd.Flags().Bool("watch", false, "Keep running and update metadata whenever a user story is saved")
+	updateUserStoriesCmd.Flags().Bool("staged", false, "Only update the user stories staged in git, e.g. from a pre-commit hook")
+	updateUserStoriesCmd.Flags().Bool("fail-fast", false, "Stop at the first user story that cannot be updated, instead of skipping it")
+	updateUserStoriesCmd.MarkFlagsMutuallyExclusive("staged", "skip-references")
+	updateUserStoriesCmd.MarkFlagsMutuallyExclusive("staged", "watch")
 	
 	// Hidden flag for testing
 	updateUserStoriesCmd.Flags().String("test-root", "", "Test root directory (for testing only)")
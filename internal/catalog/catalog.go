@@ -0,0 +1,160 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package catalog exports the user stories of a directory for external tools.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"sort"
+	"time"
+
+	"github.com/user-story-matrix/usm/internal/implementation"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+)
+
+// SchemaVersion is the version of the exported catalog format. It changes
+// only when a field is renamed or removed, never when one is added.
+const SchemaVersion = 1
+
+// Catalog is the exported list of user stories
+type Catalog struct {
+	SchemaVersion int     `json:"schema_version"`
+	Stories       []Entry `json:"stories"`
+}
+
+// Entry describes one user story of the catalog
+type Entry struct {
+	Title              string   `json:"title"`
+	FilePath           string   `json:"file_path"`
+	Description        string   `json:"description"`
+	AcceptanceCriteria []string `json:"acceptance_criteria"`
+	Tags               []string `json:"tags"`
+	Implemented        bool     `json:"implemented"`
+	ContentHash        string   `json:"content_hash"`
+	CreatedAt          string   `json:"created_at"`   // RFC3339, empty when unknown
+	LastUpdated        string   `json:"last_updated"` // RFC3339, empty when unknown
+}
+
+// ExportCatalog scans the user stories below dir and returns them as an
+// indented JSON catalog, sorted by file path. Files that cannot be read or
+// parsed are skipped.
+func ExportCatalog(dir string, fs io.FileSystem) ([]byte, error) {
+	catalog, err := BuildCatalog(dir, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// BuildCatalog scans the user stories below dir into a catalog sorted by file
+// path. A story is implemented when its front matter says so or when an
+// implemented change request references it.
+func BuildCatalog(dir string, fs io.FileSystem) (Catalog, error) {
+	catalog := Catalog{SchemaVersion: SchemaVersion, Stories: []Entry{}}
+
+	err := StreamCatalog(dir, fs, func(story models.UserStory) error {
+		catalog.Stories = append(catalog.Stories, newEntry(story))
+		return nil
+	})
+	if err != nil {
+		return catalog, err
+	}
+
+	sort.Slice(catalog.Stories, func(i, j int) bool {
+		return catalog.Stories[i].FilePath < catalog.Stories[j].FilePath
+	})
+	return catalog, nil
+}
+
+// StreamCatalog parses the user stories below dir one at a time, in file
+// path order, and passes each to emit, so that large catalogs can be
+// exported without holding every story in memory. Implementation status is
+// resolved as for BuildCatalog. Files that cannot be read or parsed are
+// skipped; an error returned by emit stops the scan and is returned.
+func StreamCatalog(dir string, fs io.FileSystem, emit func(models.UserStory) error) error {
+	files, err := metadata.FindMarkdownFiles(dir, fs)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Debug("Failed to read user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+
+		story, err := models.LoadUserStoryFromFile(file, content)
+		if err != nil {
+			logger.Debug("Failed to parse user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+		if err := implementation.UpdateImplementationStatus(&story, fs); err != nil {
+			logger.Debug("Failed to check implementation status", zap.String("file", file), zap.Error(err))
+		}
+
+		if err := emit(story); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCatalogNDJSON streams the user stories below dir to w as
+// newline-delimited JSON, one catalog entry per line, in file path order
+func WriteCatalogNDJSON(dir string, fs io.FileSystem, w stdio.Writer) error {
+	encoder := json.NewEncoder(w)
+	return StreamCatalog(dir, fs, func(story models.UserStory) error {
+		if err := encoder.Encode(newEntry(story)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", story.FilePath, err)
+		}
+		return nil
+	})
+}
+
+// newEntry converts a parsed user story into a catalog entry
+func newEntry(story models.UserStory) Entry {
+	entry := Entry{
+		Title:              story.Title,
+		FilePath:           story.FilePath,
+		Description:        story.Description,
+		AcceptanceCriteria: story.AcceptanceCriteria(),
+		Tags:               story.Tags,
+		Implemented:        story.IsImplemented,
+		ContentHash:        story.ContentHash,
+		CreatedAt:          formatDate(story.CreatedAt),
+		LastUpdated:        formatDate(story.LastUpdated),
+	}
+
+	// Empty lists are exported as [] rather than null
+	if entry.AcceptanceCriteria == nil {
+		entry.AcceptanceCriteria = []string{}
+	}
+	if entry.Tags == nil {
+		entry.Tags = []string{}
+	}
+	return entry
+}
+
+// formatDate formats t as RFC3339, whatever timestamp format the stories use
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+const loginStory = `---
+file_path: docs/user-stories/01-login.md
+created_at: 2025-03-17T12:00:00Z
+last_updated: 2025-03-18T08:30:00Z
+_content_hash: abc123
+tags: [auth, security]
+implemented: true
+---
+
+# Login
+
+As a user, I want to log in, so that I can see my data.
+
+## Acceptance criteria
+
+- Valid credentials open the dashboard
+- Invalid credentials show an error
+`
+
+func newCatalogFS() *io.MockFileSystem {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/02-export.md", []byte("# Export user data to CSV\n\nAs an admin, I want a CSV export.\n"))
+	fs.AddFile("docs/user-stories/01-login.md", []byte(loginStory))
+	fs.AddFile("docs/user-stories/notes.txt", []byte("not a story"))
+	return fs
+}
+
+func TestBuildCatalog(t *testing.T) {
+	catalog, err := BuildCatalog("docs/user-stories", newCatalogFS())
+	require.NoError(t, err)
+
+	assert.Equal(t, SchemaVersion, catalog.SchemaVersion)
+	require.Len(t, catalog.Stories, 2)
+
+	assert.Equal(t, Entry{
+		Title:              "Login",
+		FilePath:           "docs/user-stories/01-login.md",
+		Description:        "As a user, I want to log in, so that I can see my data.",
+		AcceptanceCriteria: []string{"Valid credentials open the dashboard", "Invalid credentials show an error"},
+		Tags:               []string{"auth", "security"},
+		Implemented:        true,
+		ContentHash:        "abc123",
+		CreatedAt:          "2025-03-17T12:00:00Z",
+		LastUpdated:        "2025-03-18T08:30:00Z",
+	}, catalog.Stories[0])
+
+	export := catalog.Stories[1]
+	assert.Equal(t, "Export user data to CSV", export.Title)
+	assert.False(t, export.Implemented)
+	assert.Empty(t, export.CreatedAt)
+	assert.Equal(t, []string{}, export.AcceptanceCriteria)
+}
+
+func TestExportCatalog(t *testing.T) {
+	data, err := ExportCatalog("docs/user-stories", newCatalogFS())
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, float64(SchemaVersion), decoded["schema_version"])
+
+	stories := decoded["stories"].([]interface{})
+	require.Len(t, stories, 2)
+	first := stories[0].(map[string]interface{})
+	for _, field := range []string{"title", "file_path", "description", "acceptance_criteria", "tags", "implemented", "content_hash", "created_at", "last_updated"} {
+		assert.Contains(t, first, field)
+	}
+	assert.Equal(t, []interface{}{}, stories[1].(map[string]interface{})["tags"])
+}
+
+func TestExportCatalog_MissingDirectory(t *testing.T) {
+	_, err := ExportCatalog("docs/user-stories", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
+
+func TestStreamCatalog(t *testing.T) {
+	var paths []string
+	err := StreamCatalog("docs/user-stories", newCatalogFS(), func(story models.UserStory) error {
+		paths = append(paths, story.FilePath)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/user-stories/01-login.md", "docs/user-stories/02-export.md"}, paths)
+}
+
+func TestStreamCatalog_EmitError(t *testing.T) {
+	errStop := errors.New("stop")
+	emitted := 0
+	err := StreamCatalog("docs/user-stories", newCatalogFS(), func(story models.UserStory) error {
+		emitted++
+		return errStop
+	})
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, emitted, "no story should be parsed after emit fails")
+}
+
+func TestWriteCatalogNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCatalogNDJSON("docs/user-stories", newCatalogFS(), &buf))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "Login", entry.Title)
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "Export user data to CSV", entry.Title)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package catalog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// csvHeader lists the columns of the CSV export, in order
+var csvHeader = []string{"title", "path", "implemented", "created_at", "last_updated", "acceptance_criteria_count"}
+
+// ExportCatalogCSV scans the user stories below dir and returns them as CSV,
+// one row per story after a header row, sorted by file path. Fields are
+// quoted as described in RFC 4180 and rows end with CRLF, so titles holding
+// commas or quotes open correctly in spreadsheets.
+func ExportCatalogCSV(dir string, fs io.FileSystem) ([]byte, error) {
+	catalog, err := BuildCatalog(dir, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = true
+
+	if err := w.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, entry := range catalog.Stories {
+		row := []string{
+			entry.Title,
+			entry.FilePath,
+			strconv.FormatBool(entry.Implemented),
+			entry.CreatedAt,
+			entry.LastUpdated,
+			strconv.Itoa(len(entry.AcceptanceCriteria)),
+		}
+		if err := w.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row for %s: %w", entry.FilePath, err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package catalog
+
+import (
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// ImplementationReport splits the user stories below dir into the implemented
+// ones and the ones left to do, each in file path order. Implementation status
+// is resolved as for StreamCatalog, so it matches what the story picker shows.
+func ImplementationReport(dir string, fs io.FileSystem) (done, todo []models.UserStory, err error) {
+	err = StreamCatalog(dir, fs, func(story models.UserStory) error {
+		if story.IsImplemented {
+			done = append(done, story)
+		} else {
+			todo = append(todo, story)
+		}
+		return nil
+	})
+	return done, todo, err
+}
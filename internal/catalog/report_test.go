@@ -0,0 +1,34 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestImplementationReport(t *testing.T) {
+	fs := newCatalogFS()
+	fs.AddFile("docs/user-stories/03-logout.md", []byte("# Logout\n"))
+
+	done, todo, err := ImplementationReport("docs/user-stories", fs)
+	require.NoError(t, err)
+
+	require.Len(t, done, 1)
+	assert.Equal(t, "Login", done[0].Title)
+
+	require.Len(t, todo, 2)
+	assert.Equal(t, "Export user data to CSV", todo[0].Title)
+	assert.Equal(t, "Logout", todo[1].Title)
+}
+
+func TestImplementationReport_MissingDirectory(t *testing.T) {
+	_, _, err := ImplementationReport("docs/missing", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
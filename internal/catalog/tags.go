@@ -0,0 +1,54 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package catalog
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// CollectTags returns every tag used by the user stories below dir with the
+// number of stories carrying it. Tags are matched case-insensitively, as by
+// the "tag:" search filter, so they are counted lowercased. Files that cannot
+// be read or parsed are skipped, as for StreamCatalog.
+func CollectTags(dir string, fs io.FileSystem) (map[string]int, error) {
+	counts := make(map[string]int)
+	err := StreamCatalog(dir, fs, func(story models.UserStory) error {
+		seen := make(map[string]bool, len(story.Tags))
+		for _, tag := range story.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			counts[tag]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// SortTags lists the tags of counts, most used first and alphabetically
+// among tags used equally often
+func SortTags(counts map[string]int) []string {
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestCollectTags(t *testing.T) {
+	fs := newCatalogFS()
+	fs.AddFile("docs/user-stories/03-logout.md", []byte("---\ntags:\n  - Auth\n  - ux\n  - auth\n---\n\n# Logout\n"))
+
+	counts, err := CollectTags("docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"auth": 2, "security": 1, "ux": 1}, counts)
+}
+
+func TestCollectTags_MissingDirectory(t *testing.T) {
+	_, err := CollectTags("docs/user-stories", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
+
+func TestSortTags(t *testing.T) {
+	assert.Equal(t, []string{"auth", "security", "ux"}, SortTags(map[string]int{"ux": 1, "auth": 2, "security": 1}))
+	assert.Empty(t, SortTags(nil))
+}
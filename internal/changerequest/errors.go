@@ -13,4 +13,6 @@ import (
 var (
 	ErrDirectoryNotFound = errors.New("change requests directory not found")
 	ErrReadDirectory     = errors.New("failed to read directory")
+	ErrDestinationExists = errors.New("destination file already exists")
+	ErrNoActiveWorkflow  = errors.New("no change request has a workflow in progress")
 ) 
\ No newline at end of file
@@ -5,23 +5,42 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
 // FindIncomplete finds all change requests that have a blueprint file
-// but no implementation file
+// but no implementation file, in every change requests directory. It returns
+// an error when none of the directories exists.
 func FindIncomplete(fs io.FileSystem) ([]models.ChangeRequest, error) {
 	var incompleteChangeRequests []models.ChangeRequest
+	found := false
 
-	// Define the change requests directory
-	changeRequestsDir := "docs/changes-request"
+	for _, changeRequestsDir := range config.ChangesDirs() {
+		if !fs.Exists(changeRequestsDir) {
+			continue
+		}
+		found = true
+
+		changeRequests, err := findIncompleteIn(changeRequestsDir, fs)
+		if err != nil {
+			return incompleteChangeRequests, err
+		}
+		incompleteChangeRequests = append(incompleteChangeRequests, changeRequests...)
+	}
 
-	// Check if the directory exists
-	if !fs.Exists(changeRequestsDir) {
-		return incompleteChangeRequests, fmt.Errorf("change requests directory not found: %s", changeRequestsDir)
+	if !found {
+		return incompleteChangeRequests, fmt.Errorf("change requests directory not found: %s", strings.Join(config.ChangesDirs(), ", "))
 	}
+	return incompleteChangeRequests, nil
+}
+
+// findIncompleteIn finds the incomplete change requests of one existing
+// change requests directory
+func findIncompleteIn(changeRequestsDir string, fs io.FileSystem) ([]models.ChangeRequest, error) {
+	var incompleteChangeRequests []models.ChangeRequest
 
 	// Get all files in the directory
 	entries, err := fs.ReadDir(changeRequestsDir)
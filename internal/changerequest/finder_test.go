@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/models"
 )
@@ -82,6 +83,20 @@ Test content
 	assert.Equal(t, "Test Change Request", result[0].Name)
 }
 
+func TestFindIncomplete_SeveralDirectories(t *testing.T) {
+	t.Setenv(config.ChangesDirEnv, "teams/billing:teams/search")
+	mockFS := io.NewMockFileSystem()
+
+	// Only the second directory exists
+	mockFS.AddFile("teams/search/filters.blueprint.md", []byte("---\nname: Filters\ncreated-at: 2023-01-01T00:00:00Z\n---\n\n# Blueprint\n"))
+
+	result, err := FindIncomplete(mockFS)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Filters", result[0].Name)
+}
+
 func TestFormatDescription(t *testing.T) {
 	// Create a test change request
 	cr := models.ChangeRequest{
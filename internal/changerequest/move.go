@@ -0,0 +1,125 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/workflow"
+)
+
+// markdownLinkRegex matches the target of inline markdown links and images,
+// e.g. "(../user-stories/login.md#criteria)" in "[Login](../user-stories/login.md#criteria)"
+var markdownLinkRegex = regexp.MustCompile(`\]\(([^)\s]+)((?:\s+"[^"]*")?)\)`)
+
+// fileMove is a single file to relocate as part of a change request move
+type fileMove struct {
+	from string
+	to   string
+}
+
+// MoveChangeRequest moves a change request blueprint to newPath together with
+// its workflow state file and every generated step output. Relative links in
+// the blueprint body are rewritten so they still resolve from the new
+// location. Nothing is moved if any destination file already exists.
+func MoveChangeRequest(oldPath, newPath string, fs io.FileSystem) error {
+	if !fs.Exists(oldPath) {
+		return fmt.Errorf("change request not found: %s", oldPath)
+	}
+
+	moves := []fileMove{{from: oldPath, to: newPath}}
+	if state := workflow.GenerateStateFilePath(oldPath); fs.Exists(state) {
+		moves = append(moves, fileMove{from: state, to: workflow.GenerateStateFilePath(newPath)})
+	}
+	for _, step := range workflow.StandardWorkflowSteps {
+		if output := workflow.GenerateOutputFilePath(oldPath, step); fs.Exists(output) {
+			moves = append(moves, fileMove{from: output, to: workflow.GenerateOutputFilePath(newPath, step)})
+		}
+	}
+
+	// Check every destination first so a collision leaves the tree untouched
+	for _, move := range moves {
+		if fs.Exists(move.to) {
+			return fmt.Errorf("%w: %s", ErrDestinationExists, move.to)
+		}
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	for i, move := range moves {
+		content, err := fs.ReadFile(move.from)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", move.from, err)
+		}
+
+		switch {
+		case i == 0:
+			content = []byte(RewriteRelativeLinks(string(content), filepath.Dir(oldPath), filepath.Dir(newPath)))
+		case move.from == workflow.GenerateStateFilePath(oldPath):
+			content = retargetState(content, newPath)
+		}
+
+		info, err := fs.Stat(move.from)
+		if err != nil {
+			return fmt.Errorf("failed to get file info for %s: %w", move.from, err)
+		}
+		if err := fs.WriteFile(move.to, content, info.Mode()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", move.to, err)
+		}
+		if err := fs.Remove(move.from); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", move.from, err)
+		}
+	}
+
+	return nil
+}
+
+// RewriteRelativeLinks rewrites the relative markdown link targets in content
+// so that links written from oldDir resolve to the same files from newDir.
+// Absolute paths, URLs and same-document anchors are left untouched.
+func RewriteRelativeLinks(content, oldDir, newDir string) string {
+	return markdownLinkRegex.ReplaceAllStringFunc(content, func(link string) string {
+		match := markdownLinkRegex.FindStringSubmatch(link)
+		target, title := match[1], match[2]
+
+		path, fragment := target, ""
+		if i := strings.Index(target, "#"); i >= 0 {
+			path, fragment = target[:i], target[i:]
+		}
+		if path == "" || filepath.IsAbs(path) || strings.Contains(path, ":") {
+			return link
+		}
+
+		rel, err := filepath.Rel(newDir, filepath.Join(oldDir, path))
+		if err != nil {
+			return link
+		}
+		return "](" + filepath.ToSlash(rel) + fragment + title + ")"
+	})
+}
+
+// retargetState points a saved workflow state at the new change request path,
+// leaving the state untouched if it cannot be parsed
+func retargetState(data []byte, newPath string) []byte {
+	var state workflow.WorkflowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return data
+	}
+	state.ChangeRequestPath = newPath
+
+	updated, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return data
+	}
+	return updated
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/workflow"
+)
+
+const movedBlueprint = `# Login
+
+See [the story](../user-stories/01-login.md#acceptance-criteria),
+![diagram](diagram.png "Flow"), [docs](https://example.com/docs.md)
+and [below](#notes).
+`
+
+func TestMoveChangeRequest(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+	oldPath := "docs/changes-request/login.blueprint.md"
+	newPath := "docs/changes-request/auth/login.blueprint.md"
+
+	mockFS.AddFile(oldPath, []byte(movedBlueprint))
+	mockFS.AddFile("docs/changes-request/.login.blueprint.md.step",
+		[]byte(`{"ChangeRequestPath": "docs/changes-request/login.blueprint.md", "CurrentStepIndex": 1}`))
+	mockFS.AddFile("docs/changes-request/login.01-laying-the-foundation.md", []byte("foundation"))
+
+	err := MoveChangeRequest(oldPath, newPath, mockFS)
+	require.NoError(t, err)
+
+	// The blueprint moved and its relative links still resolve
+	assert.False(t, mockFS.Exists(oldPath))
+	content, err := mockFS.ReadFile(newPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "[the story](../../user-stories/01-login.md#acceptance-criteria)")
+	assert.Contains(t, string(content), `![diagram](../diagram.png "Flow")`)
+	assert.Contains(t, string(content), "[docs](https://example.com/docs.md)")
+	assert.Contains(t, string(content), "[below](#notes)")
+
+	// The state file moved and points at the new location
+	assert.False(t, mockFS.Exists("docs/changes-request/.login.blueprint.md.step"))
+	data, err := mockFS.ReadFile("docs/changes-request/auth/.login.blueprint.md.step")
+	require.NoError(t, err)
+	var state workflow.WorkflowState
+	require.NoError(t, json.Unmarshal(data, &state))
+	assert.Equal(t, newPath, state.ChangeRequestPath)
+	assert.Equal(t, 1, state.CurrentStepIndex)
+
+	// Generated outputs moved unchanged
+	assert.False(t, mockFS.Exists("docs/changes-request/login.01-laying-the-foundation.md"))
+	output, err := mockFS.ReadFile("docs/changes-request/auth/login.01-laying-the-foundation.md")
+	require.NoError(t, err)
+	assert.Equal(t, "foundation", string(output))
+}
+
+func TestMoveChangeRequest_OutputCollision(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+	oldPath := "docs/changes-request/login.blueprint.md"
+	newPath := "docs/changes-request/auth/login.blueprint.md"
+
+	mockFS.AddFile(oldPath, []byte(movedBlueprint))
+	mockFS.AddFile("docs/changes-request/login.02-mvi.md", []byte("mvi"))
+	mockFS.AddFile("docs/changes-request/auth/login.02-mvi.md", []byte("other mvi"))
+
+	err := MoveChangeRequest(oldPath, newPath, mockFS)
+
+	assert.ErrorIs(t, err, ErrDestinationExists)
+	assert.True(t, mockFS.Exists(oldPath), "nothing is moved on collision")
+	assert.False(t, mockFS.Exists(newPath))
+}
+
+func TestMoveChangeRequest_NotFound(t *testing.T) {
+	err := MoveChangeRequest("docs/changes-request/missing.blueprint.md", "docs/other.blueprint.md", io.NewMockFileSystem())
+
+	assert.Error(t, err)
+}
+
+func TestRewriteRelativeLinks(t *testing.T) {
+	content := "[a](a.md) [b](../b.md#x) [c](/abs/c.md) [d](mailto:me@example.com)"
+
+	assert.Equal(t,
+		"[a](../a.md) [b](../../b.md#x) [c](/abs/c.md) [d](mailto:me@example.com)",
+		RewriteRelativeLinks(content, "docs/changes-request", "docs/changes-request/auth"))
+
+	// Moving to the same directory keeps the links as they are
+	assert.Equal(t, content, RewriteRelativeLinks(content, "docs", "docs"))
+}
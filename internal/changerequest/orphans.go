@@ -0,0 +1,109 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	iofs "io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/workflow"
+)
+
+// FindOrphanedWorkflowFiles lists the workflow state files and step outputs
+// under the change requests directories of root whose change request no
+// longer exists, e.g. after it was renamed or deleted without
+// MoveChangeRequest. The paths are sorted. Missing change requests
+// directories have no orphans.
+func FindOrphanedWorkflowFiles(root string, fs io.FileSystem) ([]string, error) {
+	var orphans []string
+	seen := make(map[string]bool)
+	err := walkChangesDirs(root, fs, func(path string) {
+		if seen[path] {
+			return // Reached again through a nested changes directory
+		}
+		seen[path] = true
+
+		candidates := changeRequestCandidates(path)
+		if len(candidates) == 0 {
+			return // Not a generated workflow file
+		}
+		for _, candidate := range candidates {
+			if fs.Exists(candidate) {
+				return
+			}
+		}
+		orphans = append(orphans, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// walkChangesDirs calls visit with every file below the change requests
+// directories of root that exist
+func walkChangesDirs(root string, fs io.FileSystem, visit func(path string)) error {
+	for _, dir := range config.ChangesDirsIn(root) {
+		if !fs.Exists(dir) {
+			continue
+		}
+		err := fs.WalkDir(dir, func(path string, entry iofs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() {
+				visit(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeRequestCandidates returns the change request paths that path may
+// have been generated for, by reversing workflow.GenerateStateFilePath and
+// workflow.GenerateOutputFilePath, or nil when path is neither a state file
+// nor a step output
+func changeRequestCandidates(path string) []string {
+	if changeRequestPath, ok := stateFileChangeRequest(path); ok {
+		return []string{changeRequestPath}
+	}
+
+	dir, name := filepath.Split(path)
+
+	// Step outputs drop the .blueprint.md extension of the change request
+	for _, step := range workflow.StandardWorkflowSteps {
+		suffix := strings.TrimPrefix(step.OutputFile, "%s")
+		if base := strings.TrimSuffix(name, suffix); base != name && base != "" {
+			return []string{
+				filepath.Join(dir, base+".blueprint.md"),
+				filepath.Join(dir, base),
+			}
+		}
+	}
+	return nil
+}
+
+// stateFileChangeRequest returns the change request path a workflow state
+// file was generated for, reversing workflow.GenerateStateFilePath, and false
+// when path is not a state file. State files are named
+// ".<change request file name>.step".
+func stateFileChangeRequest(path string) (string, bool) {
+	dir, name := filepath.Split(path)
+	if !strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".step") || len(name) <= len("..step") {
+		return "", false
+	}
+	return filepath.Join(dir, strings.TrimSuffix(name[1:], ".step")), true
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestFindOrphanedWorkflowFiles(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+	mockFS.AddDirectory("docs/changes-request")
+
+	// A change request that still exists keeps its workflow files
+	mockFS.AddFile("docs/changes-request/login.blueprint.md", []byte("# Login"))
+	mockFS.AddFile("docs/changes-request/.login.blueprint.md.step", []byte(`{}`))
+	mockFS.AddFile("docs/changes-request/login.01-laying-the-foundation.md", []byte("foundation"))
+
+	// A deleted change request leaves its workflow files behind
+	mockFS.AddFile("docs/changes-request/.signup.blueprint.md.step", []byte(`{}`))
+	mockFS.AddFile("docs/changes-request/signup.01-laying-the-foundation.md", []byte("foundation"))
+	mockFS.AddFile("docs/changes-request/signup.01-laying-the-foundation-test.md", []byte("tests"))
+
+	// Unrelated files are ignored
+	mockFS.AddFile("docs/changes-request/notes.md", []byte("notes"))
+
+	orphans, err := FindOrphanedWorkflowFiles(".", mockFS)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"docs/changes-request/.signup.blueprint.md.step",
+		"docs/changes-request/signup.01-laying-the-foundation-test.md",
+		"docs/changes-request/signup.01-laying-the-foundation.md",
+	}, orphans)
+}
+
+func TestFindOrphanedWorkflowFiles_NoChangesDir(t *testing.T) {
+	orphans, err := FindOrphanedWorkflowFiles(".", io.NewMockFileSystem())
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+}
+
+func TestFindOrphanedWorkflowFiles_SeveralDirectories(t *testing.T) {
+	t.Setenv(config.ChangesDirEnv, "teams/billing:teams/search")
+	mockFS := io.NewMockFileSystem()
+	mockFS.AddFile("teams/billing/.invoice.blueprint.md.step", []byte(`{}`))
+	mockFS.AddFile("teams/search/.filters.blueprint.md.step", []byte(`{}`))
+
+	orphans, err := FindOrphanedWorkflowFiles(".", mockFS)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"teams/billing/.invoice.blueprint.md.step",
+		"teams/search/.filters.blueprint.md.step",
+	}, orphans)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"time"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/workflow"
+)
+
+// FindMostRecentChangeRequest returns the change request under the change
+// requests directories of root whose workflow state was saved last. Completed
+// workflows, unreadable state files and states left behind by a deleted
+// change request are ignored. It returns ErrNoActiveWorkflow when no
+// workflow is in progress.
+func FindMostRecentChangeRequest(root string, fs io.FileSystem) (string, error) {
+	manager := workflow.NewWorkflowManager(fs, io.NewTerminalIOWithVerbosity(io.VerbosityQuiet))
+	var mostRecent string
+	var newest time.Time
+	err := walkChangesDirs(root, fs, func(path string) {
+		changeRequestPath, ok := stateFileChangeRequest(path)
+		if !ok || !fs.Exists(changeRequestPath) {
+			return
+		}
+
+		state, err := manager.LoadState(changeRequestPath)
+		if err != nil {
+			return
+		}
+		if !state.CompletedAt.IsZero() || state.CurrentStepIndex >= len(workflow.StandardWorkflowSteps) {
+			return
+		}
+
+		if mostRecent == "" || state.LastModified.After(newest) {
+			mostRecent = changeRequestPath
+			newest = state.LastModified
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if mostRecent == "" {
+		return "", ErrNoActiveWorkflow
+	}
+	return mostRecent, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func stateJSON(stepIndex int, lastModified time.Time, completed bool) []byte {
+	completedAt := time.Time{}
+	if completed {
+		completedAt = lastModified
+	}
+	return []byte(fmt.Sprintf(`{"CurrentStepIndex":%d,"LastModified":%q,"CompletedAt":%q}`,
+		stepIndex, lastModified.Format(time.RFC3339), completedAt.Format(time.RFC3339)))
+}
+
+func TestFindMostRecentChangeRequest(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockFS := io.NewMockFileSystem()
+	mockFS.AddDirectory("docs/changes-request")
+
+	mockFS.AddFile("docs/changes-request/login.blueprint.md", []byte("# Login"))
+	mockFS.AddFile("docs/changes-request/.login.blueprint.md.step", stateJSON(2, now.Add(-time.Hour), false))
+	mockFS.AddFile("docs/changes-request/search.blueprint.md", []byte("# Search"))
+	mockFS.AddFile("docs/changes-request/.search.blueprint.md.step", stateJSON(1, now, false))
+
+	// Completed workflows and orphaned state files are never resumed
+	mockFS.AddFile("docs/changes-request/export.blueprint.md", []byte("# Export"))
+	mockFS.AddFile("docs/changes-request/.export.blueprint.md.step", stateJSON(8, now.Add(time.Hour), true))
+	mockFS.AddFile("docs/changes-request/.signup.blueprint.md.step", stateJSON(1, now.Add(2*time.Hour), false))
+
+	path, err := FindMostRecentChangeRequest(".", mockFS)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/changes-request/search.blueprint.md", path)
+}
+
+func TestFindMostRecentChangeRequest_SeveralDirectories(t *testing.T) {
+	t.Setenv(config.ChangesDirEnv, "teams/billing:teams/search")
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockFS := io.NewMockFileSystem()
+
+	mockFS.AddFile("teams/billing/invoice.blueprint.md", []byte("# Invoice"))
+	mockFS.AddFile("teams/billing/.invoice.blueprint.md.step", stateJSON(1, now.Add(-time.Hour), false))
+	mockFS.AddFile("teams/search/filters.blueprint.md", []byte("# Filters"))
+	mockFS.AddFile("teams/search/.filters.blueprint.md.step", stateJSON(1, now, false))
+
+	path, err := FindMostRecentChangeRequest(".", mockFS)
+	require.NoError(t, err)
+	assert.Equal(t, "teams/search/filters.blueprint.md", path)
+}
+
+func TestFindMostRecentChangeRequest_NoActiveWorkflow(t *testing.T) {
+	_, err := FindMostRecentChangeRequest(".", io.NewMockFileSystem())
+	assert.ErrorIs(t, err, ErrNoActiveWorkflow)
+
+	mockFS := io.NewMockFileSystem()
+	mockFS.AddDirectory("docs/changes-request")
+	mockFS.AddFile("docs/changes-request/login.blueprint.md", []byte("# Login"))
+
+	_, err = FindMostRecentChangeRequest(".", mockFS)
+	assert.ErrorIs(t, err, ErrNoActiveWorkflow)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/user-story-matrix/usm/internal/implementation"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+)
+
+// StoryStatus is where a referenced user story stands for a change request
+type StoryStatus int
+
+// The statuses, in the order SummarizeChangeRequest groups the stories
+const (
+	// StoryMissing is a reference to a file that cannot be read
+	StoryMissing StoryStatus = iota
+	// StoryChanged is a story whose content changed since it was referenced
+	StoryChanged
+	// StoryPending is an unimplemented story, referenced at its current content
+	StoryPending
+	// StoryImplemented is an implemented story, referenced at its current content
+	StoryImplemented
+)
+
+// String returns the lowercase name of the status
+func (s StoryStatus) String() string {
+	switch s {
+	case StoryMissing:
+		return "missing"
+	case StoryChanged:
+		return "changed"
+	case StoryImplemented:
+		return "implemented"
+	default:
+		return "pending"
+	}
+}
+
+// StorySummary describes one user story referenced by a change request
+type StorySummary struct {
+	Title       string // As written in the reference
+	FilePath    string
+	Implemented bool
+	HashCurrent bool // The referenced hash matches the story's current content
+	Missing     bool
+}
+
+// Status returns the status the story is grouped under
+func (s StorySummary) Status() StoryStatus {
+	switch {
+	case s.Missing:
+		return StoryMissing
+	case !s.HashCurrent:
+		return StoryChanged
+	case s.Implemented:
+		return StoryImplemented
+	default:
+		return StoryPending
+	}
+}
+
+// CRSummary lists the user stories a change request would affect
+type CRSummary struct {
+	Path    string
+	Name    string // Empty for change requests without front matter
+	Stories []StorySummary
+}
+
+// Ready reports whether the change request can be worked on: every story
+// it references exists and has not changed since it was referenced
+func (s CRSummary) Ready() bool {
+	for _, story := range s.Stories {
+		if story.Missing || !story.HashCurrent {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of stories with the given status
+func (s CRSummary) Count(status StoryStatus) int {
+	count := 0
+	for _, story := range s.Stories {
+		if story.Status() == status {
+			count++
+		}
+	}
+	return count
+}
+
+// SummarizeChangeRequest reads the user stories referenced by the change
+// request at crPath and compares each one's current content hash with the
+// referenced one. Story paths are resolved from the working directory, as
+// written in references. The stories are grouped by status, keeping the
+// reference order within each group.
+func SummarizeChangeRequest(crPath string, fs io.FileSystem) (CRSummary, error) {
+	content, err := fs.ReadFile(crPath)
+	if err != nil {
+		return CRSummary{}, fmt.Errorf("failed to read change request %s: %w", crPath, err)
+	}
+
+	summary := CRSummary{Path: crPath}
+	if info, err := metadata.ParseChangeRequest(string(content)); err == nil {
+		summary.Name = info.Name
+	}
+
+	for _, ref := range metadata.ExtractReferences(string(content)) {
+		story := StorySummary{Title: ref.Title, FilePath: ref.FilePath}
+
+		storyContent, err := fs.ReadFile(ref.FilePath)
+		if err != nil {
+			logger.Debug("Failed to read referenced user story", zap.String("file", ref.FilePath), zap.Error(err))
+			story.Missing = true
+			summary.Stories = append(summary.Stories, story)
+			continue
+		}
+
+		hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(string(storyContent)))
+		story.HashCurrent = hash == ref.ContentHash
+
+		if us, err := models.LoadUserStoryFromFile(ref.FilePath, storyContent); err == nil {
+			if err := implementation.UpdateImplementationStatus(&us, fs); err != nil {
+				logger.Debug("Failed to check implementation status", zap.String("file", ref.FilePath), zap.Error(err))
+			}
+			story.Implemented = us.IsImplemented
+		}
+		summary.Stories = append(summary.Stories, story)
+	}
+
+	sort.SliceStable(summary.Stories, func(i, j int) bool {
+		return summary.Stories[i].Status() < summary.Stories[j].Status()
+	})
+	return summary, nil
+}
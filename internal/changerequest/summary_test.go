@@ -0,0 +1,97 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package changerequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+// storyHash returns the hash a change request records for a story
+func storyHash(content string) string {
+	return metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(content))
+}
+
+func TestSummarizeChangeRequest(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+
+	pending := "# Login\n\nAs a user I want to log in.\n"
+	implemented := "---\nimplemented: true\n---\n\n# Logout\n\nAs a user I want to log out.\n"
+	changed := "# Signup\n\nAs a visitor I want to sign up.\n"
+	mockFS.AddFile("docs/user-stories/login.md", []byte(pending))
+	mockFS.AddFile("docs/user-stories/logout.md", []byte(implemented))
+	mockFS.AddFile("docs/user-stories/signup.md", []byte(changed))
+
+	cr := `---
+name: Accounts
+created-at: 2025-03-17T10:00:00Z
+user-stories:
+  - title: Login
+    file: docs/user-stories/login.md
+    content-hash: ` + storyHash(pending) + `
+  - title: Logout
+    file: docs/user-stories/logout.md
+    content-hash: ` + storyHash(implemented) + `
+  - title: Signup
+    file: docs/user-stories/signup.md
+    content-hash: outdated
+  - title: Reset password
+    file: docs/user-stories/reset.md
+    content-hash: gone
+---
+
+# Blueprint
+`
+	crPath := "docs/changes-request/accounts.blueprint.md"
+	mockFS.AddFile(crPath, []byte(cr))
+
+	summary, err := SummarizeChangeRequest(crPath, mockFS)
+	require.NoError(t, err)
+
+	assert.Equal(t, crPath, summary.Path)
+	assert.Equal(t, "Accounts", summary.Name)
+	require.Len(t, summary.Stories, 4)
+
+	// Grouped by status, most urgent first
+	var titles []string
+	var statuses []StoryStatus
+	for _, story := range summary.Stories {
+		titles = append(titles, story.Title)
+		statuses = append(statuses, story.Status())
+	}
+	assert.Equal(t, []string{"Reset password", "Signup", "Login", "Logout"}, titles)
+	assert.Equal(t, []StoryStatus{StoryMissing, StoryChanged, StoryPending, StoryImplemented}, statuses)
+
+	assert.False(t, summary.Ready())
+	assert.Equal(t, 1, summary.Count(StoryMissing))
+	assert.Equal(t, 1, summary.Count(StoryPending))
+}
+
+func TestSummarizeChangeRequest_Ready(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+
+	story := "# Login\n\nAs a user I want to log in.\n"
+	mockFS.AddFile("docs/user-stories/login.md", []byte(story))
+
+	cr := "---\nname: Login\nuser-stories:\n  - title: Login\n    file: docs/user-stories/login.md\n    content-hash: " +
+		storyHash(story) + "\n---\n"
+	mockFS.AddFile("docs/changes-request/login.blueprint.md", []byte(cr))
+
+	summary, err := SummarizeChangeRequest("docs/changes-request/login.blueprint.md", mockFS)
+	require.NoError(t, err)
+	require.Len(t, summary.Stories, 1)
+	assert.True(t, summary.Stories[0].HashCurrent)
+	assert.True(t, summary.Ready())
+}
+
+func TestSummarizeChangeRequest_NotFound(t *testing.T) {
+	_, err := SummarizeChangeRequest("docs/changes-request/missing.blueprint.md", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
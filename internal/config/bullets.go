@@ -0,0 +1,21 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// BulletStyleEnv selects the marker written before acceptance criteria:
+// "-" (the default), "*" or "1." for a numbered list
+const BulletStyleEnv = "USM_BULLET_STYLE"
+
+// BulletStyle returns the acceptance criteria marker set by USM_BULLET_STYLE,
+// or an empty string when unset
+func BulletStyle() string {
+	return strings.TrimSpace(os.Getenv(BulletStyleEnv))
+}
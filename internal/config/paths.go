@@ -0,0 +1,109 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables overriding the default documentation layout
+const (
+	UserStoriesDirEnv = "USM_USER_STORIES_DIR"
+	ChangesDirEnv     = "USM_CHANGES_DIR"
+	PromptsDirEnv     = "USM_PROMPTS_DIR"
+)
+
+// Default locations, relative to the project root
+const (
+	DefaultUserStoriesDir = "docs/user-stories"
+	DefaultChangesDir     = "docs/changes-request"
+	DefaultPromptsDir     = "docs/prompts"
+)
+
+// UserStoriesDir returns the user stories directory, as set by
+// USM_USER_STORIES_DIR or docs/user-stories when unset
+func UserStoriesDir() string {
+	return dirFromEnv(UserStoriesDirEnv, DefaultUserStoriesDir)
+}
+
+// ChangesDir returns the change requests directory, as set by
+// USM_CHANGES_DIR or docs/changes-request when unset. When USM_CHANGES_DIR
+// lists several directories, it is the first one, where change requests are
+// created.
+func ChangesDir() string {
+	return ChangesDirs()[0]
+}
+
+// ChangesDirs returns every change requests directory. USM_CHANGES_DIR may
+// list several directories separated by the OS path list separator (":" on
+// Unix, ";" on Windows), e.g. one per team; blank entries and duplicates are
+// dropped. It defaults to docs/changes-request.
+func ChangesDirs() []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv(ChangesDirEnv)) {
+		dir = strings.TrimSpace(dir)
+		if dir == "" {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return []string{DefaultChangesDir}
+	}
+	return dirs
+}
+
+// PromptsDir returns the directory of the workflow prompt files, as set by
+// USM_PROMPTS_DIR or docs/prompts when unset
+func PromptsDir() string {
+	return dirFromEnv(PromptsDirEnv, DefaultPromptsDir)
+}
+
+// UserStoriesDirIn returns the user stories directory resolved against root.
+// An absolute override is returned as is.
+func UserStoriesDirIn(root string) string {
+	return resolve(root, UserStoriesDir())
+}
+
+// ChangesDirIn returns the change requests directory resolved against root.
+// An absolute override is returned as is.
+func ChangesDirIn(root string) string {
+	return resolve(root, ChangesDir())
+}
+
+// ChangesDirsIn returns every change requests directory resolved against
+// root; see ChangesDirs
+func ChangesDirsIn(root string) []string {
+	dirs := ChangesDirs()
+	for i, dir := range dirs {
+		dirs[i] = resolve(root, dir)
+	}
+	return dirs
+}
+
+// dirFromEnv reads a directory from the environment, falling back to def
+// when the variable is unset or blank
+func dirFromEnv(name, def string) string {
+	if dir := strings.TrimSpace(os.Getenv(name)); dir != "" {
+		return filepath.Clean(dir)
+	}
+	return def
+}
+
+// resolve joins a relative dir onto root
+func resolve(root, dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(root, dir)
+}
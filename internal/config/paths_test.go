@@ -0,0 +1,63 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirsDefaults(t *testing.T) {
+	t.Setenv(UserStoriesDirEnv, "")
+	t.Setenv(ChangesDirEnv, "  ")
+
+	assert.Equal(t, "docs/user-stories", UserStoriesDir())
+	assert.Equal(t, "docs/changes-request", ChangesDir())
+	assert.Equal(t, "/repo/docs/user-stories", UserStoriesDirIn("/repo"))
+	assert.Equal(t, "/repo/docs/changes-request", ChangesDirIn("/repo"))
+}
+
+func TestDirsFromEnvironment(t *testing.T) {
+	t.Setenv(UserStoriesDirEnv, "requirements/stories/")
+	t.Setenv(ChangesDirEnv, "/shared/changes")
+
+	assert.Equal(t, "requirements/stories", UserStoriesDir())
+	assert.Equal(t, "/repo/requirements/stories", UserStoriesDirIn("/repo"))
+	assert.Equal(t, "/shared/changes", ChangesDir())
+	assert.Equal(t, "/shared/changes", ChangesDirIn("/repo"))
+}
+
+func TestChangesDirs(t *testing.T) {
+	t.Setenv(ChangesDirEnv, "")
+	assert.Equal(t, []string{"docs/changes-request"}, ChangesDirs())
+
+	t.Setenv(ChangesDirEnv, "teams/billing/ :: /shared/changes:teams/billing")
+	assert.Equal(t, []string{"teams/billing", "/shared/changes"}, ChangesDirs())
+	assert.Equal(t, "teams/billing", ChangesDir())
+	assert.Equal(t, []string{"/repo/teams/billing", "/shared/changes"}, ChangesDirsIn("/repo"))
+}
+
+func TestBulletStyle(t *testing.T) {
+	t.Setenv(BulletStyleEnv, "")
+	assert.Equal(t, "", BulletStyle())
+
+	t.Setenv(BulletStyleEnv, " 1. ")
+	assert.Equal(t, "1.", BulletStyle())
+}
+
+func TestLastUpdatedPolicy(t *testing.T) {
+	t.Setenv(LastUpdatedPolicyEnv, " any ")
+	assert.Equal(t, "any", LastUpdatedPolicy())
+}
+
+func TestPromptsDir(t *testing.T) {
+	t.Setenv(PromptsDirEnv, "")
+	assert.Equal(t, "docs/prompts", PromptsDir())
+
+	t.Setenv(PromptsDirEnv, "team/prompts/")
+	assert.Equal(t, "team/prompts", PromptsDir())
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// SafeModeEnv turns off the check that metadata and reference updates only
+// write files inside the working directory and the configured docs
+// directories when set to a false value such as "0" or "false"
+const SafeModeEnv = "USM_SAFE_MODE"
+
+// SafeMode returns the value of USM_SAFE_MODE, or an empty string when unset
+func SafeMode() string {
+	return strings.TrimSpace(os.Getenv(SafeModeEnv))
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// MaxDepthEnv limits how many directory levels are scanned for markdown
+// files: 1 for the scanned directory only, unset or 0 for no limit
+const MaxDepthEnv = "USM_MAX_DEPTH"
+
+// MaxDepth returns the scan depth set by USM_MAX_DEPTH, or an empty string
+// when unset
+func MaxDepth() string {
+	return strings.TrimSpace(os.Getenv(MaxDepthEnv))
+}
+
+// FollowSymlinksEnv makes scans descend into symbolic links to directories
+// when set to a true value such as "1" or "true"
+const FollowSymlinksEnv = "USM_FOLLOW_SYMLINKS"
+
+// FollowSymlinks returns the value of USM_FOLLOW_SYMLINKS, or an empty string
+// when unset
+func FollowSymlinks() string {
+	return strings.TrimSpace(os.Getenv(FollowSymlinksEnv))
+}
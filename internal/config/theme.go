@@ -0,0 +1,39 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// ThemeEnv selects the colors of the interactive screens: "default",
+// "high-contrast" or "monochrome"
+const ThemeEnv = "USM_THEME"
+
+// Theme returns the theme set by USM_THEME, or an empty string when unset
+func Theme() string {
+	return strings.TrimSpace(os.Getenv(ThemeEnv))
+}
+
+// CheckboxEnv selects the checkbox glyphs of the story picker: "default",
+// "ascii", "radio", "emoji" or custom glyphs as "checked,unchecked"
+const CheckboxEnv = "USM_CHECKBOX"
+
+// Checkbox returns the checkbox glyphs set by USM_CHECKBOX, or an empty
+// string when unset
+func Checkbox() string {
+	return strings.TrimSpace(os.Getenv(CheckboxEnv))
+}
+
+// NoColorEnv is the variable set by users who want no colors in any terminal
+// program, whatever its value (see https://no-color.org)
+const NoColorEnv = "NO_COLOR"
+
+// NoColor reports whether NO_COLOR is set to a non-empty value
+func NoColor() bool {
+	return os.Getenv(NoColorEnv) != ""
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// TimestampFormatEnv selects how created_at and last_updated are written:
+// "rfc3339" (the default), "date", or any Go time layout
+const TimestampFormatEnv = "USM_TIMESTAMP_FORMAT"
+
+// TimestampFormat returns the time layout selected by USM_TIMESTAMP_FORMAT
+func TimestampFormat() string {
+	value := strings.TrimSpace(os.Getenv(TimestampFormatEnv))
+	switch strings.ToLower(value) {
+	case "", "rfc3339":
+		return time.RFC3339
+	case "date":
+		return time.DateOnly
+	default:
+		return value
+	}
+}
+
+// LastUpdatedPolicyEnv selects which edits bump last_updated: "content" (the
+// default) for body changes only, or "any" to include custom front-matter fields
+const LastUpdatedPolicyEnv = "USM_LAST_UPDATED_POLICY"
+
+// LastUpdatedPolicy returns the policy set by USM_LAST_UPDATED_POLICY, or an
+// empty string when unset
+func LastUpdatedPolicy() string {
+	return strings.TrimSpace(os.Getenv(LastUpdatedPolicyEnv))
+}
+
+// MetadataPlacementEnv selects where metadata is written: "top" (the default)
+// for front matter, or "footer" for a block at the end of each file
+const MetadataPlacementEnv = "USM_METADATA_PLACEMENT"
+
+// MetadataPlacement returns the placement set by USM_METADATA_PLACEMENT, or an
+// empty string when unset
+func MetadataPlacement() string {
+	return strings.TrimSpace(os.Getenv(MetadataPlacementEnv))
+}
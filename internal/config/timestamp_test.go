@@ -0,0 +1,24 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampFormat(t *testing.T) {
+	t.Setenv(TimestampFormatEnv, "")
+	assert.Equal(t, time.RFC3339, TimestampFormat())
+
+	t.Setenv(TimestampFormatEnv, "Date")
+	assert.Equal(t, "2006-01-02", TimestampFormat())
+
+	t.Setenv(TimestampFormatEnv, "02/01/2006")
+	assert.Equal(t, "02/01/2006", TimestampFormat())
+}
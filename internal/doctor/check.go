@@ -0,0 +1,112 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package doctor
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// Check is a workspace health check. Teams can add their own, e.g. "every
+// story must have a JIRA id in its front matter", by registering them with
+// RegisterCheck instead of modifying the builtin checks.
+type Check interface {
+	// Name identifies the check; it is used for the findings that do not
+	// set their own Check
+	Name() string
+	// Run checks the workspace below root and returns the problems found
+	Run(root string, fs io.FileSystem) []Finding
+}
+
+// Names of the builtin checks
+const (
+	BuiltinUserStories    = "user-stories"
+	BuiltinChangeRequests = "change-requests"
+)
+
+// builtinCheck adapts one of the diagnose functions to Check
+type builtinCheck struct {
+	name     string
+	diagnose func(root string, fs io.FileSystem, report *DiagnosticReport) error
+}
+
+// Name returns the name of the builtin check
+func (c builtinCheck) Name() string {
+	return c.name
+}
+
+// Run diagnoses the workspace, reporting a failure to do so as an error finding
+func (c builtinCheck) Run(root string, fs io.FileSystem) []Finding {
+	var report DiagnosticReport
+	if err := c.diagnose(root, fs, &report); err != nil {
+		report.add(c.name, SeverityError, root, err.Error())
+	}
+	return report.Findings
+}
+
+// builtinChecks are the checks shipped with usm: stale metadata, duplicate
+// titles and acceptance criteria for the stories; broken references, hash
+// mismatches and workflow files for the change requests
+var builtinChecks = []Check{
+	builtinCheck{name: BuiltinUserStories, diagnose: diagnoseStories},
+	builtinCheck{name: BuiltinChangeRequests, diagnose: diagnoseChangeRequests},
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []Check
+)
+
+// RegisterCheck adds a check run by RunAllChecks and RunDiagnostics after the
+// builtin checks. It panics when a check with the same name is already
+// registered, as registration usually happens from an init function.
+func RegisterCheck(check Check) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, existing := range append(append([]Check{}, builtinChecks...), registry...) {
+		if existing.Name() == check.Name() {
+			panic(fmt.Sprintf("doctor: check %q registered twice", check.Name()))
+		}
+	}
+	registry = append(registry, check)
+}
+
+// Checks returns the builtin checks followed by the registered ones
+func Checks() []Check {
+	return append(append([]Check{}, builtinChecks...), registeredChecks()...)
+}
+
+// registeredChecks returns a copy of the checks added with RegisterCheck
+func registeredChecks() []Check {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]Check{}, registry...)
+}
+
+// RunAllChecks runs every check of Checks on the workspace below root and
+// returns their findings, ordered by SortFindings
+func RunAllChecks(root string, fs io.FileSystem) []Finding {
+	var findings []Finding
+	for _, check := range Checks() {
+		findings = append(findings, runCheck(check, root, fs)...)
+	}
+	SortFindings(findings)
+	return findings
+}
+
+// runCheck runs check, attributing to it the findings that do not name a check
+func runCheck(check Check, root string, fs io.FileSystem) []Finding {
+	findings := check.Run(root, fs)
+	for i := range findings {
+		if findings[i].Check == "" {
+			findings[i].Check = check.Name()
+		}
+	}
+	return findings
+}
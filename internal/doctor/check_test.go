@@ -0,0 +1,93 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package doctor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+// jiraCheck requires a jira front-matter field on every user story
+type jiraCheck struct{}
+
+func (jiraCheck) Name() string { return "jira-id" }
+
+func (jiraCheck) Run(root string, fs io.FileSystem) []Finding {
+	files, err := metadata.FindMarkdownFiles("docs/user-stories", fs)
+	if err != nil {
+		return []Finding{{Severity: SeverityError, Path: root, Message: err.Error()}}
+	}
+
+	var findings []Finding
+	for _, file := range files {
+		content, _ := fs.ReadFile(file)
+		meta, _ := metadata.ExtractMetadata(string(content))
+		if meta.RawMetadata["jira"] == "" {
+			findings = append(findings, Finding{Severity: SeverityWarning, Path: file, Message: "no JIRA id"})
+		}
+	}
+	return findings
+}
+
+// withRegistry restores the check registry when the test ends
+func withRegistry(t *testing.T) {
+	saved := registeredChecks()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		registry = saved
+	})
+}
+
+func TestRegisterCheck(t *testing.T) {
+	withRegistry(t)
+
+	RegisterCheck(jiraCheck{})
+
+	var names []string
+	for _, check := range Checks() {
+		names = append(names, check.Name())
+	}
+	assert.Equal(t, []string{BuiltinUserStories, BuiltinChangeRequests, "jira-id"}, names)
+
+	assert.Panics(t, func() { RegisterCheck(jiraCheck{}) })
+}
+
+func TestRunAllChecks(t *testing.T) {
+	withRegistry(t)
+	RegisterCheck(jiraCheck{})
+
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+	fs.AddFile("docs/user-stories/01-login.md", withMetadata("docs/user-stories/01-login.md", loginBody))
+
+	findings := RunAllChecks("", fs)
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, Finding{Check: "jira-id", Severity: SeverityWarning, Path: "docs/user-stories/01-login.md", Message: "no JIRA id"}, findings[0])
+	assert.Equal(t, CheckWorkspace, findings[1].Check, "the missing change requests directory is only informative")
+}
+
+func TestRunDiagnostics_RunsRegisteredChecks(t *testing.T) {
+	withRegistry(t)
+	RegisterCheck(jiraCheck{})
+
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+	fs.AddFile("docs/user-stories/01-login.md", withMetadata("docs/user-stories/01-login.md", loginBody))
+
+	report, err := RunDiagnostics("", fs)
+
+	require.NoError(t, err)
+	assert.Len(t, findingsFor(report, "jira-id"), 1)
+	assert.Equal(t, 1, report.StoriesChecked)
+}
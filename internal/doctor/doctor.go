@@ -0,0 +1,327 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package doctor runs the workspace health checks behind "usm doctor".
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/changerequest"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/workflow"
+)
+
+// Severity ranks how urgently a finding should be addressed
+type Severity int
+
+const (
+	// SeverityInfo is worth knowing but needs no action
+	SeverityInfo Severity = iota
+	// SeverityWarning should be fixed but does not break usm
+	SeverityWarning
+	// SeverityError breaks a usm command or a change request
+	SeverityError
+)
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// MarshalText encodes the severity as its name, e.g. in JSON reports
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Names of the checks run by RunDiagnostics
+const (
+	CheckStaleMetadata        = "stale-metadata"
+	CheckBrokenReference      = "broken-reference"
+	CheckReferencePath        = "reference-path"
+	CheckHashMismatch         = "hash-mismatch"
+	CheckDuplicateTitle       = "duplicate-title"
+	CheckAcceptanceCriteria   = "acceptance-criteria"
+	CheckCorruptWorkflowState = "corrupt-workflow-state"
+	CheckOrphanedWorkflowFile = "orphaned-workflow-file"
+	CheckWorkspace            = "workspace"
+)
+
+// Finding is a single problem reported by a check
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Line     int      `json:"line,omitempty"` // 1-based line in Path, 0 when the finding is about the whole file
+	Message  string   `json:"message"`
+}
+
+// Location returns the path of the finding, followed by ":line" when the
+// line is known
+func (f Finding) Location() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.Path, f.Line)
+	}
+	return f.Path
+}
+
+// DiagnosticReport aggregates the findings of every check
+type DiagnosticReport struct {
+	Findings              []Finding `json:"findings"`
+	StoriesChecked        int       `json:"stories_checked"`
+	ChangeRequestsChecked int       `json:"change_requests_checked"`
+}
+
+// Count returns the number of findings with the given severity
+func (r DiagnosticReport) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// HasErrors reports whether any finding is an error
+func (r DiagnosticReport) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// Healthy reports whether no check found an error or a warning
+func (r DiagnosticReport) Healthy() bool {
+	return r.Count(SeverityError) == 0 && r.Count(SeverityWarning) == 0
+}
+
+// add records a finding
+func (r *DiagnosticReport) add(check string, severity Severity, path, message string) {
+	r.addAt(check, severity, path, 0, message)
+}
+
+// addAt records a finding about a line of a file
+func (r *DiagnosticReport) addAt(check string, severity Severity, path string, line int, message string) {
+	r.Findings = append(r.Findings, Finding{Check: check, Severity: severity, Path: path, Line: line, Message: message})
+}
+
+// RunDiagnostics checks the user stories and change requests below root and
+// returns every problem found, ordered by SortFindings. A missing user stories or
+// change requests directory is reported as a finding rather than an error.
+// The checks registered with RegisterCheck run after the builtin ones.
+func RunDiagnostics(root string, fs io.FileSystem) (DiagnosticReport, error) {
+	var report DiagnosticReport
+
+	if err := diagnoseStories(root, fs, &report); err != nil {
+		return report, err
+	}
+	if err := diagnoseChangeRequests(root, fs, &report); err != nil {
+		return report, err
+	}
+	for _, check := range registeredChecks() {
+		report.Findings = append(report.Findings, runCheck(check, root, fs)...)
+	}
+
+	SortFindings(report.Findings)
+	return report, nil
+}
+
+// SortFindings orders findings most severe first, then by path and line. The
+// sort is stable, so findings at the same place keep their order.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Line < b.Line
+	})
+}
+
+// diagnoseStories checks the user stories directory of root
+func diagnoseStories(root string, fs io.FileSystem, report *DiagnosticReport) error {
+	storiesDir := config.UserStoriesDirIn(root)
+	if !fs.Exists(storiesDir) {
+		report.add(CheckWorkspace, SeverityWarning, storiesDir, "user stories directory not found")
+		return nil
+	}
+	return checkStories(storiesDir, fs, report)
+}
+
+// diagnoseChangeRequests checks the change requests directories of root
+func diagnoseChangeRequests(root string, fs io.FileSystem, report *DiagnosticReport) error {
+	for _, dir := range config.ChangesDirsIn(root) {
+		if fs.Exists(dir) {
+			return checkChangeRequests(root, fs, report)
+		}
+	}
+	report.add(CheckWorkspace, SeverityInfo, config.ChangesDirIn(root), "no change requests directory")
+	return nil
+}
+
+// checkStories looks for stale metadata, duplicate titles and missing
+// acceptance criteria in the stories below dir
+func checkStories(dir string, fs io.FileSystem, report *DiagnosticReport) error {
+	files, err := metadata.FindMarkdownFiles(dir, fs)
+	if err != nil {
+		return err
+	}
+	report.StoriesChecked = len(files)
+
+	pathsByTitle := make(map[string][]string)
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			report.add(CheckStaleMetadata, SeverityError, file, fmt.Sprintf("cannot read file: %v", err))
+			continue
+		}
+
+		meta, _ := metadata.ExtractMetadata(string(content))
+		hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(string(content)))
+		switch meta.ContentHash {
+		case "":
+			report.add(CheckStaleMetadata, SeverityWarning, file, `no metadata, run "usm update user-stories metadata"`)
+		case hash:
+		default:
+			report.add(CheckStaleMetadata, SeverityWarning, file, `content changed since the metadata was written, run "usm update user-stories metadata"`)
+		}
+
+		story, err := models.LoadUserStoryFromFile(file, content)
+		if err == nil && story.Title != "" {
+			title := strings.ToLower(strings.TrimSpace(story.Title))
+			pathsByTitle[title] = append(pathsByTitle[title], file)
+		}
+	}
+
+	titles := make([]string, 0, len(pathsByTitle))
+	for title, paths := range pathsByTitle {
+		if len(paths) > 1 {
+			titles = append(titles, title)
+		}
+	}
+	sort.Strings(titles)
+	for _, title := range titles {
+		paths := pathsByTitle[title]
+		for _, path := range paths {
+			report.add(CheckDuplicateTitle, SeverityWarning, path, fmt.Sprintf("title shared by %d user stories", len(paths)))
+		}
+	}
+
+	withoutCriteria, err := metadata.FindStoriesWithoutAcceptanceCriteria(dir, fs)
+	if err != nil {
+		return err
+	}
+	for _, story := range withoutCriteria {
+		report.add(CheckAcceptanceCriteria, SeverityWarning, story.FilePath, "no acceptance criteria")
+	}
+
+	return nil
+}
+
+// checkChangeRequests looks for references to missing or modified stories,
+// for workflow state files that cannot be loaded and for workflow files left
+// behind by deleted change requests
+func checkChangeRequests(root string, fs io.FileSystem, report *DiagnosticReport) error {
+	files, err := metadata.FindChangeRequestFiles(root, fs)
+	if err != nil {
+		return err
+	}
+
+	orphans, err := changerequest.FindOrphanedWorkflowFiles(root, fs)
+	if err != nil {
+		return err
+	}
+	for _, orphan := range orphans {
+		report.add(CheckOrphanedWorkflowFile, SeverityWarning, orphan, `its change request no longer exists, remove it with "usm doctor --remove-orphans"`)
+	}
+
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".blueprint.md") {
+			continue
+		}
+		report.ChangeRequestsChecked++
+
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			report.add(CheckBrokenReference, SeverityError, file, fmt.Sprintf("cannot read file: %v", err))
+			continue
+		}
+
+		info, err := metadata.ParseChangeRequest(string(content))
+		if err != nil {
+			report.add(CheckBrokenReference, SeverityWarning, file, err.Error())
+		}
+
+		for _, ref := range info.References {
+			normalized, err := metadata.NormalizeReferencePath(ref.FilePath, root)
+			if err != nil {
+				report.addAt(CheckReferencePath, SeverityError, file, ref.Line, fmt.Sprintf("references user story %s outside the repository", ref.FilePath))
+				continue
+			}
+			if normalized != ref.FilePath {
+				report.addAt(CheckReferencePath, SeverityWarning, file, ref.Line, fmt.Sprintf("reference path %s is not repo-relative, use %s", ref.FilePath, normalized))
+			}
+
+			storyPath := ref.FilePath
+			if !filepath.IsAbs(storyPath) {
+				storyPath = filepath.Join(root, storyPath)
+			}
+
+			storyContent, err := fs.ReadFile(storyPath)
+			if err != nil {
+				report.addAt(CheckBrokenReference, SeverityError, file, ref.Line, fmt.Sprintf("references missing user story %s", ref.FilePath))
+				continue
+			}
+
+			hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(string(storyContent)))
+			if ref.ContentHash != hash {
+				report.addAt(CheckHashMismatch, SeverityWarning, file, ref.Line, fmt.Sprintf("user story %s changed since it was referenced", ref.FilePath))
+			}
+		}
+
+		checkWorkflowState(file, fs, report)
+	}
+
+	return nil
+}
+
+// checkWorkflowState reports a state file that is not valid JSON or points
+// at a step outside the standard workflow
+func checkWorkflowState(changeRequestPath string, fs io.FileSystem, report *DiagnosticReport) {
+	statePath := workflow.GenerateStateFilePath(changeRequestPath)
+	if !fs.Exists(statePath) {
+		return
+	}
+
+	data, err := fs.ReadFile(statePath)
+	if err != nil {
+		report.add(CheckCorruptWorkflowState, SeverityError, statePath, fmt.Sprintf("cannot read file: %v", err))
+		return
+	}
+
+	var state workflow.WorkflowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		report.add(CheckCorruptWorkflowState, SeverityError, statePath, fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	if state.CurrentStepIndex < 0 || state.CurrentStepIndex > len(workflow.StandardWorkflowSteps) {
+		report.add(CheckCorruptWorkflowState, SeverityError, statePath, fmt.Sprintf("unknown step index %d", state.CurrentStepIndex))
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package doctor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+const loginBody = `# Login
+As a user, I want to log in, so that I can see my data
+
+## Acceptance criteria
+- Login form is shown
+`
+
+// withMetadata prepends a metadata section holding the hash of body
+func withMetadata(path, body string) []byte {
+	hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(body))
+	return []byte(fmt.Sprintf("---\nfile_path: %s\n_content_hash: %s\n---\n\n%s", path, hash, body))
+}
+
+// findingsFor returns the findings of a single check
+func findingsFor(report DiagnosticReport, check string) []Finding {
+	var findings []Finding
+	for _, finding := range report.Findings {
+		if finding.Check == check {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func TestRunDiagnostics_HealthyWorkspace(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+	fs.AddDirectory("docs/changes-request")
+	fs.AddFile("docs/user-stories/01-login.md", withMetadata("docs/user-stories/01-login.md", loginBody))
+
+	hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(loginBody))
+	fs.AddFile("docs/changes-request/login.blueprint.md", []byte(fmt.Sprintf(`---
+name: Login
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: %s
+---
+`, hash)))
+
+	report, err := RunDiagnostics("", fs)
+
+	require.NoError(t, err)
+	assert.True(t, report.Healthy(), "unexpected findings: %v", report.Findings)
+	assert.Equal(t, 1, report.StoriesChecked)
+	assert.Equal(t, 1, report.ChangeRequestsChecked)
+}
+
+func TestRunDiagnostics_ReportsProblems(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+	fs.AddDirectory("docs/changes-request")
+	fs.AddFile("docs/user-stories/01-login.md", withMetadata("docs/user-stories/01-login.md", loginBody))
+	fs.AddFile("docs/user-stories/02-login-again.md", []byte("# Login\nAs a user, I want to log in twice, so that I am sure\n"))
+	fs.AddFile("docs/changes-request/login.blueprint.md", []byte(`---
+name: Login
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: outdated
+  - title: Gone
+    file: docs/user-stories/99-gone.md
+    content-hash: abc
+  - title: Elsewhere
+    file: ../other-repo/docs/user-stories/01-elsewhere.md
+    content-hash: def
+---
+`))
+	fs.AddFile("docs/changes-request/.login.blueprint.md.step", []byte("{not json"))
+
+	report, err := RunDiagnostics("", fs)
+	require.NoError(t, err)
+
+	assert.Len(t, findingsFor(report, CheckStaleMetadata), 1)
+	assert.Len(t, findingsFor(report, CheckDuplicateTitle), 2)
+	assert.Len(t, findingsFor(report, CheckAcceptanceCriteria), 1)
+	assert.Len(t, findingsFor(report, CheckHashMismatch), 1)
+	assert.Len(t, findingsFor(report, CheckBrokenReference), 1)
+	assert.Len(t, findingsFor(report, CheckCorruptWorkflowState), 1)
+	assert.Len(t, findingsFor(report, CheckReferencePath), 1)
+
+	assert.True(t, report.HasErrors())
+	assert.Equal(t, 3, report.Count(SeverityError))
+	assert.Equal(t, SeverityError, report.Findings[0].Severity, "errors should be listed first")
+
+	// Reference findings point at the line of the reference
+	assert.Equal(t, 4, findingsFor(report, CheckHashMismatch)[0].Line)
+	assert.Equal(t, 7, findingsFor(report, CheckBrokenReference)[0].Line)
+	assert.Equal(t, "docs/changes-request/login.blueprint.md:7", findingsFor(report, CheckBrokenReference)[0].Location())
+}
+
+func TestSortFindings(t *testing.T) {
+	findings := []Finding{
+		{Check: "a", Severity: SeverityInfo, Path: "a.md"},
+		{Check: "b", Severity: SeverityWarning, Path: "b.md", Line: 9},
+		{Check: "c", Severity: SeverityError, Path: "b.md"},
+		{Check: "d", Severity: SeverityWarning, Path: "b.md", Line: 2},
+		{Check: "e", Severity: SeverityWarning, Path: "a.md", Line: 30},
+		{Check: "f", Severity: SeverityWarning, Path: "b.md", Line: 2},
+	}
+
+	SortFindings(findings)
+
+	var checks []string
+	for _, finding := range findings {
+		checks = append(checks, finding.Check)
+	}
+	assert.Equal(t, []string{"c", "e", "d", "f", "b", "a"}, checks)
+}
+
+func TestRunDiagnostics_MissingDirectories(t *testing.T) {
+	report, err := RunDiagnostics("", io.NewMockFileSystem())
+
+	require.NoError(t, err)
+	assert.False(t, report.HasErrors())
+	assert.Len(t, findingsFor(report, CheckWorkspace), 2)
+}
+
+func TestSeverityString(t *testing.T) {
+	assert.Equal(t, "info", SeverityInfo.String())
+	assert.Equal(t, "warning", SeverityWarning.String())
+	assert.Equal(t, "error", SeverityError.String())
+}
+
+func TestFindingLocation(t *testing.T) {
+	assert.Equal(t, "a.md", Finding{Path: "a.md"}.Location())
+	assert.Equal(t, "a.md:4", Finding{Path: "a.md", Line: 4}.Location())
+}
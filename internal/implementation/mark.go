@@ -0,0 +1,84 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package implementation
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+// ImplementedField is the front-matter field that marks a story implemented by hand
+const ImplementedField = "implemented"
+
+// MarkImplemented sets "implemented: true" in the front matter of the story at
+// storyPath, refreshes its metadata and updates the change requests
+// referencing it. storyPath may be relative to root or absolute.
+func MarkImplemented(storyPath string, root string, fs io.FileSystem) error {
+	_, err := setImplemented(storyPath, root, fs, true)
+	return err
+}
+
+// UnmarkImplemented removes the "implemented" front-matter field set by
+// MarkImplemented. The story still counts as implemented if an implemented
+// change request references it.
+func UnmarkImplemented(storyPath string, root string, fs io.FileSystem) error {
+	_, err := setImplemented(storyPath, root, fs, false)
+	return err
+}
+
+// setImplemented writes or removes the implemented field and propagates the
+// change, returning the change request references whose hash was neither the
+// previous nor the new hash of the story
+func setImplemented(storyPath string, root string, fs io.FileSystem, implemented bool) ([]metadata.MismatchedReference, error) {
+	fullPath := storyPath
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(root, storyPath)
+	}
+
+	content, err := fs.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user story %s: %w", storyPath, err)
+	}
+
+	var updated string
+	if implemented {
+		updated = metadata.SetCustomField(string(content), ImplementedField, "true")
+	} else {
+		updated = metadata.RemoveCustomField(string(content), ImplementedField)
+	}
+
+	if updated != string(content) {
+		info, err := fs.Stat(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file info for %s: %w", storyPath, err)
+		}
+		if err := fs.WriteFile(fullPath, []byte(updated), info.Mode()); err != nil {
+			return nil, fmt.Errorf("failed to write user story %s: %w", storyPath, err)
+		}
+	}
+
+	_, hashMap, err := metadata.UpdateFileMetadata(fullPath, root, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The references still hold the hash stored before the metadata update
+	relPath, err := metadata.NormalizeReferencePath(storyPath, root)
+	if err != nil {
+		relPath = storyPath
+	}
+	hashMap.FilePath = relPath
+	hashMap.Changed = true // Stale references are brought up to date too
+	changes := metadata.ContentChangeMap{relPath: hashMap}
+	_, _, _, mismatched, err := metadata.UpdateAllChangeRequestReferences(root, changes, fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update change request references: %w", err)
+	}
+	return mismatched, nil
+}
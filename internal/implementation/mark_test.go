@@ -0,0 +1,94 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package implementation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestMarkImplemented(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	storyPath := "docs/user-stories/01-login.md"
+	body := "# Login\n\nAs a user, I want to log in, so that I can see my data\n"
+	fs.AddFile(storyPath, []byte("---\nfile_path: docs/user-stories/01-login.md\n_content_hash: outdated\n---\n\n"+body))
+	fs.AddFile("docs/changes-request/login.blueprint.md", []byte(`---
+name: Login
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: outdated
+---
+`))
+
+	require.NoError(t, MarkImplemented(storyPath, "", fs))
+
+	content, _ := fs.ReadFile(storyPath)
+	assert.Contains(t, string(content), "implemented: true\n")
+	story, err := models.LoadUserStoryFromFile(storyPath, content)
+	require.NoError(t, err)
+	assert.True(t, story.IsImplemented)
+
+	// The story hash is refreshed and propagated to the change request
+	hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(string(content)))
+	assert.Contains(t, string(content), "_content_hash: "+hash)
+	changeRequest, _ := fs.ReadFile("docs/changes-request/login.blueprint.md")
+	assert.Contains(t, string(changeRequest), "content-hash: "+hash)
+
+	// Unmarking removes the field again
+	require.NoError(t, UnmarkImplemented(storyPath, "", fs))
+	content, _ = fs.ReadFile(storyPath)
+	assert.False(t, strings.Contains(string(content), "implemented:"))
+	story, err = models.LoadUserStoryFromFile(storyPath, content)
+	require.NoError(t, err)
+	assert.False(t, story.IsImplemented)
+}
+
+func TestMarkImplemented_EditedStoryHasNoMismatches(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	storyPath := "docs/user-stories/01-login.md"
+	fs.AddFile(storyPath, []byte("---\nfile_path: docs/user-stories/01-login.md\n_content_hash: previous\n---\n\n# Login\n\nEdited since\n"))
+	fs.AddFile("docs/changes-request/login.blueprint.md", []byte(`---
+name: Login
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: previous
+---
+`))
+
+	// The reference holds the hash the story had before its metadata was refreshed
+	mismatched, err := setImplemented(storyPath, "", fs, true)
+	require.NoError(t, err)
+	assert.Empty(t, mismatched)
+
+	content, _ := fs.ReadFile(storyPath)
+	hash := metadata.CalculateContentHash(metadata.GetContentWithoutMetadata(string(content)))
+	changeRequest, _ := fs.ReadFile("docs/changes-request/login.blueprint.md")
+	assert.Contains(t, string(changeRequest), "content-hash: "+hash)
+}
+
+func TestMarkImplemented_NoChangeRequests(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+
+	require.NoError(t, MarkImplemented("docs/user-stories/01-login.md", "", fs))
+
+	content, _ := fs.ReadFile("docs/user-stories/01-login.md")
+	assert.Contains(t, string(content), "implemented: true\n")
+}
+
+func TestMarkImplemented_MissingStory(t *testing.T) {
+	err := MarkImplemented("docs/user-stories/missing.md", "", io.NewMockFileSystem())
+
+	assert.ErrorContains(t, err, "docs/user-stories/missing.md")
+}
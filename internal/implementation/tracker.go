@@ -10,16 +10,29 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
 // IsUserStoryImplemented checks if a user story is referenced by any implemented change request
+// of the change requests directories
 func IsUserStoryImplemented(userStory models.UserStory, fs io.FileSystem) (bool, error) {
-	// Define the change requests directory
-	changeRequestsDir := "docs/changes-request"
+	for _, changeRequestsDir := range config.ChangesDirs() {
+		implemented, err := isImplementedIn(changeRequestsDir, userStory, fs)
+		if err != nil || implemented {
+			return implemented, err
+		}
+	}
 
+	// If we get here, the user story is not referenced in any implemented change request
+	return false, nil
+}
+
+// isImplementedIn checks if a user story is referenced by an implemented
+// change request of one change requests directory
+func isImplementedIn(changeRequestsDir string, userStory models.UserStory, fs io.FileSystem) (bool, error) {
 	// Check if the directory exists
 	if !fs.Exists(changeRequestsDir) {
 		return false, nil // No change requests directory means no implementations
@@ -71,12 +84,16 @@ func IsUserStoryImplemented(userStory models.UserStory, fs io.FileSystem) (bool,
 		}
 	}
 
-	// If we get here, the user story is not referenced in any implemented change request
 	return false, nil
 }
 
-// UpdateImplementationStatus updates the IsImplemented flag on a user story
+// UpdateImplementationStatus updates the IsImplemented flag on a user story.
+// A story already flagged from its "implemented" front-matter field stays implemented.
 func UpdateImplementationStatus(userStory *models.UserStory, fs io.FileSystem) error {
+	if userStory.IsImplemented {
+		return nil
+	}
+	
 	implemented, err := IsUserStoryImplemented(*userStory, fs)
 	if err != nil {
 		return err
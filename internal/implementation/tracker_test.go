@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/models"
 )
@@ -95,4 +96,27 @@ This is the implementation for the user story.
 	err = UpdateImplementationStatus(&userStory, mockFS)
 	assert.NoError(t, err)
 	assert.True(t, userStory.IsImplemented, "UpdateImplementationStatus should set IsImplemented flag to true")
-} 
\ No newline at end of file
+} 
+
+func TestIsUserStoryImplemented_SeveralDirectories(t *testing.T) {
+	t.Setenv(config.ChangesDirEnv, "teams/billing:teams/search")
+	mockFS := io.NewMockFileSystem()
+	userStory := models.UserStory{Title: "Filters", FilePath: "docs/user-stories/02-filters.md"}
+
+	mockFS.AddFile("teams/search/filters.blueprint.md", []byte(`---
+name: filters
+created-at: 2025-01-01T00:00:00Z
+user-stories:
+  - title: Filters
+    file: docs/user-stories/02-filters.md
+    content-hash: abcdef123456
+---
+
+# Blueprint
+`))
+	mockFS.AddFile("teams/search/filters.implementation.md", []byte("# Implementation\n"))
+
+	isImplemented, err := IsUserStoryImplemented(userStory, mockFS)
+	assert.NoError(t, err)
+	assert.True(t, isImplemented, "Change requests of every directory should count")
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// FormValues are the values entered in the user story and feature request
+// forms, for building the same models without a terminal
+type FormValues struct {
+	Title       string
+	Description string
+	As          string   // The user type of the "As a ..." statement
+	Want        string   // The capability of the "I want ..." statement
+	SoThat      string   // The benefit of the "so that ..." statement
+	Criteria    []string // Acceptance criteria; empty ones are skipped
+	// SortCriteria writes the acceptance criteria of user stories in
+	// alphabetical order instead of the order they were entered in
+	SortCriteria bool
+}
+
+// isEmpty reports whether no value was entered
+func (v FormValues) isEmpty() bool {
+	if v.Title != "" || v.Description != "" || v.As != "" || v.Want != "" || v.SoThat != "" {
+		return false
+	}
+	for _, criterion := range v.Criteria {
+		if criterion != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildUserStory assembles a user story from values the way the user story
+// form does, keeping the file path and timestamps of us. It returns
+// ErrIncompleteForm when the title is missing, as the file name derives
+// from it.
+func BuildUserStory(us models.UserStory, values FormValues) (models.UserStory, error) {
+	if strings.TrimSpace(values.Title) == "" {
+		return us, fmt.Errorf("%w: the user story needs a title", ErrIncompleteForm)
+	}
+	return assembleUserStory(us, values), nil
+}
+
+// assembleUserStory renders the content and metadata of a user story
+func assembleUserStory(us models.UserStory, values FormValues) models.UserStory {
+	us.Title = values.Title
+
+	// Build the content without metadata
+	var contentWithoutMetadata strings.Builder
+
+	// Add title
+	contentWithoutMetadata.WriteString(fmt.Sprintf("# %s\n", us.Title))
+
+	// Add description
+	if values.Description != "" {
+		contentWithoutMetadata.WriteString(values.Description + "\n\n")
+	}
+
+	// Add user story
+	contentWithoutMetadata.WriteString(fmt.Sprintf("As a %s\nI want %s\nso that %s\n\n",
+		values.As,
+		values.Want,
+		values.SoThat))
+
+	// Add acceptance criteria
+	contentWithoutMetadata.WriteString("## Acceptance criteria\n")
+	criteria := values.Criteria
+	if values.SortCriteria {
+		criteria = sortedCriteria(criteria)
+	}
+	contentWithoutMetadata.WriteString(models.FormatAcceptanceCriteria(criteria, models.CriteriaBulletStyle()))
+
+	// Calculate content hash from content without metadata
+	var contentHash string
+	if !values.isEmpty() {
+		contentHash = models.GenerateContentHash(contentWithoutMetadata.String())
+	} else {
+		contentHash = "d41d8cd98f00b204e9800998ecf8427e" // MD5 hash of empty string
+	}
+
+	// Build final content with metadata and hash
+	var finalContent strings.Builder
+	finalContent.WriteString("---\n")
+	finalContent.WriteString(fmt.Sprintf("file_path: %s\n", us.FilePath))
+	finalContent.WriteString(fmt.Sprintf("created_at: %s\n", models.FormatTimestamp(us.CreatedAt)))
+	finalContent.WriteString(fmt.Sprintf("last_updated: %s\n", models.FormatTimestamp(us.LastUpdated)))
+	finalContent.WriteString(fmt.Sprintf("_content_hash: %s\n", contentHash))
+	if len(us.Tags) > 0 {
+		finalContent.WriteString(fmt.Sprintf("tags: %s\n", models.FormatTags(us.Tags)))
+	}
+	finalContent.WriteString("---\n\n")
+	finalContent.WriteString(contentWithoutMetadata.String())
+
+	us.Content = finalContent.String()
+	us.ContentHash = contentHash
+
+	return us
+}
+
+// sortedCriteria returns the non-empty criteria sorted alphabetically,
+// ignoring case
+func sortedCriteria(criteria []string) []string {
+	sorted := make([]string, 0, len(criteria))
+	for _, criterion := range criteria {
+		if criterion = strings.TrimSpace(criterion); criterion != "" {
+			sorted = append(sorted, criterion)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+	})
+	return sorted
+}
+
+// BuildFeatureRequest assembles a feature request from values the way the
+// feature request form does, keeping the creation time of fr. It returns
+// ErrIncompleteForm unless the request is complete enough to be submitted.
+func BuildFeatureRequest(fr models.FeatureRequest, values FormValues) (models.FeatureRequest, error) {
+	fr = assembleFeatureRequest(fr, values)
+	if !fr.IsComplete() {
+		return fr, fmt.Errorf("%w: a feature request needs a title, a description, a user story and acceptance criteria", ErrIncompleteForm)
+	}
+	return fr, nil
+}
+
+// assembleFeatureRequest copies values into a feature request
+func assembleFeatureRequest(fr models.FeatureRequest, values FormValues) models.FeatureRequest {
+	fr.Title = values.Title
+	fr.Description = values.Description
+
+	// Combine user story parts
+	asValue := strings.TrimSpace(values.As)
+	wantValue := strings.TrimSpace(values.Want)
+	soThatValue := strings.TrimSpace(values.SoThat)
+
+	userStory := ""
+	if asValue != "" {
+		userStory = "As a " + asValue
+		if wantValue != "" {
+			userStory += " I want " + wantValue
+			if soThatValue != "" {
+				userStory += " so that " + soThatValue
+			}
+		}
+	}
+
+	fr.UserStory = userStory
+
+	// For backwards compatibility, store the combined user story in the importance field
+	fr.Importance = userStory
+
+	// Collect non-empty acceptance criteria
+	var criteria []string
+	for _, criterion := range values.Criteria {
+		if criterion = strings.TrimSpace(criterion); criterion != "" {
+			criteria = append(criteria, criterion)
+		}
+	}
+	fr.AcceptanceCriteria = criteria
+
+	return fr
+}
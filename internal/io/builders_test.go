@@ -0,0 +1,98 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestBuildUserStory(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	base := models.UserStory{FilePath: "docs/user-stories/01-reset.md", CreatedAt: now, LastUpdated: now}
+
+	us, err := BuildUserStory(base, FormValues{
+		Title:    "Reset password",
+		As:       "user",
+		Want:     "to reset my password",
+		SoThat:   "I can log in again",
+		Criteria: []string{"A reset link is emailed", ""},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Reset password", us.Title)
+	assert.Contains(t, us.Content, "file_path: docs/user-stories/01-reset.md\n")
+	assert.Contains(t, us.Content, "# Reset password\nAs a user\nI want to reset my password\nso that I can log in again\n")
+	assert.Contains(t, us.Content, "## Acceptance criteria\n- A reset link is emailed\n")
+	assert.NotEmpty(t, us.ContentHash)
+
+	_, err = BuildUserStory(base, FormValues{Title: "  ", As: "user"})
+	assert.ErrorIs(t, err, ErrIncompleteForm)
+}
+
+func TestBuildUserStoryMatchesForm(t *testing.T) {
+	base := models.UserStory{FilePath: "story.md", CreatedAt: time.Now(), LastUpdated: time.Now()}
+
+	form := NewUserStoryForm(base)
+	form.titleInput.SetValue("Export data")
+	form.descInput.SetValue("Let users take their data with them")
+	form.asInput.SetValue("user")
+	form.wantInput.SetValue("to export my data")
+	form.soThatInput.SetValue("I can move elsewhere")
+	form.acInputs[0].SetValue("A CSV file is downloaded")
+
+	built, err := BuildUserStory(base, form.values())
+	require.NoError(t, err)
+	assert.Equal(t, form.GetUserStory(), built)
+}
+
+func TestBuildUserStorySortCriteria(t *testing.T) {
+	base := models.UserStory{FilePath: "story.md", CreatedAt: time.Now(), LastUpdated: time.Now()}
+	values := FormValues{
+		Title:    "Export data",
+		Criteria: []string{"The file is named after the date", "", "a CSV file is downloaded", "Empty exports are refused"},
+	}
+
+	us, err := BuildUserStory(base, values)
+	require.NoError(t, err)
+	assert.Contains(t, us.Content, "- The file is named after the date\n- a CSV file is downloaded\n- Empty exports are refused\n")
+
+	values.SortCriteria = true
+	us, err = BuildUserStory(base, values)
+	require.NoError(t, err)
+	assert.Contains(t, us.Content, "## Acceptance criteria\n- a CSV file is downloaded\n- Empty exports are refused\n- The file is named after the date\n")
+	assert.Equal(t, []string{"The file is named after the date", "", "a CSV file is downloaded", "Empty exports are refused"}, values.Criteria)
+
+	form := NewUserStoryForm(base)
+	form.titleInput.SetValue(values.Title)
+	form.acInputs = newCriteriaInputs([]string{values.Criteria[0], values.Criteria[2]})
+	form.SetSortCriteria(true)
+	assert.Contains(t, form.GetUserStory().Content, "- a CSV file is downloaded\n- The file is named after the date\n")
+}
+
+func TestBuildFeatureRequest(t *testing.T) {
+	values := FormValues{
+		Title:       "Dark mode",
+		Description: "A dark theme for the picker",
+		As:          "developer",
+		Want:        "a dark theme",
+		SoThat:      "my eyes rest",
+		Criteria:    []string{" The picker has a dark theme ", ""},
+	}
+
+	fr, err := BuildFeatureRequest(models.NewFeatureRequest(), values)
+	require.NoError(t, err)
+	assert.Equal(t, "As a developer I want a dark theme so that my eyes rest", fr.UserStory)
+	assert.Equal(t, fr.UserStory, fr.Importance)
+	assert.Equal(t, []string{"The picker has a dark theme"}, fr.AcceptanceCriteria)
+
+	values.Criteria = nil
+	_, err = BuildFeatureRequest(models.NewFeatureRequest(), values)
+	assert.ErrorIs(t, err, ErrIncompleteForm)
+}
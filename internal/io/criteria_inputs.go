@@ -0,0 +1,53 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// DefaultCriteriaSlots is the number of acceptance criteria inputs a form
+// starts with; more are added as they are filled in
+const DefaultCriteriaSlots = 5
+
+// newCriteriaInputs creates one input per value, and empty ones up to
+// DefaultCriteriaSlots
+func newCriteriaInputs(values []string) []textinput.Model {
+	count := DefaultCriteriaSlots
+	if len(values) > count {
+		count = len(values)
+	}
+
+	inputs := make([]textinput.Model, count)
+	for i := range inputs {
+		inputs[i] = newCriteriaInput(i)
+		if i < len(values) {
+			inputs[i].SetValue(values[i])
+		}
+	}
+	return inputs
+}
+
+// newCriteriaInput creates the input of the criterion at index i
+func newCriteriaInput(i int) textinput.Model {
+	input := textinput.New()
+	input.Placeholder = fmt.Sprintf("Enter acceptance criteria %d", i+1)
+	input.Width = 80
+	input.CharLimit = 200
+	return input
+}
+
+// growCriteriaInputs appends an empty input when the criterion at index is
+// the last one and has been filled in, so there is always room for one more
+func growCriteriaInputs(inputs []textinput.Model, index int) []textinput.Model {
+	if index != len(inputs)-1 || strings.TrimSpace(inputs[index].Value()) == "" {
+		return inputs
+	}
+	return append(inputs, newCriteriaInput(len(inputs)))
+}
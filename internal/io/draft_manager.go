@@ -8,82 +8,133 @@ import (
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
+// DraftsDir is the directory, relative to the working directory, where
+// interrupted feature requests are kept until they are submitted
+const DraftsDir = ".usm/drafts"
+
+// featureRequestDraftFile is the name of the feature request draft in DraftsDir
+const featureRequestDraftFile = "feature_request.json"
+
 // DraftManager handles feature request drafts
 type DraftManager struct {
 	fs FileSystem
+	// legacyPath is where drafts were saved before DraftsDir, in the home
+	// directory; a draft left there is still loaded, and removed once saved
+	// again or deleted
+	legacyPath string
 }
 
 // NewDraftManager creates a new draft manager
 func NewDraftManager(fs FileSystem) *DraftManager {
-	return &DraftManager{fs: fs}
+	dm := &DraftManager{fs: fs}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		dm.legacyPath = filepath.Join(homeDir, ".usm", "feature_request_draft.json")
+	}
+	return dm
 }
 
-// GetDraftPath returns the path to the draft file
+// GetDraftPath returns the path to the draft file, creating DraftsDir if needed
 func (dm *DraftManager) GetDraftPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	
-	configDir := filepath.Join(homeDir, ".usm")
-	if !dm.fs.Exists(configDir) {
-		if err := dm.fs.MkdirAll(configDir, 0755); err != nil {
+	if !dm.fs.Exists(DraftsDir) {
+		if err := dm.fs.MkdirAll(DraftsDir, 0755); err != nil {
 			return "", err
 		}
 	}
-	
-	return filepath.Join(configDir, "feature_request_draft.json"), nil
+
+	return filepath.Join(DraftsDir, featureRequestDraftFile), nil
 }
 
-// SaveDraft saves a feature request draft to disk
+// HasDraft reports whether there is a draft to resume, in DraftsDir or in
+// the legacy location
+func (dm *DraftManager) HasDraft() bool {
+	return dm.fs.Exists(filepath.Join(DraftsDir, featureRequestDraftFile)) ||
+		(dm.legacyPath != "" && dm.fs.Exists(dm.legacyPath))
+}
+
+// SaveDraft saves a feature request draft to DraftsDir, removing the one of
+// the legacy location
 func (dm *DraftManager) SaveDraft(fr models.FeatureRequest) error {
 	draftPath, err := dm.GetDraftPath()
 	if err != nil {
 		return err
 	}
-	
-	data, err := json.Marshal(fr)
+
+	data, err := json.MarshalIndent(fr, "", "  ")
 	if err != nil {
 		return err
 	}
-	
-	return dm.fs.WriteFile(draftPath, data, 0644)
+
+	if err := dm.fs.WriteFile(draftPath, data, 0644); err != nil {
+		return err
+	}
+	return dm.removeLegacyDraft()
 }
 
-// LoadDraft loads a feature request draft from disk
+// LoadDraft loads the feature request draft saved by SaveDraft, or the one
+// left in the legacy location by older versions. A new feature request is
+// returned when there is no draft.
 func (dm *DraftManager) LoadDraft() (models.FeatureRequest, error) {
-	draftPath, err := dm.GetDraftPath()
-	if err != nil {
-		return models.NewFeatureRequest(), err
-	}
-	
+	draftPath := filepath.Join(DraftsDir, featureRequestDraftFile)
 	if !dm.fs.Exists(draftPath) {
-		return models.NewFeatureRequest(), nil
+		if dm.legacyPath == "" || !dm.fs.Exists(dm.legacyPath) {
+			return models.NewFeatureRequest(), nil
+		}
+		draftPath = dm.legacyPath
 	}
-	
+
 	data, err := dm.fs.ReadFile(draftPath)
 	if err != nil {
 		return models.NewFeatureRequest(), err
 	}
-	
+
 	var fr models.FeatureRequest
 	if err := json.Unmarshal(data, &fr); err != nil {
 		return models.NewFeatureRequest(), err
 	}
-	
+
 	return fr, nil
 }
 
-// DeleteDraft deletes the draft file
+// DeleteDraft deletes the draft file, in DraftsDir and in the legacy
+// location
 func (dm *DraftManager) DeleteDraft() error {
-	draftPath, err := dm.GetDraftPath()
-	if err != nil {
-		return err
-	}
-	
+	draftPath := filepath.Join(DraftsDir, featureRequestDraftFile)
 	if dm.fs.Exists(draftPath) {
-		return os.Remove(draftPath)
+		if err := dm.fs.Remove(draftPath); err != nil {
+			return err
+		}
 	}
-	
-	return nil
-} 
\ No newline at end of file
+	return dm.removeLegacyDraft()
+}
+
+// removeLegacyDraft removes the draft of the legacy location, if any
+func (dm *DraftManager) removeLegacyDraft() error {
+	if dm.legacyPath == "" || !dm.fs.Exists(dm.legacyPath) {
+		return nil
+	}
+	return dm.fs.Remove(dm.legacyPath)
+}
+
+// SaveDraftToFile saves an in-progress feature request with a DraftManager
+// and returns the path of the draft
+func SaveDraftToFile(fr models.FeatureRequest, fs FileSystem) (string, error) {
+	dm := NewDraftManager(fs)
+	if err := dm.SaveDraft(fr); err != nil {
+		return "", err
+	}
+	return dm.GetDraftPath()
+}
+
+// LoadDraft loads the feature request draft saved by SaveDraftToFile. found
+// is false, with a new feature request, when there is no draft to resume.
+func LoadDraft(fs FileSystem) (fr models.FeatureRequest, found bool, err error) {
+	dm := NewDraftManager(fs)
+	if !dm.HasDraft() {
+		return models.NewFeatureRequest(), false, nil
+	}
+	fr, err = dm.LoadDraft()
+	if err != nil {
+		return fr, false, err
+	}
+	return fr, true, nil
+}
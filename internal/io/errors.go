@@ -14,4 +14,5 @@ var (
 	ErrUnexpectedModel = errors.New("unexpected model type")
 	ErrSelectionCanceled = errors.New("selection canceled")
 	ErrTypeCast        = errors.New("could not cast value")
+	ErrIncompleteForm  = errors.New("incomplete form")
 ) 
\ No newline at end of file
@@ -13,6 +13,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/ui/styles"
 )
 
 // Field represents a form field type
@@ -24,11 +25,7 @@ const (
 	UserStoryAsField
 	UserStoryWantField
 	UserStorySoThatField
-	AcceptanceCriteria1Field
-	AcceptanceCriteria2Field
-	AcceptanceCriteria3Field
-	AcceptanceCriteria4Field
-	AcceptanceCriteria5Field
+	AcceptanceCriteriaField // The criterion being edited is activeACIndex
 	ReviewField
 )
 
@@ -44,6 +41,7 @@ type FeatureForm struct {
 	activeField          FieldType
 	activeACIndex        int
 	reviewMode           bool
+	reviewOffset         int  // First acceptance criterion shown in review mode
 	ConfirmSubmission    bool // User confirmed submission
 	editMode             bool
 	cancel               bool
@@ -68,25 +66,7 @@ func NewFeatureForm(fr models.FeatureRequest) *FeatureForm {
 	descInput.SetValue(fr.Description)
 
 	// Parse existing user story if available
-	userStoryAs := ""
-	userStoryWant := ""
-	userStorySoThat := ""
-
-	if fr.UserStory != "" {
-		parts := strings.Split(fr.UserStory, " I want ")
-		if len(parts) > 1 {
-			userStoryAs = strings.TrimPrefix(parts[0], "As a ")
-			remainingParts := strings.Split(parts[1], " so that ")
-			if len(remainingParts) > 1 {
-				userStoryWant = remainingParts[0]
-				userStorySoThat = remainingParts[1]
-			} else {
-				userStoryWant = parts[1]
-			}
-		} else {
-			userStoryAs = strings.TrimPrefix(fr.UserStory, "As a ")
-		}
-	}
+	userStoryAs, userStoryWant, userStorySoThat := models.SplitUserStory(fr.UserStory)
 
 	userStoryAsInput := textinput.New()
 	userStoryAsInput.Placeholder = " Enter user type"
@@ -106,19 +86,8 @@ func NewFeatureForm(fr models.FeatureRequest) *FeatureForm {
 	userStorySoThatInput.CharLimit = 100
 	userStorySoThatInput.SetValue(userStorySoThat)
 
-	// Create 5 acceptance criteria inputs
-	acInputs := make([]textinput.Model, 5)
-	for i := 0; i < 5; i++ {
-		acInputs[i] = textinput.New()
-		acInputs[i].Placeholder = fmt.Sprintf("Enter acceptance criteria %d", i+1)
-		acInputs[i].Width = 80
-		acInputs[i].CharLimit = 200
-
-		// Set values from existing AC if available
-		if i < len(fr.AcceptanceCriteria) {
-			acInputs[i].SetValue(fr.AcceptanceCriteria[i])
-		}
-	}
+	// Create the acceptance criteria inputs, with room for every existing criterion
+	acInputs := newCriteriaInputs(fr.AcceptanceCriteria)
 
 	form := &FeatureForm{
 		fr:                   fr,
@@ -181,6 +150,16 @@ func (f *FeatureForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return f, tea.Quit
 			}
 
+		case tea.KeyUp:
+			if f.reviewMode {
+				f.scrollReview(-1)
+			}
+
+		case tea.KeyDown:
+			if f.reviewMode {
+				f.scrollReview(1)
+			}
+
 		case tea.KeyEsc:
 			if f.reviewMode {
 				// Exit review mode and go back to editing
@@ -209,20 +188,8 @@ func (f *FeatureForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case UserStorySoThatField:
 					f.userStorySoThatInput, cmd = f.userStorySoThatInput.Update(msg)
 					cmds = append(cmds, cmd)
-				case AcceptanceCriteria1Field:
-					f.acInputs[0], cmd = f.acInputs[0].Update(msg)
-					cmds = append(cmds, cmd)
-				case AcceptanceCriteria2Field:
-					f.acInputs[1], cmd = f.acInputs[1].Update(msg)
-					cmds = append(cmds, cmd)
-				case AcceptanceCriteria3Field:
-					f.acInputs[2], cmd = f.acInputs[2].Update(msg)
-					cmds = append(cmds, cmd)
-				case AcceptanceCriteria4Field:
-					f.acInputs[3], cmd = f.acInputs[3].Update(msg)
-					cmds = append(cmds, cmd)
-				case AcceptanceCriteria5Field:
-					f.acInputs[4], cmd = f.acInputs[4].Update(msg)
+				case AcceptanceCriteriaField:
+					f.acInputs[f.activeACIndex], cmd = f.acInputs[f.activeACIndex].Update(msg)
 					cmds = append(cmds, cmd)
 				}
 			} else {
@@ -257,16 +224,8 @@ func (f *FeatureForm) View() string {
 	var b strings.Builder
 
 	if f.reviewMode {
-		// If all acceptance criteria are empty and we're in review mode, go directly to confirmation
-		allEmpty := true
-		for _, ac := range f.fr.AcceptanceCriteria {
-			if ac != "" {
-				allEmpty = false
-				break
-			}
-		}
-
-		if allEmpty {
+		// If there are no acceptance criteria and we're in review mode, go directly to confirmation
+		if len(f.fr.AcceptanceCriteria) == 0 {
 			return f.renderConfirmationOnly()
 		}
 
@@ -284,33 +243,19 @@ func (f *FeatureForm) View() string {
 	asStyle := lipgloss.NewStyle()
 	wantStyle := lipgloss.NewStyle()
 	soThatStyle := lipgloss.NewStyle()
-	ac1Style := lipgloss.NewStyle()
-	ac2Style := lipgloss.NewStyle()
-	ac3Style := lipgloss.NewStyle()
-	ac4Style := lipgloss.NewStyle()
-	ac5Style := lipgloss.NewStyle()
+	activeStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.CurrentPalette().Info)
 
 	switch f.activeField {
 	case TitleField:
-		titleStyle = titleStyle.Bold(true).Foreground(lipgloss.Color("12"))
+		titleStyle = activeStyle
 	case DescriptionField:
-		descStyle = descStyle.Bold(true).Foreground(lipgloss.Color("12"))
+		descStyle = activeStyle
 	case UserStoryAsField:
-		asStyle = asStyle.Bold(true).Foreground(lipgloss.Color("12"))
+		asStyle = activeStyle
 	case UserStoryWantField:
-		wantStyle = wantStyle.Bold(true).Foreground(lipgloss.Color("12"))
+		wantStyle = activeStyle
 	case UserStorySoThatField:
-		soThatStyle = soThatStyle.Bold(true).Foreground(lipgloss.Color("12"))
-	case AcceptanceCriteria1Field:
-		ac1Style = ac1Style.Bold(true).Foreground(lipgloss.Color("12"))
-	case AcceptanceCriteria2Field:
-		ac2Style = ac2Style.Bold(true).Foreground(lipgloss.Color("12"))
-	case AcceptanceCriteria3Field:
-		ac3Style = ac3Style.Bold(true).Foreground(lipgloss.Color("12"))
-	case AcceptanceCriteria4Field:
-		ac4Style = ac4Style.Bold(true).Foreground(lipgloss.Color("12"))
-	case AcceptanceCriteria5Field:
-		ac5Style = ac5Style.Bold(true).Foreground(lipgloss.Color("12"))
+		soThatStyle = activeStyle
 	}
 
 	// Define label settings
@@ -339,23 +284,18 @@ func (f *FeatureForm) View() string {
 	// Acceptance Criteria fields
 	b.WriteString(headerStyle.Render("Acceptance Criteria") + "\n")
 
-	b.WriteString(ac1Style.Width(labelWidth).Render("1:"))
-	b.WriteString(" " + f.acInputs[0].View() + "\n")
-
-	b.WriteString(ac2Style.Width(labelWidth).Render("2:"))
-	b.WriteString(" " + f.acInputs[1].View() + "\n")
-
-	b.WriteString(ac3Style.Width(labelWidth).Render("3:"))
-	b.WriteString(" " + f.acInputs[2].View() + "\n")
-
-	b.WriteString(ac4Style.Width(labelWidth).Render("4:"))
-	b.WriteString(" " + f.acInputs[3].View() + "\n")
-
-	b.WriteString(ac5Style.Width(labelWidth).Render("5:"))
-	b.WriteString(" " + f.acInputs[4].View() + "\n\n")
+	for i, input := range f.acInputs {
+		acStyle := lipgloss.NewStyle()
+		if f.activeField == AcceptanceCriteriaField && f.activeACIndex == i {
+			acStyle = activeStyle
+		}
+		b.WriteString(acStyle.Width(labelWidth).Render(fmt.Sprintf("%d:", i+1)))
+		b.WriteString(" " + input.View() + "\n")
+	}
+	b.WriteString("\n")
 
 	// Navigation help
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).AlignHorizontal(lipgloss.Left)
+	helpStyle := lipgloss.NewStyle().Foreground(styles.CurrentPalette().Muted).AlignHorizontal(lipgloss.Left)
 	b.WriteString(helpStyle.Render(
 		"Tab: next field, Shift+Tab: previous field, Enter: confirm field\n" +
 			"Press Tab after filling all fields to submit\n" +
@@ -374,7 +314,14 @@ func (f *FeatureForm) renderConfirmationOnly() string {
 	return b.String()
 }
 
-// renderReviewMode renders the review mode view
+// reviewChromeLines is the number of lines of the review screen around the
+// acceptance criteria: the header, the four field lines, the criteria
+// heading, the scroll indicator and the confirmation prompt
+const reviewChromeLines = 10
+
+// renderReviewMode renders the review mode view. When the acceptance
+// criteria do not fit in the terminal, only a window of them is shown and
+// the arrow keys scroll it.
 func (f *FeatureForm) renderReviewMode() string {
 	var b strings.Builder
 
@@ -390,10 +337,14 @@ func (f *FeatureForm) renderReviewMode() string {
 	b.WriteString(f.fr.UserStory + "\n")
 
 	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Acceptance Criteria:\n"))
-	for i, criteria := range f.fr.AcceptanceCriteria {
-		if criteria != "" {
-			b.WriteString(fmt.Sprintf("%d. %s\n", i+1, criteria))
-		}
+	criteria := f.fr.AcceptanceCriteria
+	start, end := f.reviewWindow()
+	for i := start; i < end; i++ {
+		b.WriteString(fmt.Sprintf("%d. %s\n", i+1, criteria[i]))
+	}
+	if start > 0 || end < len(criteria) {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.CurrentPalette().Muted).Render(
+			fmt.Sprintf("↑/↓ to scroll (%d-%d of %d)", start+1, end, len(criteria))) + "\n")
 	}
 
 	b.WriteString("\nSubmit this feature request? [Y/n]\n")
@@ -402,6 +353,34 @@ func (f *FeatureForm) renderReviewMode() string {
 	return b.String()
 }
 
+// reviewWindow returns the range of acceptance criteria shown in review mode
+func (f *FeatureForm) reviewWindow() (start, end int) {
+	total := len(f.fr.AcceptanceCriteria)
+	visible := f.height - reviewChromeLines
+	if visible < 1 {
+		visible = 1
+	}
+	if visible >= total {
+		return 0, total
+	}
+
+	start = f.reviewOffset
+	if start > total-visible {
+		start = total - visible
+	}
+	if start < 0 {
+		start = 0
+	}
+	return start, start + visible
+}
+
+// scrollReview moves the review window of acceptance criteria by delta lines
+func (f *FeatureForm) scrollReview(delta int) {
+	start, _ := f.reviewWindow()
+	f.reviewOffset = start + delta
+	f.reviewOffset, _ = f.reviewWindow()
+}
+
 // RenderThankYouMessage returns a warm thank you message after submission
 func (f *FeatureForm) RenderThankYouMessage() string {
 	var b strings.Builder
@@ -409,14 +388,14 @@ func (f *FeatureForm) RenderThankYouMessage() string {
 	// Add a decorative element
 	thanksStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("10")). // Green color
+		Foreground(styles.CurrentPalette().Success).
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("10")).
+		BorderForeground(styles.CurrentPalette().Success).
 		Padding(1, 2).
 		Align(lipgloss.Center)
 
 	messageStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("15")). // White color
+		Foreground(styles.CurrentPalette().Strong).
 		Width(60).
 		Align(lipgloss.Center)
 
@@ -451,16 +430,8 @@ func (f *FeatureForm) nextField() {
 		f.userStoryWantInput.Blur()
 	case UserStorySoThatField:
 		f.userStorySoThatInput.Blur()
-	case AcceptanceCriteria1Field:
-		f.acInputs[0].Blur()
-	case AcceptanceCriteria2Field:
-		f.acInputs[1].Blur()
-	case AcceptanceCriteria3Field:
-		f.acInputs[2].Blur()
-	case AcceptanceCriteria4Field:
-		f.acInputs[3].Blur()
-	case AcceptanceCriteria5Field:
-		f.acInputs[4].Blur()
+	case AcceptanceCriteriaField:
+		f.acInputs[f.activeACIndex].Blur()
 	}
 
 	// Move to next field
@@ -478,24 +449,21 @@ func (f *FeatureForm) nextField() {
 		f.activeField = UserStorySoThatField
 		f.userStorySoThatInput.Focus()
 	case UserStorySoThatField:
-		f.activeField = AcceptanceCriteria1Field
+		f.activeField = AcceptanceCriteriaField
+		f.activeACIndex = 0
 		f.acInputs[0].Focus()
-	case AcceptanceCriteria1Field:
-		f.activeField = AcceptanceCriteria2Field
-		f.acInputs[1].Focus()
-	case AcceptanceCriteria2Field:
-		f.activeField = AcceptanceCriteria3Field
-		f.acInputs[2].Focus()
-	case AcceptanceCriteria3Field:
-		f.activeField = AcceptanceCriteria4Field
-		f.acInputs[3].Focus()
-	case AcceptanceCriteria4Field:
-		f.activeField = AcceptanceCriteria5Field
-		f.acInputs[4].Focus()
-	case AcceptanceCriteria5Field:
-		// Move to review mode when all fields are complete
-		f.activeField = ReviewField
-		f.reviewMode = true
+	case AcceptanceCriteriaField:
+		// Filling in the last criterion makes room for another one
+		f.acInputs = growCriteriaInputs(f.acInputs, f.activeACIndex)
+		if f.activeACIndex < len(f.acInputs)-1 {
+			f.activeACIndex++
+			f.acInputs[f.activeACIndex].Focus()
+		} else {
+			// Move to review mode when all fields are complete
+			f.activeField = ReviewField
+			f.reviewMode = true
+			f.reviewOffset = 0
+		}
 	}
 }
 
@@ -516,16 +484,8 @@ func (f *FeatureForm) prevField() {
 		f.userStoryWantInput.Blur()
 	case UserStorySoThatField:
 		f.userStorySoThatInput.Blur()
-	case AcceptanceCriteria1Field:
-		f.acInputs[0].Blur()
-	case AcceptanceCriteria2Field:
-		f.acInputs[1].Blur()
-	case AcceptanceCriteria3Field:
-		f.acInputs[2].Blur()
-	case AcceptanceCriteria4Field:
-		f.acInputs[3].Blur()
-	case AcceptanceCriteria5Field:
-		f.acInputs[4].Blur()
+	case AcceptanceCriteriaField:
+		f.acInputs[f.activeACIndex].Blur()
 	}
 
 	// Move to previous field
@@ -542,63 +502,36 @@ func (f *FeatureForm) prevField() {
 	case UserStorySoThatField:
 		f.activeField = UserStoryWantField
 		f.userStoryWantInput.Focus()
-	case AcceptanceCriteria1Field:
-		f.activeField = UserStorySoThatField
-		f.userStorySoThatInput.Focus()
-	case AcceptanceCriteria2Field:
-		f.activeField = AcceptanceCriteria1Field
-		f.acInputs[0].Focus()
-	case AcceptanceCriteria3Field:
-		f.activeField = AcceptanceCriteria2Field
-		f.acInputs[1].Focus()
-	case AcceptanceCriteria4Field:
-		f.activeField = AcceptanceCriteria3Field
-		f.acInputs[2].Focus()
-	case AcceptanceCriteria5Field:
-		f.activeField = AcceptanceCriteria4Field
-		f.acInputs[3].Focus()
+	case AcceptanceCriteriaField:
+		if f.activeACIndex > 0 {
+			f.activeACIndex--
+			f.acInputs[f.activeACIndex].Focus()
+		} else {
+			f.activeField = UserStorySoThatField
+			f.userStorySoThatInput.Focus()
+		}
 	case ReviewField:
-		f.activeField = AcceptanceCriteria5Field
-		f.acInputs[4].Focus()
+		f.activeField = AcceptanceCriteriaField
+		f.activeACIndex = len(f.acInputs) - 1
+		f.acInputs[f.activeACIndex].Focus()
 		f.reviewMode = false
 	}
 }
 
 // updateFeatureRequest updates the feature request from form fields
 func (f *FeatureForm) updateFeatureRequest() {
-	f.fr.Title = f.titleInput.Value()
-	f.fr.Description = f.descInput.Value()
-
-	// Combine user story parts
-	asValue := strings.TrimSpace(f.userStoryAsInput.Value())
-	wantValue := strings.TrimSpace(f.userStoryWantInput.Value())
-	soThatValue := strings.TrimSpace(f.userStorySoThatInput.Value())
-
-	userStory := ""
-	if asValue != "" {
-		userStory = "As a " + asValue
-		if wantValue != "" {
-			userStory += " I want " + wantValue
-			if soThatValue != "" {
-				userStory += " so that " + soThatValue
-			}
-		}
-	}
-
-	f.fr.UserStory = userStory
-
-	// For backwards compatibility, store the combined user story in the importance field
-	f.fr.Importance = userStory
-
-	// Collect non-empty acceptance criteria
-	var criteria []string
+	criteria := make([]string, 0, len(f.acInputs))
 	for _, input := range f.acInputs {
-		value := strings.TrimSpace(input.Value())
-		if value != "" {
-			criteria = append(criteria, value)
-		}
+		criteria = append(criteria, input.Value())
 	}
-	f.fr.AcceptanceCriteria = criteria
+	f.fr = assembleFeatureRequest(f.fr, FormValues{
+		Title:       f.titleInput.Value(),
+		Description: f.descInput.Value(),
+		As:          f.userStoryAsInput.Value(),
+		Want:        f.userStoryWantInput.Value(),
+		SoThat:      f.userStorySoThatInput.Value(),
+		Criteria:    criteria,
+	})
 }
 
 // SaveDraft returns the current state of the feature request
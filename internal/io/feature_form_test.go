@@ -7,9 +7,12 @@
 package io
 
 import (
+	"fmt"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
@@ -129,4 +132,68 @@ func TestEmptyFieldsNoUserStory(t *testing.T) {
 	assert.Equal(t, "", savedFR.Title)
 	assert.Equal(t, "", savedFR.Description)
 	assert.Equal(t, 0, len(savedFR.AcceptanceCriteria))
-} 
\ No newline at end of file
+} 
+func TestFeatureFormMoreCriteriaThanDefaultSlots(t *testing.T) {
+	fr := models.NewFeatureRequest()
+	for i := 1; i <= 7; i++ {
+		fr.AcceptanceCriteria = append(fr.AcceptanceCriteria, fmt.Sprintf("Criterion %d", i))
+	}
+	form := NewFeatureForm(fr)
+	assert.Len(t, form.acInputs, 7)
+
+	// Tabbing past the last filled criterion adds an empty one
+	form.activeField = AcceptanceCriteriaField
+	form.activeACIndex = 6
+	form.nextField()
+	assert.Len(t, form.acInputs, 8)
+	assert.Equal(t, 7, form.activeACIndex)
+	assert.Contains(t, form.View(), "8:")
+
+	// Tabbing past the empty one opens the review
+	form.nextField()
+	assert.True(t, form.reviewMode)
+	assert.Len(t, form.GetFeatureRequest().AcceptanceCriteria, 7)
+}
+
+func TestFeatureFormReviewNumbersAndScrollsCriteria(t *testing.T) {
+	fr := models.NewFeatureRequest()
+	fr.Title = "Export"
+	for i := 1; i <= 20; i++ {
+		fr.AcceptanceCriteria = append(fr.AcceptanceCriteria, fmt.Sprintf("Criterion %d", i))
+	}
+	form := NewFeatureForm(fr)
+	form.Update(tea.WindowSizeMsg{Width: 80, Height: 15})
+	form.acInputs[1].SetValue("")
+	form.activeField = AcceptanceCriteriaField
+	form.activeACIndex = len(form.acInputs) - 1
+	form.nextField() // Adds an empty criterion after the last one
+	form.nextField()
+	require.True(t, form.reviewMode)
+
+	// The blank criterion is dropped and the others are numbered 1..N
+	view := form.View()
+	assert.Contains(t, view, "1. Criterion 1\n")
+	assert.Contains(t, view, "2. Criterion 3\n")
+	assert.Contains(t, view, "↑/↓ to scroll (1-5 of 19)")
+	assert.NotContains(t, view, "6. ")
+
+	form.Update(tea.KeyMsg{Type: tea.KeyDown})
+	view = form.View()
+	assert.NotContains(t, view, "1. Criterion 1\n")
+	assert.Contains(t, view, "6. Criterion 7\n")
+	assert.Contains(t, view, "(2-6 of 19)")
+
+	// Scrolling stops at both ends
+	for i := 0; i < 30; i++ {
+		form.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	assert.Contains(t, form.View(), "(15-19 of 19)")
+	for i := 0; i < 30; i++ {
+		form.Update(tea.KeyMsg{Type: tea.KeyUp})
+	}
+	assert.Contains(t, form.View(), "(1-5 of 19)")
+
+	// A tall terminal shows every criterion without the indicator
+	form.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	assert.NotContains(t, form.View(), "to scroll")
+}
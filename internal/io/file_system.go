@@ -33,6 +33,15 @@ type FileSystem interface {
 	
 	// Exists checks if a file or directory exists
 	Exists(path string) bool
+	
+	// Remove deletes the named file or empty directory
+	Remove(name string) error
+
+	// Rename moves a file, replacing the destination if it already exists
+	Rename(oldpath, newpath string) error
+
+	// EvalSymlinks returns the path name after the evaluation of any symbolic links
+	EvalSymlinks(path string) (string, error)
 }
 
 // OSFileSystem implements FileSystem interface with standard os operations
@@ -77,4 +86,19 @@ func (fs *OSFileSystem) Stat(path string) (os.FileInfo, error) {
 // WalkDir walks the file tree rooted at root, calling fn for each file or directory
 func (fs *OSFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
 	return filepath.WalkDir(root, fn)
-} 
\ No newline at end of file
+} 
+
+// Remove deletes the named file or empty directory
+func (fs *OSFileSystem) Remove(path string) error {
+	return os.Remove(path)
+}
+
+// Rename moves a file, replacing the destination if it already exists
+func (fs *OSFileSystem) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// EvalSymlinks returns the path name after the evaluation of any symbolic links
+func (fs *OSFileSystem) EvalSymlinks(path string) (string, error) {
+	return filepath.EvalSymlinks(path)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	goio "io"
+	"strings"
+)
+
+// OutputFormat selects how a command prints its result
+type OutputFormat string
+
+const (
+	// FormatText prints the result for people, as the command always did
+	FormatText OutputFormat = "text"
+	// FormatJSON prints the JSON encoding of the result, for scripts
+	FormatJSON OutputFormat = "json"
+	// FormatTable prints the result as a table
+	FormatTable OutputFormat = "table"
+)
+
+// ParseOutputFormat parses an output format name. An empty value is text.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch format := OutputFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatTable:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, use text, json or table", value)
+	}
+}
+
+// FormattedResult is a command result that every OutputFormatter can print.
+// Its JSON encoding is the machine-readable schema of the command, so it
+// should use json tags with snake_case names.
+type FormattedResult interface {
+	// WriteText prints the result for people
+	WriteText(out UserOutput)
+	// Table returns the result as table headers and rows
+	Table() (headers []string, rows [][]string)
+}
+
+// OutputFormatter prints command results in one format
+type OutputFormatter interface {
+	Format(result FormattedResult) error
+}
+
+// NewOutputFormatter returns the formatter of format. Text and tables are
+// printed to out, JSON is written to w.
+func NewOutputFormatter(format OutputFormat, out UserOutput, w goio.Writer) OutputFormatter {
+	switch format {
+	case FormatJSON:
+		return JSONFormatter{Writer: w}
+	case FormatTable:
+		return TableFormatter{Out: out}
+	default:
+		return TextFormatter{Out: out}
+	}
+}
+
+// TextFormatter prints results for people
+type TextFormatter struct {
+	Out UserOutput
+}
+
+// Format prints the text of result
+func (f TextFormatter) Format(result FormattedResult) error {
+	result.WriteText(f.Out)
+	return nil
+}
+
+// TableFormatter prints results as tables
+type TableFormatter struct {
+	Out UserOutput
+}
+
+// Format prints the table of result
+func (f TableFormatter) Format(result FormattedResult) error {
+	headers, rows := result.Table()
+	f.Out.PrintTable(headers, rows)
+	return nil
+}
+
+// JSONFormatter writes results as indented JSON documents
+type JSONFormatter struct {
+	Writer goio.Writer
+}
+
+// Format writes the JSON encoding of result, followed by a newline
+func (f JSONFormatter) Format(result FormattedResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode result as JSON: %w", err)
+	}
+	_, err = f.Writer.Write(append(data, '\n'))
+	return err
+}
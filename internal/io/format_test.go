@@ -0,0 +1,71 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResult struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (r testResult) WriteText(out UserOutput) {
+	out.Print(r.Name)
+}
+
+func (r testResult) Table() ([]string, [][]string) {
+	return []string{"Name", "Count"}, [][]string{{r.Name, "2"}}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	for value, expected := range map[string]OutputFormat{
+		"":       FormatText,
+		"text":   FormatText,
+		" JSON ": FormatJSON,
+		"table":  FormatTable,
+	} {
+		format, err := ParseOutputFormat(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, format, value)
+	}
+
+	_, err := ParseOutputFormat("yaml")
+	assert.Error(t, err)
+}
+
+func TestOutputFormatters(t *testing.T) {
+	result := testResult{Name: "login", Count: 2}
+
+	t.Run("text", func(t *testing.T) {
+		out := NewMockIO()
+		require.NoError(t, NewOutputFormatter(FormatText, out, nil).Format(result))
+		assert.Equal(t, []string{"login"}, out.Messages)
+		assert.Empty(t, out.Tables)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		out := NewMockIO()
+		require.NoError(t, NewOutputFormatter(FormatTable, out, nil).Format(result))
+		require.Len(t, out.Tables, 1)
+		assert.Equal(t, []string{"Name", "Count"}, out.Tables[0].Headers)
+		assert.Equal(t, [][]string{{"login", "2"}}, out.Tables[0].Rows)
+		assert.Empty(t, out.Messages)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := NewMockIO()
+		var buf bytes.Buffer
+		require.NoError(t, NewOutputFormatter(FormatJSON, out, &buf).Format(result))
+		assert.Equal(t, "{\n  \"name\": \"login\",\n  \"count\": 2\n}\n", buf.String())
+		assert.Empty(t, out.Messages)
+	})
+}
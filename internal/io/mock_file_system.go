@@ -55,8 +55,9 @@ func (m MockFileInfo) Sys() interface{} {
 
 // MockFileEntry implements os.DirEntry for testing purposes
 type MockFileEntry struct {
-	name  string
-	isDir bool
+	name    string
+	isDir   bool
+	symlink bool
 }
 
 // Name returns the name of the file
@@ -71,6 +72,9 @@ func (m MockFileEntry) IsDir() bool {
 
 // Type returns the file mode
 func (m MockFileEntry) Type() os.FileMode {
+	if m.symlink {
+		return os.ModeSymlink
+	}
 	if m.isDir {
 		return os.ModeDir
 	}
@@ -93,6 +97,8 @@ type MockFileSystem struct {
 	DirItems map[string][]os.DirEntry
 	DirInfo  map[string]os.FileInfo
 	FileInfo map[string]os.FileInfo
+	// Symlinks maps the path of each symbolic link to its target
+	Symlinks map[string]string
 	// Track write operations for testing
 	WriteOps []FileWriteOperation
 }
@@ -112,10 +118,105 @@ func NewMockFileSystem() *MockFileSystem {
 		DirItems: make(map[string][]os.DirEntry),
 		DirInfo:  make(map[string]os.FileInfo),
 		FileInfo: make(map[string]os.FileInfo),
+		Symlinks: make(map[string]string),
 		WriteOps: make([]FileWriteOperation, 0),
 	}
 }
 
+// maxSymlinkHops bounds symbolic link resolution, as the OS does, so that
+// link cycles fail instead of looping
+const maxSymlinkHops = 40
+
+// AddSymlink adds a mock symbolic link at path pointing to target. A relative
+// target is resolved from the directory of the link. The target does not
+// need to exist.
+func (fs *MockFileSystem) AddSymlink(path, target string) {
+	path = filepath.Clean(path)
+	if fs.Symlinks == nil {
+		fs.Symlinks = make(map[string]string)
+	}
+	fs.Symlinks[path] = target
+
+	dir := filepath.Dir(path)
+	if _, exists := fs.DirItems[dir]; !exists {
+		fs.AddDirectory(dir)
+	}
+	fs.DirItems[dir] = append(fs.DirItems[dir], MockFileEntry{
+		name:    filepath.Base(path),
+		symlink: true,
+	})
+}
+
+// resolve follows the symbolic links of every component of path
+func (fs *MockFileSystem) resolve(path string) (string, error) {
+	path = filepath.Clean(path)
+	for hops := 0; hops < maxSymlinkHops; hops++ {
+		resolved, followed := fs.resolveFirstLink(path)
+		if !followed {
+			return path, nil
+		}
+		path = resolved
+	}
+	return "", fmt.Errorf("too many levels of symbolic links: %s", path)
+}
+
+// resolveFirstLink replaces the first component of path that is a symbolic
+// link with its target, reporting whether there was one
+func (fs *MockFileSystem) resolveFirstLink(path string) (string, bool) {
+	if len(fs.Symlinks) == 0 {
+		return path, false
+	}
+
+	parts := strings.Split(path, string(filepath.Separator))
+	for i := 1; i <= len(parts); i++ {
+		link := strings.Join(parts[:i], string(filepath.Separator))
+		target, ok := fs.Symlinks[link]
+		if !ok {
+			continue
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(link), target)
+		}
+		return filepath.Join(append([]string{target}, parts[i:]...)...), true
+	}
+	return path, false
+}
+
+// Rename moves a file, keeping its mode and modification time, and replacing
+// the destination if it already exists
+func (fs *MockFileSystem) Rename(oldpath, newpath string) error {
+	oldpath = filepath.Clean(oldpath)
+	newpath = filepath.Clean(newpath)
+
+	content, exists := fs.Files[oldpath]
+	if !exists {
+		return fmt.Errorf("file not found: %s", oldpath)
+	}
+	info := MockFileInfo{name: filepath.Base(newpath), size: int64(len(content)), mode: 0644, modTime: time.Now()}
+	if oldInfo, ok := fs.FileInfo[oldpath]; ok {
+		info.mode = oldInfo.Mode()
+		info.modTime = oldInfo.ModTime()
+	}
+
+	if err := fs.WriteFile(newpath, content, info.mode); err != nil {
+		return err
+	}
+	fs.FileInfo[newpath] = info
+	return fs.Remove(oldpath)
+}
+
+// EvalSymlinks returns the path name after the evaluation of any symbolic links
+func (fs *MockFileSystem) EvalSymlinks(path string) (string, error) {
+	resolved, err := fs.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	if !fs.Exists(resolved) {
+		return "", fmt.Errorf("file or directory not found: %s", path)
+	}
+	return resolved, nil
+}
+
 // AddDirectory adds a mock directory
 func (fs *MockFileSystem) AddDirectory(path string) {
 	// Normalize path to avoid inconsistencies
@@ -193,8 +294,11 @@ func (fs *MockFileSystem) AddFile(path string, content []byte) {
 
 // ReadDir reads the directory named by dirname and returns a list of directory entries
 func (fs *MockFileSystem) ReadDir(path string) ([]os.DirEntry, error) {
-	// Normalize path to avoid inconsistencies
-	path = filepath.Clean(path)
+	// Normalize path and follow symbolic links
+	path, err := fs.resolve(path)
+	if err != nil {
+		return nil, err
+	}
 
 	if entries, exists := fs.DirItems[path]; exists {
 		return entries, nil
@@ -204,8 +308,11 @@ func (fs *MockFileSystem) ReadDir(path string) ([]os.DirEntry, error) {
 
 // ReadFile reads the file named by filename and returns the contents
 func (fs *MockFileSystem) ReadFile(path string) ([]byte, error) {
-	// Normalize path to avoid inconsistencies
-	path = filepath.Clean(path)
+	// Normalize path and follow symbolic links
+	path, err := fs.resolve(path)
+	if err != nil {
+		return nil, err
+	}
 
 	if content, exists := fs.Files[path]; exists {
 		// Return a copy of the content to avoid unexpected modifications
@@ -313,19 +420,57 @@ func (fs *MockFileSystem) MkdirAll(path string, perm os.FileMode) error {
 
 // Exists checks if a file or directory exists
 func (fs *MockFileSystem) Exists(path string) bool {
-	// Normalize path to avoid inconsistencies
-	path = filepath.Clean(path)
+	// Normalize path and follow symbolic links
+	path, err := fs.resolve(path)
+	if err != nil {
+		return false
+	}
 	
 	_, fileExists := fs.Files[path]
 	_, dirExists := fs.DirItems[path]
 	return fileExists || dirExists
 }
 
-// Stat returns file info for the named file
-func (fs *MockFileSystem) Stat(path string) (os.FileInfo, error) {
+// Remove deletes the named file or empty directory
+func (fs *MockFileSystem) Remove(path string) error {
 	// Normalize path to avoid inconsistencies
 	path = filepath.Clean(path)
 	
+	if entries, isDir := fs.DirItems[path]; isDir {
+		if len(entries) > 0 {
+			return fmt.Errorf("directory not empty: %s", path)
+		}
+		delete(fs.DirItems, path)
+		delete(fs.DirInfo, path)
+	} else if _, isFile := fs.Files[path]; isFile {
+		delete(fs.Files, path)
+		delete(fs.FileInfo, path)
+	} else {
+		return fmt.Errorf("file or directory not found: %s", path)
+	}
+	
+	// Drop the entry from its parent directory listing
+	dir := filepath.Dir(path)
+	name := filepath.Base(path)
+	entries := fs.DirItems[dir]
+	for i, entry := range entries {
+		if entry.Name() == name {
+			fs.DirItems[dir] = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+	
+	return nil
+}
+
+// Stat returns file info for the named file
+func (fs *MockFileSystem) Stat(path string) (os.FileInfo, error) {
+	// Normalize path and follow symbolic links
+	path, err := fs.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Check if it's a file
 	if info, exists := fs.FileInfo[path]; exists {
 		return info, nil
@@ -382,6 +527,14 @@ func (fs *MockFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
 		path := queue[0]
 		queue = queue[1:]
 		
+		// Like filepath.WalkDir, report symbolic links without following them
+		if _, isLink := fs.Symlinks[path]; isLink && path != root {
+			if err := fn(path, MockFileEntry{name: filepath.Base(path), symlink: true}, nil); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+		
 		// Get info
 		info, err := fs.Stat(path)
 		if err != nil {
@@ -140,4 +140,85 @@ func TestMockFileSystemGetLastWrite(t *testing.T) {
 		assert.True(t, exists, "GetLastWrite should return true for existing file")
 		assert.Equal(t, string(content), string(write.Content), "Last write content should match the latest update")
 	}
-} 
\ No newline at end of file
+} 
+func TestMockFileSystemRemove(t *testing.T) {
+	fs := NewMockFileSystem()
+	fs.AddFile("docs/a.md", []byte("a"))
+	fs.AddFile("docs/b.md", []byte("b"))
+
+	// A non-empty directory cannot be removed
+	assert.Error(t, fs.Remove("docs"))
+
+	assert.NoError(t, fs.Remove("docs/a.md"))
+	assert.False(t, fs.Exists("docs/a.md"))
+	entries, err := fs.ReadDir("docs")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "b.md", entries[0].Name())
+
+	assert.NoError(t, fs.Remove("docs/b.md"))
+	assert.NoError(t, fs.Remove("docs"))
+	assert.False(t, fs.Exists("docs"))
+
+	assert.Error(t, fs.Remove("docs/missing.md"))
+}
+
+func TestMockFileSystemRename(t *testing.T) {
+	fs := NewMockFileSystem()
+	assert.NoError(t, fs.WriteFile("docs/a.md", []byte("a"), 0600))
+	fs.AddFile("docs/b.md", []byte("b"))
+
+	// The destination is replaced and the mode kept
+	assert.NoError(t, fs.Rename("docs/a.md", "docs/b.md"))
+	assert.False(t, fs.Exists("docs/a.md"))
+	content, err := fs.ReadFile("docs/b.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", string(content))
+	info, err := fs.Stat("docs/b.md")
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode())
+	entries, err := fs.ReadDir("docs")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.Error(t, fs.Rename("docs/missing.md", "docs/c.md"))
+}
+
+func TestMockFileSystemSymlinks(t *testing.T) {
+	fs := NewMockFileSystem()
+	fs.AddDirectory("shared")
+	fs.AddFile("shared/login.md", []byte("# Login"))
+	fs.AddDirectory("docs")
+	fs.AddSymlink("docs/login.md", "../shared/login.md")
+	fs.AddSymlink("docs/shared", "../shared")
+	fs.AddSymlink("docs/loop", "loop")
+
+	// Links are followed when reading
+	content, err := fs.ReadFile("docs/login.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Login", string(content))
+
+	content, err = fs.ReadFile("docs/shared/login.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Login", string(content))
+
+	info, err := fs.Stat("docs/shared")
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	resolved, err := fs.EvalSymlinks("docs/shared/login.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "shared/login.md", resolved)
+
+	// Directory listings report the links themselves
+	entries, err := fs.ReadDir("docs")
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.Equal(t, os.ModeSymlink, entry.Type(), entry.Name())
+	}
+
+	// A link to itself cannot be resolved
+	_, err = fs.Stat("docs/loop")
+	assert.Error(t, err)
+	assert.False(t, fs.Exists("docs/loop"))
+}
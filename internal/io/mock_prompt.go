@@ -115,6 +115,14 @@ func (m *MockIO) PrintStep(stepNumber int, totalSteps int, description string) {
 	m.StepMessages = append(m.StepMessages, message)
 }
 
+// Verbosity reports debug verbosity when debug mode is on, normal otherwise
+func (m *MockIO) Verbosity() Verbosity {
+	if m.DebugEnabled {
+		return VerbosityDebug
+	}
+	return VerbosityNormal
+}
+
 // IsDebugEnabled returns the debug mode status
 func (m *MockIO) IsDebugEnabled() bool {
 	return m.DebugEnabled
@@ -194,6 +202,12 @@ func (m *MockUserIO) PrintStep(stepNumber int, totalSteps int, description strin
 	m.Called(stepNumber, totalSteps, description)
 }
 
+// Verbosity mocks the Verbosity method
+func (m *MockUserIO) Verbosity() Verbosity {
+	args := m.Called()
+	return args.Get(0).(Verbosity)
+}
+
 // IsDebugEnabled mocks the IsDebugEnabled method
 func (m *MockUserIO) IsDebugEnabled() bool {
 	args := m.Called()
@@ -31,6 +31,7 @@ type UserOutput interface {
 	PrintWarning(message string)
 	PrintProgress(message string)
 	PrintStep(stepNumber int, totalSteps int, description string)
+	Verbosity() Verbosity
 	IsDebugEnabled() bool
 }
 
@@ -46,13 +47,13 @@ type TerminalIO struct {
 		progress lipgloss.Style
 		step    lipgloss.Style
 	}
-	debugEnabled bool
+	verbosity Verbosity
 }
 
 // NewTerminalIO creates a new instance of TerminalIO
 func NewTerminalIO() *TerminalIO {
 	t := &TerminalIO{
-		debugEnabled: false,
+		verbosity: VerbosityNormal,
 	}
 	
 	// Configure styles
@@ -71,7 +72,14 @@ func NewTerminalIO() *TerminalIO {
 // NewTerminalIOWithDebug creates a new instance of TerminalIO with debug setting
 func NewTerminalIOWithDebug(debug bool) *TerminalIO {
 	t := NewTerminalIO()
-	t.debugEnabled = debug
+	t.SetDebugMode(debug)
+	return t
+}
+
+// NewTerminalIOWithVerbosity creates a new instance of TerminalIO at the given verbosity
+func NewTerminalIOWithVerbosity(verbosity Verbosity) *TerminalIO {
+	t := NewTerminalIO()
+	t.verbosity = verbosity
 	return t
 }
 
@@ -176,6 +184,9 @@ func (t *TerminalIO) Print(message string) {
 
 // PrintSuccess displays a success message
 func (t *TerminalIO) PrintSuccess(message string) {
+	if t.verbosity < VerbosityNormal {
+		return
+	}
 	fmt.Println(t.styles.success.Render("✓ " + message))
 }
 
@@ -233,23 +244,44 @@ func (t *TerminalIO) PrintWarning(message string) {
 
 // PrintProgress displays a progress message
 func (t *TerminalIO) PrintProgress(message string) {
+	if t.verbosity < VerbosityVerbose {
+		return
+	}
 	fmt.Println(t.styles.progress.Render(message))
 }
 
 // PrintStep displays a step progress message
 func (t *TerminalIO) PrintStep(stepNumber int, totalSteps int, description string) {
+	if t.verbosity < VerbosityNormal {
+		return
+	}
 	message := fmt.Sprintf("Step %d/%d: %s", stepNumber, totalSteps, description)
 	fmt.Println(t.styles.step.Render(message))
 }
 
+// Verbosity returns the current output verbosity
+func (t *TerminalIO) Verbosity() Verbosity {
+	return t.verbosity
+}
+
+// SetVerbosity changes the output verbosity
+func (t *TerminalIO) SetVerbosity(verbosity Verbosity) {
+	t.verbosity = verbosity
+}
+
 // IsDebugEnabled returns whether debug output is enabled
 func (t *TerminalIO) IsDebugEnabled() bool {
-	return t.debugEnabled
+	return t.verbosity >= VerbosityDebug
 }
 
-// SetDebugMode enables or disables debug output
+// SetDebugMode enables or disables debug output. Disabling it drops back to
+// normal verbosity when debug output was on.
 func (t *TerminalIO) SetDebugMode(enabled bool) {
-	t.debugEnabled = enabled
+	if enabled {
+		t.verbosity = VerbosityDebug
+	} else if t.verbosity >= VerbosityDebug {
+		t.verbosity = VerbosityNormal
+	}
 }
 
 // Mock implementations of models for bubbletea
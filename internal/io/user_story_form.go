@@ -13,6 +13,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/ui/styles"
 )
 
 // Field represents a form field type
@@ -39,6 +40,7 @@ type UserStoryForm struct {
 	activeField       UserStoryFieldType
 	activeACIndex     int
 	ConfirmSubmission bool
+	sortCriteria      bool
 	cancel            bool
 	focused           bool
 	width             int
@@ -74,14 +76,8 @@ func NewUserStoryForm(us models.UserStory) *UserStoryForm {
 	soThatInput.Width = 80
 	soThatInput.CharLimit = 100
 
-	// Create 5 acceptance criteria inputs
-	acInputs := make([]textinput.Model, 5)
-	for i := 0; i < 5; i++ {
-		acInputs[i] = textinput.New()
-		acInputs[i].Placeholder = fmt.Sprintf("Enter acceptance criteria %d", i+1)
-		acInputs[i].Width = 80
-		acInputs[i].CharLimit = 200
-	}
+	// Create the acceptance criteria inputs
+	acInputs := newCriteriaInputs(nil)
 
 	form := &UserStoryForm{
 		us:                us,
@@ -192,36 +188,19 @@ func (f *UserStoryForm) View() string {
 	asStyle := lipgloss.NewStyle()
 	wantStyle := lipgloss.NewStyle()
 	soThatStyle := lipgloss.NewStyle()
-	ac1Style := lipgloss.NewStyle()
-	ac2Style := lipgloss.NewStyle()
-	ac3Style := lipgloss.NewStyle()
-	ac4Style := lipgloss.NewStyle()
-	ac5Style := lipgloss.NewStyle()
+	activeStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.CurrentPalette().Accent)
 
 	switch f.activeField {
 	case USTitleField:
-		titleStyle = titleStyle.Bold(true).Foreground(lipgloss.Color("5"))
+		titleStyle = activeStyle
 	case USDescriptionField:
-		descStyle = descStyle.Bold(true).Foreground(lipgloss.Color("5"))
+		descStyle = activeStyle
 	case USAsField:
-		asStyle = asStyle.Bold(true).Foreground(lipgloss.Color("5"))
+		asStyle = activeStyle
 	case USWantField:
-		wantStyle = wantStyle.Bold(true).Foreground(lipgloss.Color("5"))
+		wantStyle = activeStyle
 	case USSoThatField:
-		soThatStyle = soThatStyle.Bold(true).Foreground(lipgloss.Color("5"))
-	case USAcceptanceCriteriaField:
-		switch f.activeACIndex {
-		case 0:
-			ac1Style = ac1Style.Bold(true).Foreground(lipgloss.Color("5"))
-		case 1:
-			ac2Style = ac2Style.Bold(true).Foreground(lipgloss.Color("5"))
-		case 2:
-			ac3Style = ac3Style.Bold(true).Foreground(lipgloss.Color("5"))
-		case 3:
-			ac4Style = ac4Style.Bold(true).Foreground(lipgloss.Color("5"))
-		case 4:
-			ac5Style = ac5Style.Bold(true).Foreground(lipgloss.Color("5"))
-		}
+		soThatStyle = activeStyle
 	}
 
 	// Define label settings
@@ -250,20 +229,14 @@ func (f *UserStoryForm) View() string {
 	// Acceptance Criteria fields
 	b.WriteString(headerStyle.Render("Acceptance Criteria") + "\n")
 
-	b.WriteString(ac1Style.Width(labelWidth).Render("1."))
-	b.WriteString(f.acInputs[0].View() + "\n")
-
-	b.WriteString(ac2Style.Width(labelWidth).Render("2."))
-	b.WriteString(f.acInputs[1].View() + "\n")
-
-	b.WriteString(ac3Style.Width(labelWidth).Render("3."))
-	b.WriteString(f.acInputs[2].View() + "\n")
-
-	b.WriteString(ac4Style.Width(labelWidth).Render("4."))
-	b.WriteString(f.acInputs[3].View() + "\n")
-
-	b.WriteString(ac5Style.Width(labelWidth).Render("5."))
-	b.WriteString(f.acInputs[4].View() + "\n")
+	for i, input := range f.acInputs {
+		acStyle := lipgloss.NewStyle()
+		if f.activeField == USAcceptanceCriteriaField && f.activeACIndex == i {
+			acStyle = activeStyle
+		}
+		b.WriteString(acStyle.Width(labelWidth).Render(fmt.Sprintf("%d.", i+1)))
+		b.WriteString(input.View() + "\n")
+	}
 
 	// Help text
 	b.WriteString("\n" + lipgloss.NewStyle().Faint(true).Render("Tab: Next • Shift+Tab: Previous • Enter: Next • Ctrl+C: Quit"))
@@ -297,6 +270,8 @@ func (f *UserStoryForm) nextField() tea.Cmd {
 		f.acInputs[0].Focus()
 	case USAcceptanceCriteriaField:
 		f.acInputs[f.activeACIndex].Blur()
+		// Filling in the last criterion makes room for another one
+		f.acInputs = growCriteriaInputs(f.acInputs, f.activeACIndex)
 		if f.activeACIndex < len(f.acInputs)-1 {
 			f.activeACIndex++
 			f.acInputs[f.activeACIndex].Focus()
@@ -346,22 +321,7 @@ func (f *UserStoryForm) prevField() {
 
 // hasContent checks if any field has content
 func (f *UserStoryForm) hasContent() bool {
-	if f.titleInput.Value() != "" ||
-		f.descInput.Value() != "" ||
-		f.asInput.Value() != "" ||
-		f.wantInput.Value() != "" ||
-		f.soThatInput.Value() != "" {
-		return true
-	}
-
-	// Check acceptance criteria
-	for _, input := range f.acInputs {
-		if input.Value() != "" {
-			return true
-		}
-	}
-
-	return false
+	return !f.values().isEmpty()
 }
 
 // GetTitle returns the current title value
@@ -374,56 +334,30 @@ func (f *UserStoryForm) SetFilePath(path string) {
 	f.us.FilePath = path
 }
 
+// SetSortCriteria sets whether GetUserStory writes the acceptance criteria in
+// alphabetical order rather than in the order they were entered
+func (f *UserStoryForm) SetSortCriteria(sort bool) {
+	f.sortCriteria = sort
+}
+
 // GetUserStory returns the final user story
 func (f *UserStoryForm) GetUserStory() models.UserStory {
-	us := f.us
-	us.Title = f.titleInput.Value()
-
-	// Build the content without metadata
-	var contentWithoutMetadata strings.Builder
-
-	// Add title
-	contentWithoutMetadata.WriteString(fmt.Sprintf("# %s\n", us.Title))
-
-	// Add description
-	if desc := f.descInput.Value(); desc != "" {
-		contentWithoutMetadata.WriteString(desc + "\n\n")
-	}
-
-	// Add user story
-	contentWithoutMetadata.WriteString(fmt.Sprintf("As a %s\nI want %s\nso that %s\n\n",
-		f.asInput.Value(),
-		f.wantInput.Value(),
-		f.soThatInput.Value()))
+	return assembleUserStory(f.us, f.values())
+}
 
-	// Add acceptance criteria
-	contentWithoutMetadata.WriteString("## Acceptance criteria\n")
+// values returns the values entered in the form
+func (f *UserStoryForm) values() FormValues {
+	criteria := make([]string, 0, len(f.acInputs))
 	for _, input := range f.acInputs {
-		if value := input.Value(); value != "" {
-			contentWithoutMetadata.WriteString(fmt.Sprintf("- %s\n", value))
-		}
+		criteria = append(criteria, input.Value())
 	}
-
-	// Calculate content hash from content without metadata
-	var contentHash string
-	if f.hasContent() {
-		contentHash = models.GenerateContentHash(contentWithoutMetadata.String())
-	} else {
-		contentHash = "d41d8cd98f00b204e9800998ecf8427e" // MD5 hash of empty string
+	return FormValues{
+		Title:        f.titleInput.Value(),
+		Description:  f.descInput.Value(),
+		As:           f.asInput.Value(),
+		Want:         f.wantInput.Value(),
+		SoThat:       f.soThatInput.Value(),
+		Criteria:     criteria,
+		SortCriteria: f.sortCriteria,
 	}
-
-	// Build final content with metadata and hash
-	var finalContent strings.Builder
-	finalContent.WriteString("---\n")
-	finalContent.WriteString(fmt.Sprintf("file_path: %s\n", us.FilePath))
-	finalContent.WriteString(fmt.Sprintf("created_at: %s\n", us.CreatedAt.Format("2006-01-02T15:04:05Z07:00")))
-	finalContent.WriteString(fmt.Sprintf("last_updated: %s\n", us.LastUpdated.Format("2006-01-02T15:04:05Z07:00")))
-	finalContent.WriteString(fmt.Sprintf("_content_hash: %s\n", contentHash))
-	finalContent.WriteString("---\n\n")
-	finalContent.WriteString(contentWithoutMetadata.String())
-
-	us.Content = finalContent.String()
-	us.ContentHash = contentHash
-
-	return us
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import "fmt"
+
+// Verbosity controls how much non-essential output is shown to the user.
+// Errors and warnings are always shown; each level adds to the one below it.
+type Verbosity int
+
+const (
+	// VerbosityQuiet shows only primary output, warnings and errors
+	VerbosityQuiet Verbosity = iota
+	// VerbosityNormal also shows success and step messages
+	VerbosityNormal
+	// VerbosityVerbose also shows progress messages
+	VerbosityVerbose
+	// VerbosityDebug also shows internal bookkeeping such as state file access
+	VerbosityDebug
+)
+
+// String returns the name of the verbosity level
+func (v Verbosity) String() string {
+	switch v {
+	case VerbosityQuiet:
+		return "quiet"
+	case VerbosityNormal:
+		return "normal"
+	case VerbosityVerbose:
+		return "verbose"
+	case VerbosityDebug:
+		return "debug"
+	default:
+		return fmt.Sprintf("verbosity(%d)", int(v))
+	}
+}
+
+// VerbosityFromFlags resolves the --quiet, --verbose and --debug flags into a
+// single level. Debug wins over verbose, and both win over quiet.
+func VerbosityFromFlags(quiet, verbose, debug bool) Verbosity {
+	switch {
+	case debug:
+		return VerbosityDebug
+	case verbose:
+		return VerbosityVerbose
+	case quiet:
+		return VerbosityQuiet
+	default:
+		return VerbosityNormal
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerbosityFromFlags(t *testing.T) {
+	assert.Equal(t, VerbosityNormal, VerbosityFromFlags(false, false, false))
+	assert.Equal(t, VerbosityQuiet, VerbosityFromFlags(true, false, false))
+	assert.Equal(t, VerbosityVerbose, VerbosityFromFlags(false, true, false))
+	assert.Equal(t, VerbosityVerbose, VerbosityFromFlags(true, true, false))
+	assert.Equal(t, VerbosityDebug, VerbosityFromFlags(true, true, true))
+}
+
+func TestVerbosityString(t *testing.T) {
+	assert.Equal(t, "quiet", VerbosityQuiet.String())
+	assert.Equal(t, "debug", VerbosityDebug.String())
+	assert.Equal(t, "verbosity(7)", Verbosity(7).String())
+}
+
+func TestTerminalIOVerbosity(t *testing.T) {
+	term := NewTerminalIO()
+	assert.Equal(t, VerbosityNormal, term.Verbosity())
+	assert.False(t, term.IsDebugEnabled())
+
+	term.SetVerbosity(VerbosityVerbose)
+	assert.False(t, term.IsDebugEnabled())
+
+	term.SetDebugMode(true)
+	assert.Equal(t, VerbosityDebug, term.Verbosity())
+	assert.True(t, term.IsDebugEnabled())
+
+	term.SetDebugMode(false)
+	assert.Equal(t, VerbosityNormal, term.Verbosity())
+
+	assert.True(t, NewTerminalIOWithDebug(true).IsDebugEnabled())
+	assert.Equal(t, VerbosityQuiet, NewTerminalIOWithVerbosity(VerbosityQuiet).Verbosity())
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/config"
+)
+
+// listChangedFiles returns the files added, copied, modified or renamed in
+// the work tree of root since ref, staged or not, followed by the untracked
+// files that are not ignored. Paths are relative to root and limited to the
+// files under it. It is a variable so that tests can run without git.
+var listChangedFiles = func(ref, root string) ([]string, error) {
+	diff := exec.Command("git", "diff", "--name-only", "--relative", "--diff-filter=ACMR", "-z", ref, "--")
+	diff.Dir = root
+	output, err := diff.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotGitRepository, err)
+	}
+	files := splitGitPaths(output)
+
+	untracked := exec.Command("git", "ls-files", "--others", "--exclude-standard", "-z")
+	untracked.Dir = root
+	output, err = untracked.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotGitRepository, err)
+	}
+	return append(files, splitGitPaths(output)...), nil
+}
+
+// FindChangedUserStories returns the user stories of root changed since the
+// git ref, "HEAD" when empty, including uncommitted and untracked ones. The
+// paths are joined to root and listed once. Files outside the user stories
+// directory, in skipped directories or not markdown are ignored. It returns
+// an error wrapping ErrNotGitRepository when git cannot list the changes. A
+// ref starting with "-" is refused, since git would take it for an option.
+func FindChangedUserStories(ref, root string) ([]string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref %q: it must not start with \"-\"", ref)
+	}
+	changed, err := listChangedFiles(ref, root)
+	if err != nil {
+		return nil, err
+	}
+
+	userStoriesDir := config.UserStoriesDirIn(root)
+	seen := make(map[string]bool)
+	var stories []string
+	for _, relPath := range changed {
+		file := filepath.Join(root, relPath)
+		if seen[file] || !isUserStoryFile(file, userStoriesDir) {
+			continue
+		}
+		seen[file] = true
+		stories = append(stories, file)
+	}
+	return stories, nil
+}
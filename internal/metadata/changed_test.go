@@ -0,0 +1,67 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubChangedFiles makes listChangedFiles return files, or err, and records
+// the ref it is called with
+func stubChangedFiles(t *testing.T, files []string, err error) *string {
+	var gotRef string
+	original := listChangedFiles
+	listChangedFiles = func(ref, root string) ([]string, error) {
+		gotRef = ref
+		return files, err
+	}
+	t.Cleanup(func() { listChangedFiles = original })
+	return &gotRef
+}
+
+func TestFindChangedUserStories(t *testing.T) {
+	ref := stubChangedFiles(t, []string{
+		filepath.FromSlash("docs/user-stories/01-login.md"),
+		filepath.FromSlash("docs/user-stories/node_modules/03-vendored.md"),
+		"README.md",
+		filepath.FromSlash("docs/user-stories/notes.txt"),
+		filepath.FromSlash("docs/user-stories/01-login.md"),
+	}, nil)
+
+	stories, err := FindChangedUserStories("", "/project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.FromSlash("/project/docs/user-stories/01-login.md")}, stories)
+	assert.Equal(t, "HEAD", *ref)
+
+	_, err = FindChangedUserStories("main", "/project")
+	require.NoError(t, err)
+	assert.Equal(t, "main", *ref)
+}
+
+func TestFindChangedUserStories_RejectsOptionRef(t *testing.T) {
+	ref := stubChangedFiles(t, nil, nil)
+
+	_, err := FindChangedUserStories("--output=/tmp/out", "/project")
+	assert.Error(t, err)
+	assert.Empty(t, *ref)
+}
+
+func TestFindChangedUserStories_NotGitRepository(t *testing.T) {
+	stubChangedFiles(t, nil, fmt.Errorf("%w: exit status 128", ErrNotGitRepository))
+
+	_, err := FindChangedUserStories("", "/project")
+	assert.ErrorIs(t, err, ErrNotGitRepository)
+}
+
+func TestListChangedFiles_NotGitRepository(t *testing.T) {
+	_, err := listChangedFiles("HEAD", t.TempDir())
+	assert.ErrorIs(t, err, ErrNotGitRepository)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+)
+
+// FindStoriesWithoutAcceptanceCriteria returns the user stories below dir whose
+// "Acceptance criteria" section is missing or lists no bullets. The body is
+// parsed the same way as models.ValidateUserStory, so both checks agree.
+// Files that cannot be read or parsed are skipped.
+func FindStoriesWithoutAcceptanceCriteria(dir string, fs io.FileSystem) ([]models.UserStory, error) {
+	files, err := FindMarkdownFiles(dir, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	var stories []models.UserStory
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Debug("Failed to read user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+
+		story, err := models.LoadUserStoryFromFile(file, content)
+		if err != nil {
+			logger.Debug("Failed to parse user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+
+		if story.AcceptanceCriteriaCount() == 0 {
+			stories = append(stories, story)
+		}
+	}
+
+	return stories, nil
+}
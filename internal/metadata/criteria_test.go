@@ -0,0 +1,46 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestFindStoriesWithoutAcceptanceCriteria(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+	fs.AddFile("docs/user-stories/01-specified.md", []byte(`# Specified
+As a user, I want to log in, so that I can see my data
+
+## Acceptance criteria
+- Login form is shown
+`))
+	fs.AddFile("docs/user-stories/02-empty-section.md", []byte(`# Empty section
+As a user, I want to log out, so that my session ends
+
+## Acceptance criteria
+`))
+	fs.AddFile("docs/user-stories/03-no-section.md", []byte(`# No section
+As a user, I want to reset my password, so that I can log in again
+`))
+
+	stories, err := FindStoriesWithoutAcceptanceCriteria("docs/user-stories", fs)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, story := range stories {
+		paths = append(paths, story.FilePath)
+	}
+	assert.ElementsMatch(t, []string{
+		"docs/user-stories/02-empty-section.md",
+		"docs/user-stories/03-no-section.md",
+	}, paths)
+}
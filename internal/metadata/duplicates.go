@@ -0,0 +1,47 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"sort"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"go.uber.org/zap"
+)
+
+// FindDuplicateContent hashes the body of every story below dir, ignoring the
+// metadata section, and returns the hashes shared by more than one file
+// mapped to the sorted paths of those files. Two stories with the same body
+// are usually an accidental copy. Files that cannot be read are skipped.
+func FindDuplicateContent(dir string, fs io.FileSystem) (map[string][]string, error) {
+	files, err := FindMarkdownFiles(dir, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	pathsByHash := make(map[string][]string)
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Debug("Failed to read user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+
+		hash := CalculateContentHash(GetContentWithoutMetadata(string(content)))
+		pathsByHash[hash] = append(pathsByHash[hash], file)
+	}
+
+	duplicates := make(map[string][]string)
+	for hash, paths := range pathsByHash {
+		if len(paths) > 1 {
+			sort.Strings(paths)
+			duplicates[hash] = paths
+		}
+	}
+
+	return duplicates, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestFindDuplicateContent(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+
+	body := "# Login\nAs a user, I want to log in, so that I can see my data\n"
+	fs.AddFile("docs/user-stories/01-login.md", []byte("---\nfile_path: docs/user-stories/01-login.md\n---\n\n"+body))
+	fs.AddFile("docs/user-stories/02-login-copy.md", []byte("---\nfile_path: docs/user-stories/02-login-copy.md\n---\n\n"+body))
+	fs.AddFile("docs/user-stories/03-logout.md", []byte("# Logout\nAs a user, I want to log out, so that my session ends\n"))
+
+	duplicates, err := FindDuplicateContent("docs/user-stories", fs)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		CalculateContentHash(GetContentWithoutMetadata(body)): {
+			"docs/user-stories/01-login.md",
+			"docs/user-stories/02-login-copy.md",
+		},
+	}, duplicates)
+}
+
+func TestFindDuplicateContent_NoDuplicates(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddDirectory("docs/user-stories")
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("docs/user-stories/02-logout.md", []byte("# Logout\n"))
+
+	duplicates, err := FindDuplicateContent("docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Empty(t, duplicates)
+}
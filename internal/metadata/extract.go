@@ -8,17 +8,27 @@ package metadata
 import (
 	"regexp"
 	"strings"
-	"time"
+
+	"github.com/user-story-matrix/usm/internal/models"
 )
 
 var (
-	// Regex pattern to match metadata section
-	metadataRegex = regexp.MustCompile(`(?m)^---\s*\n([\s\S]*?)\n---\s*\n`)
-
 	// Regex pattern to match specific metadata key-value pairs
 	metadataKeyValueRegex = regexp.MustCompile(`(?m)^([^:]+):\s*(.*)$`)
+
+	// Regex pattern to match a top-level (non-indented) metadata key
+	metadataTopLevelKeyRegex = regexp.MustCompile(`^([^\s:#-][^:]*):`)
 )
 
+// managedFields are the metadata fields written by usm itself
+var managedFields = map[string]bool{
+	"file_path":     true,
+	"created_at":    true,
+	"last_updated":  true,
+	"_content_hash": true,
+	fieldsHashKey:   true,
+}
+
 // ExtractMetadata extracts metadata from file content
 func ExtractMetadata(content string) (Metadata, error) {
 	metadata := Metadata{
@@ -28,6 +38,7 @@ func ExtractMetadata(content string) (Metadata, error) {
 	// Extract raw metadata key-value pairs
 	rawMetadata := extractRawMetadata(content)
 	metadata.RawMetadata = rawMetadata
+	metadata.CustomFields = extractCustomFields(content)
 
 	// Parse specific fields
 	if filePath, ok := rawMetadata["file_path"]; ok {
@@ -40,14 +51,14 @@ func ExtractMetadata(content string) (Metadata, error) {
 
 	// Parse timestamps
 	if createdAt, ok := rawMetadata["created_at"]; ok {
-		t, err := time.Parse(time.RFC3339, createdAt)
+		t, err := models.ParseTimestamp(createdAt)
 		if err == nil {
 			metadata.CreatedAt = t
 		}
 	}
 
 	if lastUpdated, ok := rawMetadata["last_updated"]; ok {
-		t, err := time.Parse(time.RFC3339, lastUpdated)
+		t, err := models.ParseTimestamp(lastUpdated)
 		if err == nil {
 			metadata.LastUpdated = t
 		}
@@ -60,12 +71,11 @@ func ExtractMetadata(content string) (Metadata, error) {
 func extractRawMetadata(content string) map[string]string {
 	rawMetadata := make(map[string]string)
 
-	matches := metadataRegex.FindStringSubmatch(content)
-	if len(matches) < 2 {
+	metadataText, _, ok := models.SplitMetadata(content)
+	if !ok {
 		return rawMetadata
 	}
 
-	metadataText := matches[1]
 	kvMatches := metadataKeyValueRegex.FindAllStringSubmatch(metadataText, -1)
 
 	for _, kv := range kvMatches {
@@ -81,7 +91,40 @@ func extractRawMetadata(content string) map[string]string {
 	return rawMetadata
 }
 
-// GetContentWithoutMetadata removes metadata section from content
+// extractCustomFields extracts the fields not managed by usm, keeping each
+// field's continuation lines (e.g. YAML list items) attached to its key
+func extractCustomFields(content string) []CustomField {
+	frontMatter, _, ok := models.SplitMetadata(content)
+	if !ok {
+		return nil
+	}
+
+	var fields []CustomField
+	var current *CustomField
+
+	for _, line := range strings.Split(frontMatter, "\n") {
+		if keyMatch := metadataTopLevelKeyRegex.FindStringSubmatch(line); keyMatch != nil {
+			current = nil
+			key := strings.TrimSpace(keyMatch[1])
+			if !managedFields[key] {
+				fields = append(fields, CustomField{Key: key, Raw: line})
+				current = &fields[len(fields)-1]
+			}
+			continue
+		}
+
+		// Continuation lines belong to the most recent custom field
+		if current != nil && strings.TrimSpace(line) != "" {
+			current.Raw += "\n" + line
+		}
+	}
+
+	return fields
+}
+
+// GetContentWithoutMetadata removes the metadata section from content, be it
+// front matter or a footer
 func GetContentWithoutMetadata(content string) string {
-	return metadataRegex.ReplaceAllString(content, "")
+	_, body, _ := models.SplitMetadata(content)
+	return body
 } 
\ No newline at end of file
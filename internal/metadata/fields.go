@@ -0,0 +1,154 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// ErrInvalidFieldKey is returned by SetFieldForStories for a key that is not a
+// plain front-matter key or names a field managed by usm
+var ErrInvalidFieldKey = errors.New("invalid front-matter field key")
+
+// fieldKeyRegex matches the keys SetFieldForStories accepts
+var fieldKeyRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// SetFieldForStories sets the custom front-matter field key to value in each
+// story of paths, keeping the other fields, and refreshes their metadata. The
+// fields managed by usm cannot be set. A story that cannot be updated does not
+// stop the others; the errors are joined. It returns the stories whose file
+// changed.
+func SetFieldForStories(paths []string, key, value string, fs io.FileSystem) ([]string, error) {
+	if !fieldKeyRegex.MatchString(key) || managedFields[key] {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidFieldKey, key)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return nil, fmt.Errorf("the value of %s must fit on one line", key)
+	}
+
+	var changed []string
+	var errs []error
+	for _, path := range paths {
+		updated, err := setFieldForStory(path, key, value, fs)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if updated {
+			changed = append(changed, path)
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+// setFieldForStory sets one field of a story and refreshes its metadata,
+// reporting whether the file changed
+func setFieldForStory(path, key, value string, fs io.FileSystem) (bool, error) {
+	content, err := fs.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated := SetCustomField(string(content), key, value)
+	if updated != string(content) {
+		info, err := fs.Stat(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to get file info for %s: %w", path, err)
+		}
+		if err := fs.WriteFile(path, []byte(updated), info.Mode()); err != nil {
+			return false, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	metadataUpdated, _, err := UpdateFileMetadata(path, ".", fs)
+	if err != nil {
+		return false, err
+	}
+	return updated != string(content) || metadataUpdated, nil
+}
+
+// SetCustomField sets a top-level front-matter field to a single-line value,
+// replacing the field (and any continuation lines) if it already exists.
+// A metadata section is added when content has none. The rest of the file is
+// left untouched.
+func SetCustomField(content, key, value string) string {
+	line := key + ": " + value
+
+	start, end, ok := frontMatterSpan(content)
+	if !ok {
+		return "---\n" + line + "\n---\n\n" + content
+	}
+	if start == end {
+		// Empty block: the field becomes its only line
+		return content[:start] + line + "\n" + content[end:]
+	}
+
+	lines, found := replaceField(content[start:end], key, &line)
+	if !found {
+		lines = append(lines, line)
+	}
+	return content[:start] + strings.Join(lines, "\n") + content[end:]
+}
+
+// RemoveCustomField removes a top-level front-matter field and its
+// continuation lines. Content without the field is returned unchanged.
+func RemoveCustomField(content, key string) string {
+	start, end, ok := frontMatterSpan(content)
+	if !ok {
+		return content
+	}
+
+	lines, found := replaceField(content[start:end], key, nil)
+	if !found {
+		return content
+	}
+	return content[:start] + strings.Join(lines, "\n") + content[end:]
+}
+
+// frontMatterSpan returns the offsets of the front-matter text, between the
+// fences, within content, or of the metadata footer fields when content has
+// no front matter
+func frontMatterSpan(content string) (start, end int, ok bool) {
+	frontMatter, _, ok := models.SplitFrontMatter(content)
+	if !ok {
+		_, start, end, ok = models.FooterMetadataSpan(content)
+		return start, end, ok
+	}
+	start = strings.IndexByte(content, '\n') + 1
+	return start, start + len(frontMatter), true
+}
+
+// replaceField returns the lines of the front-matter text with the field key
+// replaced by replacement, or dropped when replacement is nil
+func replaceField(frontMatter, key string, replacement *string) ([]string, bool) {
+	var lines []string
+	found := false
+	inField := false
+
+	for _, line := range strings.Split(frontMatter, "\n") {
+		if keyMatch := metadataTopLevelKeyRegex.FindStringSubmatch(line); keyMatch != nil {
+			inField = strings.TrimSpace(keyMatch[1]) == key
+			if inField {
+				found = true
+				if replacement != nil {
+					lines = append(lines, *replacement)
+				}
+				continue
+			}
+		} else if inField && strings.TrimSpace(line) != "" {
+			continue // Continuation line of the replaced field
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, found
+}
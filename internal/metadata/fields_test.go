@@ -0,0 +1,97 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestSetCustomField(t *testing.T) {
+	content := "---\nfile_path: a.md\ntags:\n  - auth\n_content_hash: abc\n---\n\n# Title\n"
+
+	// Adding a new field keeps the existing ones
+	added := SetCustomField(content, "implemented", "true")
+	assert.Equal(t, "---\nfile_path: a.md\ntags:\n  - auth\n_content_hash: abc\nimplemented: true\n---\n\n# Title\n", added)
+
+	// Replacing a multi-line field drops its continuation lines
+	replaced := SetCustomField(added, "tags", "[billing]")
+	assert.Equal(t, "---\nfile_path: a.md\ntags: [billing]\n_content_hash: abc\nimplemented: true\n---\n\n# Title\n", replaced)
+
+	// A file without metadata gets a metadata section
+	assert.Equal(t, "---\nimplemented: true\n---\n\n# Title\n", SetCustomField("# Title\n", "implemented", "true"))
+
+	// An empty metadata section is filled in rather than doubled
+	assert.Equal(t, "---\nimplemented: true\n---\n# Title\n", SetCustomField("---\n---\n# Title\n", "implemented", "true"))
+}
+
+func TestRemoveCustomField(t *testing.T) {
+	content := "---\nfile_path: a.md\nimplemented: true\ntags:\n  - auth\n---\n\n# Title\n"
+
+	assert.Equal(t, "---\nfile_path: a.md\ntags:\n  - auth\n---\n\n# Title\n", RemoveCustomField(content, "implemented"))
+	assert.Equal(t, "---\nfile_path: a.md\nimplemented: true\n---\n\n# Title\n", RemoveCustomField(content, "tags"))
+	assert.Equal(t, content, RemoveCustomField(content, "missing"))
+	assert.Equal(t, "# Title\n", RemoveCustomField("# Title\n", "implemented"))
+}
+
+func TestSetFieldForStories(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	body := "# Login\n"
+	fs.AddFile("docs/user-stories/01-login.md", []byte("---\nfile_path: docs/user-stories/01-login.md\n"+
+		"created_at: 2024-01-02T03:04:05Z\nlast_updated: 2024-01-02T03:04:05Z\n_content_hash: "+CalculateContentHash(body)+
+		"\ntags: [auth]\n---\n\n"+body))
+	fs.AddFile("docs/user-stories/02-logout.md", []byte("# Logout\n"))
+	fs.AddFile("docs/user-stories/03-signup.md", []byte("---\nteam: security\n---\n\n# Signup\n"))
+	_, _, err := UpdateFileMetadata("docs/user-stories/03-signup.md", ".", fs)
+	require.NoError(t, err)
+
+	changed, err := SetFieldForStories([]string{
+		"docs/user-stories/01-login.md",
+		"docs/user-stories/02-logout.md",
+		"docs/user-stories/03-signup.md",
+	}, "team", "security", fs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/user-stories/01-login.md", "docs/user-stories/02-logout.md"}, changed)
+
+	// The other fields are kept and the managed ones still match the body
+	content, err := fs.ReadFile("docs/user-stories/01-login.md")
+	require.NoError(t, err)
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, "security", meta.RawMetadata["team"])
+	assert.Equal(t, "[auth]", meta.RawMetadata["tags"])
+	assert.Equal(t, "2024-01-02T03:04:05Z", meta.RawMetadata["created_at"])
+	assert.Equal(t, CalculateContentHash(body), meta.ContentHash)
+
+	// A story without metadata gets it
+	content, err = fs.ReadFile("docs/user-stories/02-logout.md")
+	require.NoError(t, err)
+	meta, err = ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/02-logout.md", meta.FilePath)
+	assert.Equal(t, "security", meta.RawMetadata["team"])
+}
+
+func TestSetFieldForStories_Errors(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+
+	for _, key := range []string{"", "last_updated", "_content_hash", "team name", "a:b"} {
+		_, err := SetFieldForStories([]string{"docs/user-stories/01-login.md"}, key, "x", fs)
+		assert.ErrorIs(t, err, ErrInvalidFieldKey, key)
+	}
+
+	_, err := SetFieldForStories([]string{"docs/user-stories/01-login.md"}, "team", "a\nb", fs)
+	assert.Error(t, err)
+
+	// A missing story is reported without stopping the others
+	changed, err := SetFieldForStories([]string{"docs/user-stories/99-missing.md", "docs/user-stories/01-login.md"}, "team", "security", fs)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"docs/user-stories/01-login.md"}, changed)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"go.uber.org/zap"
+)
+
+// StaleReference is a reference whose content hash does not match the
+// current content of the user story it points at
+type StaleReference struct {
+	Title       string
+	FilePath    string
+	Line        int    // Line of the reference in the change request
+	StoredHash  string // The content-hash written in the change request
+	CurrentHash string // Empty when the story cannot be read
+}
+
+// AuditReferenceFreshness compares the content hash of every reference of the
+// change request at crPath with the hash of the current content of its story,
+// whatever the hash maps of earlier updates say. Story paths are resolved from
+// the working directory, as written in references. A story that cannot be read
+// is reported with an empty CurrentHash.
+func AuditReferenceFreshness(crPath string, fs io.FileSystem) ([]StaleReference, error) {
+	content, err := fs.ReadFile(crPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read change request %s: %w", crPath, err)
+	}
+
+	var stale []StaleReference
+	for _, ref := range ExtractReferences(string(content)) {
+		currentHash, err := currentStoryHash(ref.FilePath, fs)
+		if err != nil {
+			logger.Debug("Failed to read referenced user story", zap.String("file", ref.FilePath), zap.Error(err))
+		}
+
+		if currentHash != "" && currentHash == ref.ContentHash {
+			continue
+		}
+		stale = append(stale, StaleReference{
+			Title:       ref.Title,
+			FilePath:    ref.FilePath,
+			Line:        ref.Line,
+			StoredHash:  ref.ContentHash,
+			CurrentHash: currentHash,
+		})
+	}
+	return stale, nil
+}
+
+// RefreshChangeRequest updates the content hash of every reference of the
+// change request at crPath to the hash of the current content of its story,
+// without looking at any other change request or user story. Story paths are
+// resolved from the working directory, as written in references; stories that
+// cannot be read are left as they are. It reports whether the change request
+// was written and the number of references updated.
+func RefreshChangeRequest(crPath string, fs io.FileSystem) (updated bool, refCount int, err error) {
+	content, err := fs.ReadFile(crPath)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to read change request %s: %w", crPath, err)
+	}
+
+	hashMap := ContentChangeMap{}
+	for _, ref := range ExtractReferences(string(content)) {
+		if _, seen := hashMap[ref.FilePath]; seen {
+			continue
+		}
+		currentHash, err := currentStoryHash(ref.FilePath, fs)
+		if err != nil {
+			logger.Debug("Skipping unreadable referenced user story", zap.String("file", ref.FilePath), zap.Error(err))
+			continue
+		}
+		hashMap[ref.FilePath] = ContentHashMap{
+			FilePath: ref.FilePath,
+			OldHash:  ref.ContentHash,
+			NewHash:  currentHash,
+			Changed:  currentHash != ref.ContentHash,
+		}
+	}
+
+	updated, refCount, _, err = UpdateChangeRequestReferences(crPath, FilterChangedContent(hashMap), fs)
+	return updated, refCount, err
+}
+
+// currentStoryHash returns the content hash of the current content of a user story
+func currentStoryHash(path string, fs io.FileSystem) (string, error) {
+	content, err := fs.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return CalculateContentHash(GetContentWithoutMetadata(string(content))), nil
+}
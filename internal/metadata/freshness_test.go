@@ -0,0 +1,132 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestAuditReferenceFreshness(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+
+	fresh := "---\nfile_path: docs/user-stories/01-login.md\n---\n\n# Login\n"
+	edited := "# Logout\n\nNow with a confirmation dialog.\n"
+	mockFS.AddFile("docs/user-stories/01-login.md", []byte(fresh))
+	mockFS.AddFile("docs/user-stories/02-logout.md", []byte(edited))
+	freshHash := CalculateContentHash(GetContentWithoutMetadata(fresh))
+
+	crPath := "docs/changes-request/auth.blueprint.md"
+	mockFS.AddFile(crPath, []byte(`---
+name: Auth
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: `+freshHash+`
+  - title: Logout
+    file: docs/user-stories/02-logout.md
+    content-hash: old-hash
+  - title: Signup
+    file: docs/user-stories/03-signup.md
+    content-hash: gone-hash
+---
+`))
+
+	stale, err := AuditReferenceFreshness(crPath, mockFS)
+	require.NoError(t, err)
+	assert.Equal(t, []StaleReference{
+		{
+			Title:       "Logout",
+			FilePath:    "docs/user-stories/02-logout.md",
+			Line:        7,
+			StoredHash:  "old-hash",
+			CurrentHash: CalculateContentHash(edited),
+		},
+		{
+			Title:      "Signup",
+			FilePath:   "docs/user-stories/03-signup.md",
+			Line:       10,
+			StoredHash: "gone-hash",
+		},
+	}, stale)
+}
+
+func TestAuditReferenceFreshness_AllFresh(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+	story := "# Login\n"
+	mockFS.AddFile("docs/user-stories/01-login.md", []byte(story))
+	mockFS.AddFile("docs/changes-request/login.blueprint.md", []byte(
+		"---\nname: Login\nuser-stories:\n  - title: Login\n    file: docs/user-stories/01-login.md\n    content-hash: "+
+			CalculateContentHash(story)+"\n---\n"))
+
+	stale, err := AuditReferenceFreshness("docs/changes-request/login.blueprint.md", mockFS)
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
+
+func TestAuditReferenceFreshness_MissingChangeRequest(t *testing.T) {
+	_, err := AuditReferenceFreshness("docs/changes-request/missing.blueprint.md", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
+
+func TestRefreshChangeRequest(t *testing.T) {
+	mockFS := io.NewMockFileSystem()
+
+	login := "# Login\n"
+	logout := "# Logout\n\nNow with a confirmation dialog.\n"
+	mockFS.AddFile("docs/user-stories/01-login.md", []byte(login))
+	mockFS.AddFile("docs/user-stories/02-logout.md", []byte(logout))
+	loginHash := CalculateContentHash(login)
+
+	crPath := "docs/changes-request/auth.blueprint.md"
+	mockFS.AddFile(crPath, []byte(`---
+name: Auth
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: `+loginHash+`
+  - title: Logout
+    file: docs/user-stories/02-logout.md
+    content-hash: old-hash
+  - title: Signup
+    file: docs/user-stories/03-signup.md
+    content-hash: gone-hash
+---
+`))
+	// Another change request referencing the edited story is not touched
+	otherPath := "docs/changes-request/other.blueprint.md"
+	other := "---\nname: Other\nuser-stories:\n  - title: Logout\n    file: docs/user-stories/02-logout.md\n    content-hash: old-hash\n---\n"
+	mockFS.AddFile(otherPath, []byte(other))
+
+	updated, refCount, err := RefreshChangeRequest(crPath, mockFS)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, 1, refCount)
+
+	content, err := mockFS.ReadFile(crPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "content-hash: "+CalculateContentHash(logout))
+	assert.Contains(t, string(content), "content-hash: "+loginHash)
+	assert.Contains(t, string(content), "content-hash: gone-hash")
+
+	otherContent, err := mockFS.ReadFile(otherPath)
+	require.NoError(t, err)
+	assert.Equal(t, other, string(otherContent))
+
+	// A second refresh has nothing left to update
+	updated, refCount, err = RefreshChangeRequest(crPath, mockFS)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.Equal(t, 0, refCount)
+}
+
+func TestRefreshChangeRequest_MissingChangeRequest(t *testing.T) {
+	_, _, err := RefreshChangeRequest("docs/changes-request/missing.blueprint.md", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
@@ -11,21 +11,37 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/models"
 	"go.uber.org/zap"
 )
 
-// CalculateContentHash calculates the SHA-256 hash of content
+// CalculateContentHash calculates the SHA-256 hash of content, leaving out
+// the regions marked with models.IgnoreRegionStart and models.IgnoreRegionEnd
 func CalculateContentHash(content string) string {
 	hash := sha256.New()
-	hash.Write([]byte(content))
+	hash.Write([]byte(models.StripIgnoredRegions(content)))
 	return hex.EncodeToString(hash.Sum(nil))
 }
 
+// metadataField is a field written by usm, with its value
+type metadataField struct {
+	key   string
+	value string
+}
+
 // GenerateMetadata creates a metadata section for a file
 func GenerateMetadata(filePath, root string, fileInfo os.FileInfo, existingMetadata Metadata, contentHash string) string {
+	fields := generateManagedFields(filePath, root, fileInfo, existingMetadata, contentHash)
+	return formatMetadataSection(fields, existingMetadata.CustomFields)
+}
+
+// generateManagedFields returns the fields written by usm for a file, in the
+// order GenerateMetadata writes them
+func generateManagedFields(filePath, root string, fileInfo os.FileInfo, existingMetadata Metadata, contentHash string) []metadataField {
 	// Get the relative path
 	relativePath, err := filepath.Rel(root, filePath)
 	if err != nil {
@@ -36,25 +52,34 @@ func GenerateMetadata(filePath, root string, fileInfo os.FileInfo, existingMetad
 	// This preserves the original creation date as required by the user story
 	var creationDate string
 	if !existingMetadata.CreatedAt.IsZero() {
-		creationDate = existingMetadata.CreatedAt.Format(time.RFC3339)
+		creationDate = models.FormatTimestamp(existingMetadata.CreatedAt)
 	} else if createdAt, ok := existingMetadata.RawMetadata["created_at"]; ok && createdAt != "" {
 		creationDate = createdAt
 	} else {
-		creationDate = fileInfo.ModTime().Format(time.RFC3339) // Use mod time as fallback
+		creationDate = models.FormatTimestamp(fileInfo.ModTime()) // Use mod time as fallback
 	}
 	
 	// Check if content has changed by comparing hashes
 	storedHash := existingMetadata.ContentHash
 	contentChanged := storedHash != contentHash
 	
+	// Under OnAnyChange, edited custom fields count as a change too
+	var fieldsHash string
+	if lastUpdatedPolicy == OnAnyChange {
+		fieldsHash = customFieldsHash(existingMetadata.CustomFields)
+		if stored := existingMetadata.RawMetadata[fieldsHashKey]; stored != "" && stored != fieldsHash {
+			contentChanged = true
+		}
+	}
+	
 	// Only update last_updated date if content has changed or it doesn't exist
 	var modifiedDate string
 	if !existingMetadata.LastUpdated.IsZero() && !contentChanged {
-		modifiedDate = existingMetadata.LastUpdated.Format(time.RFC3339)
+		modifiedDate = models.FormatTimestamp(existingMetadata.LastUpdated)
 	} else if lastUpdated, ok := existingMetadata.RawMetadata["last_updated"]; ok && lastUpdated != "" && !contentChanged {
 		modifiedDate = lastUpdated
 	} else {
-		modifiedDate = time.Now().Format(time.RFC3339)
+		modifiedDate = models.FormatTimestamp(time.Now())
 		logger.Debug("Updating modified date", 
 			zap.String("file", relativePath), 
 			zap.String("old_hash", storedHash), 
@@ -62,18 +87,46 @@ func GenerateMetadata(filePath, root string, fileInfo os.FileInfo, existingMetad
 			zap.Bool("content_changed", contentChanged))
 	}
 	
-	// Build the metadata section
-	metadata := fmt.Sprintf("---\nfile_path: %s\ncreated_at: %s\nlast_updated: %s\n_content_hash: %s\n---\n\n", 
-		relativePath, creationDate, modifiedDate, contentHash)
-	
-	return metadata
+	fields := []metadataField{
+		{"file_path", relativePath},
+		{"created_at", creationDate},
+		{"last_updated", modifiedDate},
+		{"_content_hash", contentHash},
+	}
+	if fieldsHash != "" {
+		fields = append(fields, metadataField{fieldsHashKey, fieldsHash})
+	}
+	return fields
+}
+
+// formatMetadataSection builds a metadata section from the fields written by
+// usm followed by the custom fields
+func formatMetadataSection(fields []metadataField, customFields []CustomField) string {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	for _, field := range fields {
+		fmt.Fprintf(&sb, "%s: %s\n", field.key, field.value)
+	}
+	sb.WriteString(formatCustomFields(customFields))
+	sb.WriteString("---\n\n")
+	return sb.String()
 }
 
 // FormatMetadata formats a Metadata struct into a string representation
 func FormatMetadata(metadata Metadata, contentHash string) string {
-	creationDate := metadata.CreatedAt.Format(time.RFC3339)
-	modifiedDate := metadata.LastUpdated.Format(time.RFC3339)
+	creationDate := models.FormatTimestamp(metadata.CreatedAt)
+	modifiedDate := models.FormatTimestamp(metadata.LastUpdated)
 	
-	return fmt.Sprintf("---\nfile_path: %s\ncreated_at: %s\nlast_updated: %s\n_content_hash: %s\n---\n\n", 
-		metadata.FilePath, creationDate, modifiedDate, contentHash)
+	return fmt.Sprintf("---\nfile_path: %s\ncreated_at: %s\nlast_updated: %s\n_content_hash: %s\n%s---\n\n", 
+		metadata.FilePath, creationDate, modifiedDate, contentHash, formatCustomFields(metadata.CustomFields))
+}
+
+// formatCustomFields renders custom fields verbatim, one field per block of lines
+func formatCustomFields(fields []CustomField) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		sb.WriteString(field.Raw)
+		sb.WriteString("\n")
+	}
+	return sb.String()
 } 
\ No newline at end of file
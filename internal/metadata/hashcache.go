@@ -0,0 +1,144 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+)
+
+// HashCacheFile is the hash cache location, relative to the project root
+const HashCacheFile = ".usm/hashcache.json"
+
+// hashCacheEntry is the state of a user story after its metadata was last
+// brought up to date
+type hashCacheEntry struct {
+	ModTime time.Time `json:"modtime"`
+	Size    int64     `json:"size"`
+	Hash    string    `json:"hash"`
+}
+
+// hashCacheFile is the content of the hash cache file
+type hashCacheFile struct {
+	Settings string                    `json:"settings"`
+	Entries  map[string]hashCacheEntry `json:"entries"`
+}
+
+// HashCache remembers, per user story, the modification time and size the
+// file had once its metadata was up to date. A file that still has the same
+// modification time and size can be treated as unchanged without reading it.
+// The entries only hold for the settings they were written with, so they are
+// all dropped when these settings change.
+type HashCache struct {
+	settings string
+	entries  map[string]hashCacheEntry // Keyed by path relative to the project root
+	dirty    bool
+}
+
+// LoadHashCache reads the hash cache of the project at root. A missing or
+// unreadable cache, or one written with other metadata settings, yields an
+// empty one, so the next run simply rehashes.
+func LoadHashCache(root string, fs io.FileSystem) *HashCache {
+	cache := &HashCache{
+		settings: hashCacheSettings(root),
+		entries:  make(map[string]hashCacheEntry),
+	}
+
+	path := filepath.Join(root, HashCacheFile)
+	if !fs.Exists(path) {
+		return cache
+	}
+	var file hashCacheFile
+	data, err := fs.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		logger.Debug("Ignoring unreadable hash cache", zap.String("file", path), zap.Error(err))
+		return cache
+	}
+	if file.Settings != cache.settings {
+		logger.Debug("Ignoring hash cache written with other settings", zap.String("file", path))
+		cache.dirty = len(file.Entries) > 0
+		return cache
+	}
+	if file.Entries != nil {
+		cache.entries = file.Entries
+	}
+	return cache
+}
+
+// hashCacheSettings fingerprints the settings that change the metadata
+// written for a story: the timestamp format, the metadata placement, the
+// last_updated policy and the project root
+func hashCacheSettings(root string) string {
+	if absRoot, err := filepath.Abs(root); err == nil {
+		root = absRoot
+	}
+	return CalculateContentHash(fmt.Sprintf("%s\n%d\n%d\n%s",
+		models.TimestampFormat(), metadataPlacement, lastUpdatedPolicy, root))
+}
+
+// Save writes the cache back to root when it changed since it was loaded
+func (c *HashCache) Save(root string, fs io.FileSystem) error {
+	if !c.dirty {
+		return nil
+	}
+
+	path := filepath.Join(root, HashCacheFile)
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	data, err := json.MarshalIndent(hashCacheFile{Settings: c.settings, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode hash cache: %w", err)
+	}
+	if err := fs.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// Fresh reports whether the file at relPath still has the modification time
+// and size recorded for it
+func (c *HashCache) Fresh(relPath string, info os.FileInfo) bool {
+	entry, ok := c.entries[relPath]
+	return ok && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime())
+}
+
+// Store records the state of the file at relPath with its content hash
+func (c *HashCache) Store(relPath string, info os.FileInfo, hash string) {
+	entry := hashCacheEntry{ModTime: info.ModTime(), Size: info.Size(), Hash: hash}
+	if previous, ok := c.entries[relPath]; ok && previous.Hash == entry.Hash &&
+		previous.Size == entry.Size && previous.ModTime.Equal(entry.ModTime) {
+		return
+	}
+	c.entries[relPath] = entry
+	c.dirty = true
+}
+
+// Retain drops the entries of files not in relPaths, such as deleted stories
+func (c *HashCache) Retain(relPaths []string) {
+	keep := make(map[string]bool, len(relPaths))
+	for _, path := range relPaths {
+		keep[path] = true
+	}
+	for path := range c.entries {
+		if !keep[path] {
+			delete(c.entries, path)
+			c.dirty = true
+		}
+	}
+}
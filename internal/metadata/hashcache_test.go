@@ -0,0 +1,128 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// readCountingFS counts the files read through it
+type readCountingFS struct {
+	io.FileSystem
+	reads map[string]int
+}
+
+func (fs *readCountingFS) ReadFile(path string) ([]byte, error) {
+	fs.reads[path]++
+	return fs.FileSystem.ReadFile(path)
+}
+
+func newHashCacheFS() *readCountingFS {
+	mock := io.NewMockFileSystem()
+	mock.AddFile("/project/docs/user-stories/01-login.md", []byte("# Login\n"))
+	mock.AddFile("/project/docs/user-stories/02-logout.md", []byte("# Logout\n"))
+	return &readCountingFS{FileSystem: mock, reads: make(map[string]int)}
+}
+
+func TestUpdateAllUserStoryMetadata_HashCache(t *testing.T) {
+	fs := newHashCacheFS()
+
+	updated, unchanged, _, err := UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Len(t, updated, 2)
+	assert.Empty(t, unchanged)
+	assert.True(t, fs.Exists("/project/"+HashCacheFile))
+
+	// A warm run reports the same result as a full one without reading the stories
+	fs.reads = make(map[string]int)
+	updated, unchanged, hashMap, err := UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+	assert.ElementsMatch(t, []string{"docs/user-stories/01-login.md", "docs/user-stories/02-logout.md"}, unchanged)
+	assert.Empty(t, hashMap)
+	assert.Zero(t, fs.reads["/project/docs/user-stories/01-login.md"])
+	assert.Zero(t, fs.reads["/project/docs/user-stories/02-logout.md"])
+
+	// An edited story no longer matches its entry and is processed again
+	content, err := fs.ReadFile("/project/docs/user-stories/01-login.md")
+	require.NoError(t, err)
+	require.NoError(t, fs.WriteFile("/project/docs/user-stories/01-login.md", append(content, "More text\n"...), 0644))
+
+	updated, unchanged, hashMap, err = UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/user-stories/01-login.md"}, updated)
+	assert.Equal(t, []string{"docs/user-stories/02-logout.md"}, unchanged)
+	assert.True(t, hashMap["docs/user-stories/01-login.md"].Changed)
+}
+
+func TestUpdateAllUserStoryMetadata_HashCacheSettingsChanged(t *testing.T) {
+	fs := newHashCacheFS()
+	_, _, _, err := UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+
+	// A warm cache written with other settings is not trusted
+	SetMetadataPlacement(Footer)
+	defer SetMetadataPlacement(FrontMatter)
+	updated, unchanged, _, err := UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Len(t, updated, 2)
+	assert.Empty(t, unchanged)
+	content, err := fs.ReadFile("/project/docs/user-stories/01-login.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), models.MetadataFooterMarker)
+
+	// Nor is one written before the timestamp format changed
+	models.SetTimestampFormat("2006-01-02")
+	defer models.SetTimestampFormat("")
+	updated, unchanged, _, err = UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Len(t, updated, 2)
+	assert.Empty(t, unchanged)
+
+	// The cache is trusted again once written with the current settings
+	updated, unchanged, _, err = UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+	assert.Len(t, unchanged, 2)
+}
+
+func TestLoadHashCache_Corrupt(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("/project/"+HashCacheFile, []byte("not json"))
+	fs.AddFile("/project/docs/user-stories/01-login.md", []byte("# Login\n"))
+
+	cache := LoadHashCache("/project", fs)
+	assert.Empty(t, cache.entries)
+
+	updated, _, _, err := UpdateAllUserStoryMetadata("/project/docs/user-stories", "/project", fs)
+	require.NoError(t, err)
+	assert.Len(t, updated, 1)
+	assert.Len(t, LoadHashCache("/project", fs).entries, 1)
+}
+
+func TestHashCache_Retain(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("/project/docs/a.md", []byte("# A\n"))
+	info, err := fs.Stat("/project/docs/a.md")
+	require.NoError(t, err)
+
+	cache := LoadHashCache("/project", fs)
+	cache.Store("docs/a.md", info, "hash-a")
+	cache.Store("docs/b.md", info, "hash-b")
+	assert.True(t, cache.Fresh("docs/a.md", info))
+
+	cache.Retain([]string{"docs/a.md"})
+	require.NoError(t, cache.Save("/project", fs))
+
+	reloaded := LoadHashCache("/project", fs)
+	assert.True(t, reloaded.Fresh("docs/a.md", info))
+	assert.False(t, reloaded.Fresh("docs/b.md", info))
+}
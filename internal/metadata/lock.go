@@ -0,0 +1,47 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+)
+
+// ErrChangeRequestLocked is returned when updating the references of a change
+// request whose workflow has been locked with usm code --lock
+var ErrChangeRequestLocked = errors.New("change request workflow is locked")
+
+// IsChangeRequestLocked reports whether the workflow of a change request has
+// been locked. It reads the workflow state file named by
+// models.WorkflowStateFilePath, since the workflow package depends on this
+// one. A change request without a state file is not locked, but one whose
+// state file cannot be read or parsed counts as locked, so that it is not
+// changed by mistake.
+func IsChangeRequestLocked(changeRequestPath string, fs io.FileSystem) bool {
+	statePath := models.WorkflowStateFilePath(changeRequestPath)
+	if !fs.Exists(statePath) {
+		return false
+	}
+	data, err := fs.ReadFile(statePath)
+	if err != nil {
+		logger.Warn("Treating change request with an unreadable state file as locked",
+			zap.String("file", statePath), zap.Error(err))
+		return true
+	}
+
+	var lock models.WorkflowLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		logger.Warn("Treating change request with an invalid state file as locked",
+			zap.String("file", statePath), zap.Error(err))
+		return true
+	}
+	return lock.Locked
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// lockChangeRequest writes the workflow state file of a complete change request
+func lockChangeRequest(fs io.FileSystem, changeRequestPath string, locked bool) {
+	state := fmt.Sprintf(`{"ChangeRequestPath": %q, "CurrentStepIndex": 8, "Locked": %t}`, changeRequestPath, locked)
+	fs.(*io.MockFileSystem).AddFile(models.WorkflowStateFilePath(changeRequestPath), []byte(state))
+}
+
+func TestIsChangeRequestLocked(t *testing.T) {
+	fs := setupReferenceTestFiles()
+	assert.False(t, IsChangeRequestLocked("docs/changes-request/cr1.blueprint.md", fs))
+
+	lockChangeRequest(fs, "docs/changes-request/cr1.blueprint.md", false)
+	assert.False(t, IsChangeRequestLocked("docs/changes-request/cr1.blueprint.md", fs))
+
+	lockChangeRequest(fs, "docs/changes-request/cr1.blueprint.md", true)
+	assert.True(t, IsChangeRequestLocked("docs/changes-request/cr1.blueprint.md", fs))
+	assert.False(t, IsChangeRequestLocked("docs/changes-request/cr2.blueprint.md", fs))
+
+	// A state file that cannot be parsed is treated as locked
+	fs.(*io.MockFileSystem).AddFile(models.WorkflowStateFilePath("docs/changes-request/cr2.blueprint.md"), []byte("not json"))
+	assert.True(t, IsChangeRequestLocked("docs/changes-request/cr2.blueprint.md", fs))
+}
+
+func TestUpdateChangeRequestReferences_Locked(t *testing.T) {
+	fs := setupReferenceTestFiles()
+	lockChangeRequest(fs, "docs/changes-request/cr1.blueprint.md", true)
+	before, _ := fs.ReadFile("docs/changes-request/cr1.blueprint.md")
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "new-hash-1", Changed: true},
+	}
+	updated, count, _, err := UpdateChangeRequestReferences("docs/changes-request/cr1.blueprint.md", hashMap, fs)
+	assert.True(t, errors.Is(err, ErrChangeRequestLocked))
+	assert.False(t, updated)
+	assert.Equal(t, 0, count)
+
+	after, _ := fs.ReadFile("docs/changes-request/cr1.blueprint.md")
+	assert.Equal(t, string(before), string(after))
+}
+
+func TestUpdateAllChangeRequestReferencesDetailed_SkipsLocked(t *testing.T) {
+	fs := setupReferenceTestFiles()
+	lockChangeRequest(fs, "docs/changes-request/cr1.blueprint.md", true)
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "new-hash-1", Changed: true},
+	}
+	result, err := UpdateAllChangeRequestReferencesDetailed("", hashMap, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/changes-request/cr1.blueprint.md"}, result.Locked)
+	assert.Equal(t, []string{"docs/changes-request/cr2.blueprint.md"}, result.UpdatedFiles)
+
+	content, _ := fs.ReadFile("docs/changes-request/cr1.blueprint.md")
+	assert.Contains(t, string(content), "content-hash: old-hash-1")
+}
+
+func TestUpdateAllChangeRequestReferencesDetailed_LockedUpToDate(t *testing.T) {
+	fs := setupReferenceTestFiles()
+	lockChangeRequest(fs, "docs/changes-request/cr2.blueprint.md", true)
+
+	// Only the unlocked change request references story 2
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story2.md": {FilePath: "docs/user-stories/story2.md", OldHash: "old-hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+	result, err := UpdateAllChangeRequestReferencesDetailed("", hashMap, fs)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Locked)
+	assert.Equal(t, []string{"docs/changes-request/cr1.blueprint.md"}, result.UpdatedFiles)
+	assert.Contains(t, result.UnchangedFiles, "docs/changes-request/cr2.blueprint.md")
+}
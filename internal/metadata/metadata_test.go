@@ -6,11 +6,14 @@
 package metadata
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
 )
 
 func TestExtractMetadata(t *testing.T) {
@@ -47,6 +50,52 @@ This is a sample user story.
 	assert.Equal(t, "abcdef1234567890", metadata.RawMetadata["_content_hash"])
 }
 
+func TestExtractMetadata_AcceptsBothTimestampFormats(t *testing.T) {
+	content := `---
+file_path: docs/user-stories/example/sample.md
+created_at: 2023-01-01
+last_updated: 2023-01-02T12:00:00Z
+---
+
+# Sample User Story
+`
+
+	for _, layout := range []string{time.RFC3339, models.DateOnlyFormat} {
+		models.SetTimestampFormat(layout)
+
+		metadata, err := ExtractMetadata(content)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), metadata.CreatedAt)
+		assert.Equal(t, time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC), metadata.LastUpdated)
+	}
+	models.SetTimestampFormat("")
+}
+
+func TestUpdateFileMetadata_DateOnlyTimestamps(t *testing.T) {
+	models.SetTimestampFormat(models.DateOnlyFormat)
+	defer models.SetTimestampFormat("")
+
+	fs := io.NewMockFileSystem()
+	fs.AddFile("test.md", []byte(`---
+file_path: test.md
+created_at: 2022-05-15T10:30:00Z
+last_updated: 2022-05-16T10:30:00Z
+_content_hash: oldhash
+---
+
+# Test
+`))
+
+	_, _, err := UpdateFileMetadata("test.md", "", fs)
+	assert.NoError(t, err)
+
+	content, _ := fs.ReadFile("test.md")
+	metadata, err := ExtractMetadata(string(content))
+	assert.NoError(t, err)
+	assert.Equal(t, "2022-05-15", metadata.RawMetadata["created_at"])
+	assert.Equal(t, time.Now().Format(time.DateOnly), metadata.RawMetadata["last_updated"])
+}
+
 func TestGetContentWithoutMetadata(t *testing.T) {
 	content := `---
 file_path: docs/user-stories/example/sample.md
@@ -80,6 +129,40 @@ func TestCalculateContentHash(t *testing.T) {
 	assert.Equal(t, expectedHash, hash)
 }
 
+func TestCalculateContentHash_IgnoredRegions(t *testing.T) {
+	story := func(toc string) string {
+		return "# Story\n\n<!-- usm:ignore -->\n" + toc + "<!-- usm:endignore -->\n\nAs a user, I want a table of contents\n"
+	}
+
+	hash := CalculateContentHash(story("- [Story](#story)\n"))
+	assert.Equal(t, hash, CalculateContentHash(story("- [Story](#story)\n- [Criteria](#criteria)\n")),
+		"editing an ignored region keeps the hash")
+	assert.Equal(t, hash, CalculateContentHash(story("")))
+
+	// Changes outside the region, or to the markers themselves, still count
+	assert.NotEqual(t, hash, CalculateContentHash(strings.Replace(story(""), "As a user", "As an admin", 1)))
+	assert.NotEqual(t, hash, CalculateContentHash("# Story\n\nAs a user, I want a table of contents\n"))
+}
+
+func TestUpdateFileMetadata_IgnoredRegionChange(t *testing.T) {
+	body := "# Story\n\n<!-- usm:ignore -->\nGenerated at 10:00\n<!-- usm:endignore -->\n\nText\n"
+	fs := io.NewMockFileSystem()
+	fs.AddFile("story.md", []byte(body))
+
+	updated, _, err := UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	content, err := fs.ReadFile("story.md")
+	require.NoError(t, err)
+
+	// Regenerating the region doesn't flip the hash or bump last_updated
+	fs.AddFile("story.md", []byte(strings.Replace(string(content), "10:00", "11:30", 1)))
+	updated, hashMap, err := UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.False(t, hashMap.Changed)
+}
+
 func setupMockFileSystem() *io.MockFileSystem {
 	fs := io.NewMockFileSystem()
 	
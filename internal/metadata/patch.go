@@ -0,0 +1,85 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// patchManagedFields replaces the values of fields in the metadata section of
+// content, leaving every other byte of content untouched: field order,
+// custom fields, quoting and blank lines are kept. It reports false when the
+// section cannot be patched and has to be rebuilt instead: content has no
+// metadata section where SetMetadataPlacement says, or the section does not
+// hold exactly the fields written by usm, each once.
+func patchManagedFields(content string, fields []metadataField) (string, bool) {
+	_, _, hasFrontMatter := models.SplitFrontMatter(content)
+	if hasFrontMatter != (metadataPlacement == FrontMatter) {
+		return content, false
+	}
+	start, end, ok := frontMatterSpan(content)
+	if !ok {
+		return content, false
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		values[field.key] = field.value
+	}
+
+	lines := strings.Split(content[start:end], "\n")
+	seen := make(map[string]bool, len(fields))
+	for i, line := range lines {
+		keyMatch := metadataTopLevelKeyRegex.FindStringSubmatch(line)
+		if keyMatch == nil {
+			continue
+		}
+		key := strings.TrimSpace(keyMatch[1])
+		if !managedFields[key] {
+			continue
+		}
+		value, ok := values[key]
+		if !ok || seen[key] {
+			return content, false
+		}
+		seen[key] = true
+		lines[i] = replaceFieldValue(line, len(keyMatch[0]), value)
+	}
+	if len(seen) != len(fields) {
+		return content, false
+	}
+
+	return content[:start] + strings.Join(lines, "\n") + content[end:], true
+}
+
+// replaceFieldValue replaces the value of a single-line field whose key ends
+// at offset valueStart of line, keeping the spacing after the colon, the
+// quotes around the value and a trailing carriage return. Values read back
+// from the raw metadata may still be quoted.
+func replaceFieldValue(line string, valueStart int, value string) string {
+	rest := line[valueStart:]
+	suffix := ""
+	if strings.HasSuffix(rest, "\r") {
+		rest, suffix = rest[:len(rest)-1], "\r"
+	}
+	old := strings.TrimSpace(rest)
+	prefix := rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+
+	if isQuoted(old) && !isQuoted(value) {
+		value = old[:1] + value + old[:1]
+	}
+	if prefix == "" {
+		prefix = " "
+	}
+	return line[:valueStart] + prefix + value + suffix
+}
+
+// isQuoted reports whether value is enclosed in single or double quotes
+func isQuoted(value string) bool {
+	return len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0]
+}
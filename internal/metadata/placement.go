@@ -0,0 +1,80 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// MetadataPlacement decides where UpdateFileMetadata writes the metadata of a
+// file. Metadata is read from either place whatever the placement.
+type MetadataPlacement int
+
+const (
+	// FrontMatter writes the metadata between "---" fences at the top of the
+	// file (the default)
+	FrontMatter MetadataPlacement = iota
+	// Footer writes the metadata at the bottom of the file between
+	// models.MetadataFooterMarker comments, which markdown renderers hide
+	Footer
+)
+
+// metadataPlacement is the placement applied by UpdateFileMetadata
+var metadataPlacement = FrontMatter
+
+// SetMetadataPlacement sets where metadata is written
+func SetMetadataPlacement(placement MetadataPlacement) {
+	metadataPlacement = placement
+}
+
+// CurrentMetadataPlacement returns where metadata is written
+func CurrentMetadataPlacement() MetadataPlacement {
+	return metadataPlacement
+}
+
+// ParseMetadataPlacement parses "top" (the default, also used for an empty
+// value) or "footer"
+func ParseMetadataPlacement(value string) (MetadataPlacement, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "top":
+		return FrontMatter, nil
+	case "footer":
+		return Footer, nil
+	default:
+		return FrontMatter, fmt.Errorf("unknown metadata placement %q, use top or footer", value)
+	}
+}
+
+// withMetadataFooter appends the fields of a front-matter block, as built by
+// GenerateMetadata, to body as a metadata footer, after a blank line
+func withMetadataFooter(body, frontMatter string) string {
+	fields, _, _ := models.SplitFrontMatter(frontMatter)
+
+	var sb strings.Builder
+	if body != "" {
+		sb.WriteString(body)
+		if !strings.HasSuffix(body, "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(models.MetadataFooterMarker + "\n")
+	if fields != "" {
+		sb.WriteString(fields + "\n")
+	}
+	sb.WriteString(models.MetadataFooterMarker + "\n")
+	return sb.String()
+}
+
+// footerBody returns body as read back from a metadata footer written after
+// it, i.e. without trailing blank lines, so that its hash stays stable
+func footerBody(body string) string {
+	_, footerBody, _ := models.SplitFooterMetadata(withMetadataFooter(body, ""))
+	return footerBody
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestUpdateFileMetadata_Footer(t *testing.T) {
+	defer SetMetadataPlacement(FrontMatter)
+	SetMetadataPlacement(Footer)
+
+	body := "# Story\n\nAs a user, I want a footer.\n"
+	fs := io.NewMockFileSystem()
+	fs.AddFile("story.md", []byte(body))
+
+	updated, hashMap, err := UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, CalculateContentHash(body), hashMap.NewHash)
+
+	content, err := fs.ReadFile("story.md")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), body+"\n<!-- usm:meta -->\nfile_path: story.md\n"), string(content))
+	assert.True(t, strings.HasSuffix(string(content), "\n<!-- usm:meta -->\n"), string(content))
+
+	// The footer is read back, and a second update changes nothing
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, CalculateContentHash(body), meta.ContentHash)
+	assert.Equal(t, body, GetContentWithoutMetadata(string(content)))
+
+	updated, hashMap, err = UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.False(t, hashMap.Changed)
+
+	// Custom fields are set in the footer
+	assert.Contains(t, SetCustomField(string(content), "priority", "high"), "priority: high\n<!-- usm:meta -->\n")
+}
+
+func TestUpdateFileMetadata_MovesMetadata(t *testing.T) {
+	defer SetMetadataPlacement(FrontMatter)
+
+	body := "# Story\n\nText\n"
+	fs := io.NewMockFileSystem()
+	fs.AddFile("story.md", []byte("---\nfile_path: story.md\ncreated_at: 2022-06-01T10:00:00Z\nlast_updated: 2022-06-20T15:45:00Z\n_content_hash: "+
+		CalculateContentHash(body)+"\npriority: high\n---\n\n"+body))
+
+	// Top to footer, keeping the fields and the hash
+	SetMetadataPlacement(Footer)
+	updated, hashMap, err := UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, hashMap.Changed)
+	content, err := fs.ReadFile("story.md")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), body), string(content))
+	assert.Contains(t, string(content), "last_updated: 2022-06-20T15:45:00Z\n")
+	assert.Contains(t, string(content), "priority: high\n<!-- usm:meta -->\n")
+
+	// And back to the top
+	SetMetadataPlacement(FrontMatter)
+	updated, hashMap, err = UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, hashMap.Changed)
+	content, err = fs.ReadFile("story.md")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "---\nfile_path: story.md\n"), string(content))
+	assert.True(t, strings.HasSuffix(string(content), "\n\n"+body), string(content))
+	assert.NotContains(t, string(content), "usm:meta")
+}
+
+func TestParseMetadataPlacement(t *testing.T) {
+	for value, want := range map[string]MetadataPlacement{"": FrontMatter, "top": FrontMatter, " Footer ": Footer} {
+		placement, err := ParseMetadataPlacement(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, placement, value)
+	}
+
+	_, err := ParseMetadataPlacement("bottom")
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LastUpdatedPolicy decides which edits of a user story bump its last_updated
+// timestamp
+type LastUpdatedPolicy int
+
+const (
+	// OnlyOnContentChange bumps last_updated when the body changes (the default)
+	OnlyOnContentChange LastUpdatedPolicy = iota
+	// OnAnyChange also bumps last_updated when a front-matter field not
+	// managed by usm, such as priority, is added, edited or removed. The
+	// fields are tracked through a _fields_hash entry; a story without one
+	// only gets it recorded on its first update.
+	OnAnyChange
+)
+
+// fieldsHashKey is the managed front-matter field holding the hash of the
+// custom fields under OnAnyChange
+const fieldsHashKey = "_fields_hash"
+
+// lastUpdatedPolicy is the policy applied by GenerateMetadata
+var lastUpdatedPolicy = OnlyOnContentChange
+
+// SetLastUpdatedPolicy sets the policy applied when updating metadata
+func SetLastUpdatedPolicy(policy LastUpdatedPolicy) {
+	lastUpdatedPolicy = policy
+}
+
+// CurrentLastUpdatedPolicy returns the policy applied when updating metadata
+func CurrentLastUpdatedPolicy() LastUpdatedPolicy {
+	return lastUpdatedPolicy
+}
+
+// ParseLastUpdatedPolicy parses "content" (the default, also used for an
+// empty value) or "any"
+func ParseLastUpdatedPolicy(value string) (LastUpdatedPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "content":
+		return OnlyOnContentChange, nil
+	case "any":
+		return OnAnyChange, nil
+	default:
+		return OnlyOnContentChange, fmt.Errorf("unknown last_updated policy %q, use content or any", value)
+	}
+}
+
+// customFieldsHash hashes the custom fields as written, in order
+func customFieldsHash(fields []CustomField) string {
+	return CalculateContentHash(formatCustomFields(fields))
+}
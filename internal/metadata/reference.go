@@ -6,18 +6,38 @@
 package metadata
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/models"
 	"go.uber.org/zap"
 )
 
-// Regular expression to match user story references in change request files
-var userStoryReferenceRegex = regexp.MustCompile(`(?m)^(\s*-\s*title:\s*.+\n\s*file:\s*)([^\n]+)(\n\s*content-hash:\s*)([^\n]+)(\n)`)
+// Regular expression to match user story references in change request files.
+// It tolerates any indentation, spaces around the colons, trailing spaces,
+// CRLF line endings and a reference ending the file without a newline. The
+// groups are the prefix up to the file path, the file path, the text up to
+// the content hash, the content hash and the end of the line.
+var userStoryReferenceRegex = regexp.MustCompile(`(?m)^([ \t]*-[ \t]*title[ \t]*:[^\n]*\n[ \t]*file[ \t]*:[ \t]*)([^\n]*?\S)([ \t\r]*\n[ \t]*content-hash[ \t]*:[ \t]*)(\S+)([ \t\r]*(?:\n|$))`)
+
+// referenceTitleRegex extracts the title from the first line of a reference
+var referenceTitleRegex = regexp.MustCompile(`^[ \t]*-[ \t]*title[ \t]*:(.*)`)
+
+// ErrNoChangeRequestDir is returned when the change requests directory does not
+// exist yet, which is normal in a fresh repository
+var ErrNoChangeRequestDir = errors.New("change request directory not found")
+
+// ErrReferenceOutsideRoot is returned for a reference path that is absolute
+// outside the repository or escapes it with "..", so it would only resolve
+// on one machine
+var ErrReferenceOutsideRoot = errors.New("reference path is outside the repository")
 
 // Reference represents a user story reference in a change request
 type Reference struct {
@@ -34,21 +54,81 @@ type MismatchedReference struct {
 	OldHash       string
 }
 
+// ErrNoChangeRequestMetadata is returned when a change request has no front matter
+var ErrNoChangeRequestMetadata = errors.New("change request has no metadata section")
+
 // ChangeRequestInfo contains information about a change request file
 type ChangeRequestInfo struct {
 	FilePath   string
+	Name       string
+	CreatedAt  time.Time // Zero when the created-at field is missing or invalid
 	References []Reference
 }
 
-// FindChangeRequestFiles finds all change request files in a directory
+// ParseChangeRequest parses the front matter of a change request blueprint:
+// its name, creation date and user story references. FilePath is left for
+// the caller to fill in.
+func ParseChangeRequest(content string) (ChangeRequestInfo, error) {
+	var info ChangeRequestInfo
+	if _, _, ok := models.SplitFrontMatter(content); !ok {
+		return info, ErrNoChangeRequestMetadata
+	}
+
+	raw := extractRawMetadata(content)
+	info.Name = raw["name"]
+	if createdAt, ok := raw["created-at"]; ok {
+		if t, err := models.ParseTimestamp(createdAt); err == nil {
+			info.CreatedAt = t
+		}
+	}
+	info.References = ExtractReferences(content)
+
+	return info, nil
+}
+
+// FindChangeRequestFiles finds all change request files in the change
+// requests directories of root; see config.ChangesDirs
 func FindChangeRequestFiles(root string, fs io.FileSystem) ([]string, error) {
-	changeRequestDir := filepath.Join(root, "docs", "changes-request")
-	
-	// Check if the directory exists
-	if !fs.Exists(changeRequestDir) {
-		return nil, fmt.Errorf("change request directory not found: %s", changeRequestDir)
+	return FindChangeRequestFilesIn(config.ChangesDirsIn(root), fs)
+}
+
+// FindChangeRequestFilesIn finds all change request files in dirs and their
+// subdirectories. A file found through several directories, e.g. nested ones,
+// is listed once. Directories that do not exist are skipped; it returns
+// ErrNoChangeRequestDir when none does.
+func FindChangeRequestFilesIn(dirs []string, fs io.FileSystem) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	found := false
+
+	for _, dir := range dirs {
+		if !fs.Exists(dir) {
+			logger.Debug("Change requests directory not found", zap.String("dir", dir))
+			continue
+		}
+		found = true
+
+		dirFiles, err := findChangeRequestFilesInDir(dir, fs)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range dirFiles {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
 	}
-	
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrNoChangeRequestDir, strings.Join(dirs, ", "))
+	}
+	return files, nil
+}
+
+// findChangeRequestFilesInDir finds the change request files of one directory
+// and its subdirectories
+func findChangeRequestFilesInDir(changeRequestDir string, fs io.FileSystem) ([]string, error) {
 	// Get all files in the directory
 	entries, err := fs.ReadDir(changeRequestDir)
 	if err != nil {
@@ -62,7 +142,7 @@ func FindChangeRequestFiles(root string, fs io.FileSystem) ([]string, error) {
 		if entry.IsDir() {
 			// Recursively search subdirectories
 			subdir := filepath.Join(changeRequestDir, entry.Name())
-			subfiles, err := FindChangeRequestFiles(subdir, fs)
+			subfiles, err := findChangeRequestFilesInDir(subdir, fs)
 			if err != nil {
 				logger.Warn("Error scanning subdirectory for change requests",
 					zap.String("dir", subdir),
@@ -84,12 +164,25 @@ func FindChangeRequestFiles(root string, fs io.FileSystem) ([]string, error) {
 	return files, nil
 }
 
-// ExtractReferences extracts all user story references from a change request file
+// ExtractReferences extracts all user story references from a change request
+// file. The user-stories list of the front matter is parsed as YAML; see
+// ParseReferenceList. Legacy blueprints without such a list are matched
+// against the fixed title, file, content-hash layout instead.
 func ExtractReferences(content string) []Reference {
+	if references, err := ParseReferenceList(content); err == nil {
+		return references
+	}
+	return extractLegacyReferences(content)
+}
+
+// extractLegacyReferences matches the references written as title, file and
+// content-hash lines, in that order
+func extractLegacyReferences(content string) []Reference {
 	references := []Reference{}
 	matches := userStoryReferenceRegex.FindAllStringSubmatch(content, -1)
+	indices := userStoryReferenceRegex.FindAllStringIndex(content, -1)
 	
-	for _, match := range matches {
+	for i, match := range matches {
 		// The match array should contain:
 		// [0]: full match
 		// [1]: prefix (spaces + "- title:" + content + newline + spaces + "file:")
@@ -104,36 +197,159 @@ func ExtractReferences(content string) []Reference {
 		filePath := match[2]
 		contentHash := match[4]
 		
-		// Extract title from the previous line
-		titleStart := strings.LastIndex(match[1], "title:")
-		if titleStart == -1 {
-			continue
-		}
-		titleLine := match[1][titleStart:]
-		titleEnd := strings.Index(titleLine, "\n")
-		if titleEnd == -1 {
+		// Extract title from the first line of the reference
+		titleLine, _, _ := strings.Cut(match[1], "\n")
+		titleMatch := referenceTitleRegex.FindStringSubmatch(titleLine)
+		if titleMatch == nil {
 			continue
 		}
-		title := strings.TrimSpace(strings.TrimPrefix(titleLine[:titleEnd], "title:"))
+		title := strings.TrimSpace(titleMatch[1])
 		
 		references = append(references, Reference{
 			Title:       title,
 			FilePath:    filePath,
 			ContentHash: contentHash,
-			Line:        0, // TODO: Calculate actual line number
+			Line:        strings.Count(content[:indices[i][0]], "\n") + 1,
 		})
 	}
 	
 	return references
 }
 
-// ValidateChangedReferences checks all references against the hash map and reports any that need updating
+// FindReferencingChangeRequests returns the change request files that reference
+// the given user story. storyPath may be relative to root or absolute; it is
+// compared against the reference file paths after cleaning. Change requests
+// that cannot be read are logged and skipped.
+func FindReferencingChangeRequests(storyPath string, root string, fs io.FileSystem) ([]string, error) {
+	files, err := FindChangeRequestFiles(root, fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find change request files: %w", err)
+	}
+
+	target := normalizeReferencePath(storyPath, root)
+	var referencing []string
+
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Warn("Failed to read change request",
+				zap.String("file", file),
+				zap.Error(err))
+			continue
+		}
+
+		for _, ref := range ExtractReferences(string(content)) {
+			if normalizeReferencePath(ref.FilePath, root) == target {
+				referencing = append(referencing, file)
+				break
+			}
+		}
+	}
+
+	return referencing, nil
+}
+
+// FindUnreferencedStories returns the user stories below the user stories
+// directory of root that no change request references, e.g. forgotten or
+// stale stories. Without any change request directory, every story is
+// unreferenced. Directories skipped by ShouldSkipDirectory are not scanned;
+// change requests and stories that cannot be read are logged and skipped.
+func FindUnreferencedStories(root string, fs io.FileSystem) ([]models.UserStory, error) {
+	files, err := FindChangeRequestFiles(root, fs)
+	if err != nil && !errors.Is(err, ErrNoChangeRequestDir) {
+		return nil, fmt.Errorf("failed to find change request files: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Warn("Failed to read change request",
+				zap.String("file", file),
+				zap.Error(err))
+			continue
+		}
+		for _, ref := range ExtractReferences(string(content)) {
+			referenced[normalizeReferencePath(ref.FilePath, root)] = true
+		}
+	}
+
+	storyFiles, err := FindMarkdownFiles(config.UserStoriesDirIn(root), fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user stories: %w", err)
+	}
+
+	var unreferenced []models.UserStory
+	for _, file := range storyFiles {
+		relPath, err := filepath.Rel(root, file)
+		if err != nil {
+			relPath = file
+		}
+		if referenced[normalizeReferencePath(relPath, root)] {
+			continue
+		}
+
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Warn("Failed to read user story",
+				zap.String("file", file),
+				zap.Error(err))
+			continue
+		}
+		story, err := models.LoadUserStoryFromFile(file, content)
+		if err != nil {
+			logger.Warn("Failed to load user story",
+				zap.String("file", file),
+				zap.Error(err))
+			continue
+		}
+		unreferenced = append(unreferenced, story)
+	}
+
+	return unreferenced, nil
+}
+
+// NormalizeReferencePath returns a story path as written in references:
+// cleaned, relative to root and with forward slashes. Relative paths are taken
+// as relative to root and absolute ones are made relative to it. A path that
+// ends up outside root returns ErrReferenceOutsideRoot.
+func NormalizeReferencePath(path, root string) (string, error) {
+	normalized := filepath.Clean(path)
+	if filepath.IsAbs(normalized) {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve root %s: %w", root, err)
+		}
+		if normalized, err = filepath.Rel(absRoot, normalized); err != nil {
+			return "", fmt.Errorf("%w: %s", ErrReferenceOutsideRoot, path)
+		}
+	}
+	if normalized == ".." || strings.HasPrefix(normalized, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrReferenceOutsideRoot, path)
+	}
+	return filepath.ToSlash(normalized), nil
+}
+
+// normalizeReferencePath is NormalizeReferencePath for comparing paths: a
+// path outside root is only cleaned
+func normalizeReferencePath(path string, root string) string {
+	if normalized, err := NormalizeReferencePath(path, root); err == nil {
+		return normalized
+	}
+	return filepath.Clean(path)
+}
+
+// ValidateChangedReferences checks all references against the hash map and reports any that need updating.
+// References already at the new hash, e.g. updated by an earlier run, need no update.
 func ValidateChangedReferences(references []Reference, hashMap ContentChangeMap) ([]Reference, []MismatchedReference) {
 	changedReferences := []Reference{}
 	mismatchedReferences := []MismatchedReference{}
 	
 	for _, ref := range references {
 		if hashInfo, ok := hashMap[ref.FilePath]; ok && hashInfo.Changed {
+			if ref.ContentHash == hashInfo.NewHash {
+				continue
+			}
 			if hashInfo.OldHash == ref.ContentHash {
 				changedReferences = append(changedReferences, ref)
 			} else {
@@ -155,24 +371,35 @@ func ValidateChangedReferences(references []Reference, hashMap ContentChangeMap)
 	return changedReferences, mismatchedReferences
 }
 
-// UpdateChangeRequestReferences updates references in change request files
+// UpdateChangeRequestReferences updates references in change request files.
+// It is safe to run again with the same hash map: references already at their
+// new hash are left alone, so a second run reports no update. A change request
+// whose workflow is locked is not written: an error wrapping
+// ErrChangeRequestLocked is returned when it has references to update. In safe
+// mode, a filePath outside the working directory and the configured docs
+// directories is not written either; see SetSafeMode.
 // Returns:
 // - bool: whether the file was updated
 // - int: number of references updated
 // - []MismatchedReference: list of references with mismatched hashes
 // - error: any error that occurred
 func UpdateChangeRequestReferences(filePath string, hashMap ContentChangeMap, fs io.FileSystem) (bool, int, []MismatchedReference, error) {
+	updated, count, mismatched, _, err := updateChangeRequestReferences(filePath, ".", hashMap, fs)
+	return updated, count, mismatched, err
+}
+
+// updateChangeRequestReferences is UpdateChangeRequestReferences also
+// returning the references whose hash was updated. In safe mode, filePath
+// must be inside root or its configured docs directories.
+func updateChangeRequestReferences(filePath, root string, hashMap ContentChangeMap, fs io.FileSystem) (bool, int, []MismatchedReference, []Reference, error) {
 	// Read file content
 	content, err := fs.ReadFile(filePath)
 	if err != nil {
-		return false, 0, nil, fmt.Errorf("failed to read change request file: %w", err)
+		return false, 0, nil, nil, fmt.Errorf("failed to read change request file: %w", err)
 	}
 	
 	originalContent := string(content)
 	
-	changesMade := false
-	updatedReferences := 0
-	
 	// Extract all references
 	references := ExtractReferences(originalContent)
 	
@@ -180,15 +407,69 @@ func UpdateChangeRequestReferences(filePath string, hashMap ContentChangeMap, fs
 	changedReferences, mismatchedReferences := ValidateChangedReferences(references, hashMap)
 	
 	if len(changedReferences) == 0 {
-		return false, 0, nil, nil
+		return false, 0, nil, nil, nil
 	}
 	
-	// Clone the original content for updating
-	updatedContent := originalContent
+	updatedContent, updatedReferences := replaceReferenceHashes(originalContent, hashMap)
+	changesMade := updatedReferences > 0
+	
+	// Write the updated content back to the file if changes were made
+	if changesMade {
+		if IsChangeRequestLocked(filePath, fs) {
+			return false, 0, nil, nil, fmt.Errorf("%w: %s", ErrChangeRequestLocked, filePath)
+		}
+		if err := checkWritePath(filePath, root, fs); err != nil {
+			return false, 0, nil, nil, err
+		}
+
+		fileInfo, err := fs.Stat(filePath)
+		if err != nil {
+			return false, updatedReferences, mismatchedReferences, nil, fmt.Errorf("failed to get file info: %w", err)
+		}
+		
+		err = fs.WriteFile(filePath, []byte(updatedContent), fileInfo.Mode())
+		if err != nil {
+			return false, updatedReferences, mismatchedReferences, nil, fmt.Errorf("failed to write updated content: %w", err)
+		}
+	}
+	
+	return changesMade, updatedReferences, mismatchedReferences, changedReferences, nil
+}
+
+// PreviewChangeRequestReferences reports the reference hash updates that
+// UpdateChangeRequestReferences would make to a change request file, without
+// writing it. The changes are returned as a unified diff, empty when no
+// reference would change, along with the number of references concerned.
+func PreviewChangeRequestReferences(filePath string, hashMap ContentChangeMap, fs io.FileSystem) (diff string, refCount int, err error) {
+	content, err := fs.ReadFile(filePath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read change request file: %w", err)
+	}
+
+	original := string(content)
+	updated, refCount := replaceReferenceHashes(original, hashMap)
+	if refCount == 0 {
+		return "", 0, nil
+	}
+	return unifiedLineDiff(filePath, original, updated), refCount, nil
+}
+
+// replaceReferenceHashes replaces the content hash of every reference to a
+// changed file of hashMap with its new hash, leaving the file paths and the
+// rest of content untouched. References already at the new hash are not
+// counted. It returns the updated content and the number of references
+// updated.
+func replaceReferenceHashes(content string, hashMap ContentChangeMap) (string, int) {
+	if listed, err := parseReferenceList(content); err == nil {
+		return replaceListedHashes(content, listed, hashMap)
+	}
+
+	updatedContent := content
+	updatedReferences := 0
 	
 	// Find all user story references
-	matches := userStoryReferenceRegex.FindAllStringSubmatch(originalContent, -1)
-	matchIndices := userStoryReferenceRegex.FindAllStringSubmatchIndex(originalContent, -1)
+	matches := userStoryReferenceRegex.FindAllStringSubmatch(content, -1)
+	matchIndices := userStoryReferenceRegex.FindAllStringSubmatchIndex(content, -1)
 	
 	// Track the offset caused by changes in string length
 	offset := 0
@@ -202,7 +483,7 @@ func UpdateChangeRequestReferences(filePath string, hashMap ContentChangeMap, fs
 		currentHash := match[4]
 		
 		// Check if this file is in our hash map
-		if hashInfo, ok := hashMap[filePath]; ok && hashInfo.Changed {
+		if hashInfo, ok := hashMap[filePath]; ok && hashInfo.Changed && currentHash != hashInfo.NewHash {
 			// We need to find where in the string the content hash starts and ends,
 			// adjusted by the current offset
 			hashStartPos := matchIndex[8] + offset
@@ -215,7 +496,6 @@ func UpdateChangeRequestReferences(filePath string, hashMap ContentChangeMap, fs
 			// Update only the content hash, not touching the file path
 			updatedContent = updatedContent[:hashStartPos] + hashInfo.NewHash + updatedContent[hashEndPos:]
 			
-			changesMade = true
 			updatedReferences++
 			
 			logger.Debug("Updated reference hash", 
@@ -225,20 +505,199 @@ func UpdateChangeRequestReferences(filePath string, hashMap ContentChangeMap, fs
 		}
 	}
 	
-	// Write the updated content back to the file if changes were made
-	if changesMade {
-		fileInfo, err := fs.Stat(filePath)
-		if err != nil {
-			return false, updatedReferences, mismatchedReferences, fmt.Errorf("failed to get file info: %w", err)
+	return updatedContent, updatedReferences
+}
+
+// diffContext is the number of unchanged lines shown around each change of a diff
+const diffContext = 2
+
+// unifiedLineDiff renders the changes from before to after as a unified diff
+// of path. It compares the contents line by line, which suits edits that
+// replace text within lines, such as hash updates, but not ones that add or
+// remove lines.
+func unifiedLineDiff(path, before, after string) string {
+	oldLines := strings.Split(before, "\n")
+	newLines := strings.Split(after, "\n")
+	if len(oldLines) != len(newLines) {
+		// Not a line-for-line edit: show the whole file as replaced
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n@@ -1,%d +1,%d @@\n", path, path, len(oldLines), len(newLines)))
+		for _, line := range oldLines {
+			sb.WriteString("-" + line + "\n")
 		}
-		
-		err = fs.WriteFile(filePath, []byte(updatedContent), fileInfo.Mode())
+		for _, line := range newLines {
+			sb.WriteString("+" + line + "\n")
+		}
+		return sb.String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", path, path))
+	for i := 0; i < len(oldLines); {
+		if oldLines[i] == newLines[i] {
+			i++
+			continue
+		}
+
+		// Extend the hunk while the next change is within the context lines
+		start := max(i-diffContext, 0)
+		end := i + 1
+		for j := end; j < len(oldLines) && j <= end+2*diffContext; j++ {
+			if oldLines[j] != newLines[j] {
+				end = j + 1
+			}
+		}
+		end = min(end+diffContext, len(oldLines))
+
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start))
+		for k := start; k < end; k++ {
+			if oldLines[k] == newLines[k] {
+				sb.WriteString(" " + oldLines[k] + "\n")
+				continue
+			}
+			sb.WriteString("-" + oldLines[k] + "\n")
+			sb.WriteString("+" + newLines[k] + "\n")
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// updateReferencePaths points the references of a change request file at the
+// new paths of renamed user stories, leaving their content hashes untouched.
+// renames maps old paths to new ones, relative to root or absolute. It returns
+// the number of references updated.
+func updateReferencePaths(filePath string, renames map[string]string, root string, fs io.FileSystem) (int, error) {
+	normalized := make(map[string]string, len(renames))
+	for oldPath, newPath := range renames {
+		normalized[normalizeReferencePath(oldPath, root)] = filepath.ToSlash(normalizeReferencePath(newPath, root))
+	}
+
+	return rewriteReferencePaths(filePath, root, fs, func(path string) (string, bool) {
+		newPath, ok := normalized[normalizeReferencePath(path, root)]
+		return newPath, ok
+	})
+}
+
+// NormalizeChangeRequestReferencePaths rewrites the reference paths of a
+// change request file that are absolute or not clean, e.g. pasted from a
+// file manager, as repo-relative paths. Paths outside root are left as they
+// are, for the doctor to report. It returns the number of references updated.
+func NormalizeChangeRequestReferencePaths(filePath, root string, fs io.FileSystem) (int, error) {
+	return rewriteReferencePaths(filePath, root, fs, func(path string) (string, bool) {
+		normalized, err := NormalizeReferencePath(path, root)
+		return normalized, err == nil && normalized != path
+	})
+}
+
+// rewriteReferencePaths replaces the file path of each reference of a change
+// request file for which rewrite returns a new path, leaving the content
+// hashes and the rest of the file untouched. The file is only written when a
+// path changed and, in safe mode, when it is inside root or its configured
+// docs directories. It returns the number of references updated.
+func rewriteReferencePaths(filePath, root string, fs io.FileSystem, rewrite func(path string) (string, bool)) (int, error) {
+	content, err := fs.ReadFile(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read change request file: %w", err)
+	}
+
+	updated, count := replaceReferencePaths(string(content), rewrite)
+	if count == 0 {
+		return 0, nil
+	}
+
+	if err := checkWritePath(filePath, root, fs); err != nil {
+		return 0, err
+	}
+	fileInfo, err := fs.Stat(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get file info: %w", err)
+	}
+	if err := fs.WriteFile(filePath, []byte(updated), fileInfo.Mode()); err != nil {
+		return 0, fmt.Errorf("failed to write updated content: %w", err)
+	}
+	return count, nil
+}
+
+// replaceReferencePaths replaces the file path of each reference of content
+// for which rewrite returns a new path. References listed in the front matter
+// are found by their YAML position, whatever the order of their fields. It
+// returns the updated content and the number of references updated.
+func replaceReferencePaths(content string, rewrite func(path string) (string, bool)) (string, int) {
+	if listed, err := parseReferenceList(content); err == nil {
+		return replaceListedPaths(content, listed, rewrite)
+	}
+
+	var updated strings.Builder
+	last, count := 0, 0
+	for _, matchIndex := range userStoryReferenceRegex.FindAllStringSubmatchIndex(content, -1) {
+		pathStart, pathEnd := matchIndex[4], matchIndex[5]
+		newPath, ok := rewrite(content[pathStart:pathEnd])
+		if !ok {
+			continue
+		}
+		updated.WriteString(content[last:pathStart])
+		updated.WriteString(newPath)
+		last = pathEnd
+		count++
+	}
+	updated.WriteString(content[last:])
+	return updated.String(), count
+}
+
+// updateAllReferencePaths applies updateReferencePaths to every change request
+// under root. A missing change requests directory is not an error.
+func updateAllReferencePaths(root string, renames map[string]string, fs io.FileSystem) error {
+	files, err := FindChangeRequestFiles(root, fs)
+	if errors.Is(err, ErrNoChangeRequestDir) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find change request files: %w", err)
+	}
+
+	for _, file := range files {
+		if _, err := updateReferencePaths(file, renames, root, fs); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// lockedChangeRequestsReferencing lists the change requests under root whose
+// workflow is locked and that reference one of the old paths of renames, so
+// that renumbering can refuse before renaming anything
+func lockedChangeRequestsReferencing(root string, renames map[string]string, fs io.FileSystem) ([]string, error) {
+	files, err := FindChangeRequestFiles(root, fs)
+	if errors.Is(err, ErrNoChangeRequestDir) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find change request files: %w", err)
+	}
+
+	renamed := make(map[string]bool, len(renames))
+	for oldPath := range renames {
+		renamed[normalizeReferencePath(oldPath, root)] = true
+	}
+
+	var locked []string
+	for _, file := range files {
+		if !IsChangeRequestLocked(file, fs) {
+			continue
+		}
+		content, err := fs.ReadFile(file)
 		if err != nil {
-			return false, updatedReferences, mismatchedReferences, fmt.Errorf("failed to write updated content: %w", err)
+			return nil, fmt.Errorf("failed to read change request file: %w", err)
+		}
+		for _, ref := range ExtractReferences(string(content)) {
+			if renamed[normalizeReferencePath(ref.FilePath, root)] {
+				locked = append(locked, file)
+				break
+			}
 		}
 	}
-	
-	return changesMade, updatedReferences, mismatchedReferences, nil
+	return locked, nil
 }
 
 // FilterChangedContent filters the hash map to include only files with changed content
@@ -262,24 +721,39 @@ func FilterChangedContent(hashMap ContentChangeMap) ContentChangeMap {
 // - []MismatchedReference: list of references with mismatched hashes
 // - error: any error that occurred
 func UpdateAllChangeRequestReferences(root string, hashMap ContentChangeMap, fs io.FileSystem) ([]string, []string, int, []MismatchedReference, error) {
+	result, err := UpdateAllChangeRequestReferencesDetailed(root, hashMap, fs)
+	return result.UpdatedFiles, result.UnchangedFiles, result.ReferencesUpdated, result.Mismatched, err
+}
+
+// UpdateAllChangeRequestReferencesDetailed is UpdateAllChangeRequestReferences
+// returning a ReferenceUpdateResult, which also breaks the references updated
+// down per change request
+func UpdateAllChangeRequestReferencesDetailed(root string, hashMap ContentChangeMap, fs io.FileSystem) (ReferenceUpdateResult, error) {
 	// Filter the hash map to include only files with changed content
 	changedMap := FilterChangedContent(hashMap)
 	
 	// If no content has changed, no need to update references
 	if len(changedMap) == 0 {
 		logger.Debug("No content changes detected, skipping reference updates")
-		return nil, nil, 0, nil, nil
+		return ReferenceUpdateResult{}, nil
 	}
 	
-	// Find all change request files
+	// Find all change request files; without any there is nothing to update
 	files, err := FindChangeRequestFiles(root, fs)
+	if errors.Is(err, ErrNoChangeRequestDir) {
+		logger.Debug("No change request directory, skipping reference updates", zap.String("root", root))
+		return ReferenceUpdateResult{}, nil
+	}
 	if err != nil {
-		return nil, nil, 0, nil, fmt.Errorf("failed to find change request files: %w", err)
+		return ReferenceUpdateResult{}, fmt.Errorf("failed to find change request files: %w", err)
 	}
 	
 	updatedFiles := make([]string, 0, len(files))
 	unchangedFiles := make([]string, 0, len(files))
+	var lockedFiles []string
 	allMismatchedRefs := make([]MismatchedReference, 0)
+	referencesPerFile := make(map[string]int)
+	var changes []ReferenceChange
 	totalReferencesUpdated := 0
 	errors := make([]string, 0) // Track any errors during processing
 	
@@ -287,7 +761,33 @@ func UpdateAllChangeRequestReferences(root string, hashMap ContentChangeMap, fs
 	for _, file := range files {
 		logger.Debug("Processing change request", zap.String("file", file))
 		
-		updated, referencesUpdated, mismatchedReferences, err := UpdateChangeRequestReferences(file, changedMap, fs)
+		// Locked change requests are left untouched, reporting the ones
+		// that are now out of date
+		if IsChangeRequestLocked(file, fs) {
+			relPath, err := filepath.Rel(root, file)
+			if err != nil {
+				relPath = file
+			}
+			if _, refCount, err := PreviewChangeRequestReferences(file, changedMap, fs); err == nil && refCount == 0 {
+				unchangedFiles = append(unchangedFiles, relPath)
+			} else {
+				logger.Debug("Skipping locked change request", zap.String("file", file))
+				lockedFiles = append(lockedFiles, relPath)
+			}
+			continue
+		}
+		
+		// Absolute reference paths would not match the repo-relative hash map
+		normalizedPaths, err := NormalizeChangeRequestReferencePaths(file, root, fs)
+		if err != nil {
+			logger.Error("Failed to normalize reference paths", 
+				zap.String("file", file), 
+				zap.Error(err))
+			errors = append(errors, fmt.Sprintf("%s: %s", file, err.Error()))
+			continue
+		}
+		
+		updated, referencesUpdated, mismatchedReferences, changedReferences, err := updateChangeRequestReferences(file, root, changedMap, fs)
 		if err != nil {
 			logger.Error("Failed to update references", 
 				zap.String("file", file), 
@@ -304,9 +804,19 @@ func UpdateAllChangeRequestReferences(root string, hashMap ContentChangeMap, fs
 			relPath = file // Use full path if relative path can't be determined
 		}
 		
-		if updated {
+		if updated || normalizedPaths > 0 {
 			updatedFiles = append(updatedFiles, relPath)
+			referencesPerFile[relPath] = referencesUpdated
 			totalReferencesUpdated += referencesUpdated
+			for _, ref := range changedReferences {
+				changes = append(changes, ReferenceChange{
+					ChangeRequest: relPath,
+					Title:         ref.Title,
+					FilePath:      ref.FilePath,
+					OldHash:       ref.ContentHash,
+					NewHash:       changedMap[ref.FilePath].NewHash,
+				})
+			}
 		} else {
 			unchangedFiles = append(unchangedFiles, relPath)
 		}
@@ -335,5 +845,59 @@ func UpdateAllChangeRequestReferences(root string, hashMap ContentChangeMap, fs
 		zap.Int("errors", stats["errors"]),
 		zap.Int("references_updated", stats["references_updated"]))
 	
-	return updatedFiles, unchangedFiles, totalReferencesUpdated, allMismatchedRefs, nil
-} 
\ No newline at end of file
+	return ReferenceUpdateResult{
+		UpdatedFiles:      updatedFiles,
+		UnchangedFiles:    unchangedFiles,
+		ReferencesUpdated: totalReferencesUpdated,
+		ReferencesPerFile: referencesPerFile,
+		Mismatched:        allMismatchedRefs,
+		Changes:           changes,
+		Locked:            lockedFiles,
+	}, nil
+} 
+
+// UpdateReferencesForFiles updates change request references for the given
+// user story files only, e.g. the ones a git hook reports as changed. The
+// current content hash of each file is computed and every reference to it is
+// updated, so the rest of the tree is never re-hashed. Paths may be relative
+// to root or absolute; files that cannot be read are logged and skipped.
+// The stored content hash of each file is taken as its previous hash, so call
+// it before updating their metadata; afterwards, pass the hash map returned by
+// UpdateFileMetadata to UpdateAllChangeRequestReferences instead.
+// It returns the same values as UpdateAllChangeRequestReferences.
+func UpdateReferencesForFiles(changedPaths []string, root string, fs io.FileSystem) ([]string, []string, int, []MismatchedReference, error) {
+	hashMap := make(ContentChangeMap, len(changedPaths))
+
+	for _, path := range changedPaths {
+		relPath := normalizeReferencePath(path, root)
+		fullPath := relPath
+		if !filepath.IsAbs(fullPath) {
+			fullPath = filepath.Join(root, relPath)
+		}
+
+		content, err := fs.ReadFile(fullPath)
+		if err != nil {
+			logger.Warn("Skipping unreadable user story",
+				zap.String("file", fullPath),
+				zap.Error(err))
+			continue
+		}
+
+		existingMetadata, err := ExtractMetadata(string(content))
+		if err != nil {
+			logger.Warn("Skipping user story with invalid metadata",
+				zap.String("file", fullPath),
+				zap.Error(err))
+			continue
+		}
+
+		hashMap[relPath] = ContentHashMap{
+			FilePath: relPath,
+			OldHash:  existingMetadata.ContentHash,
+			NewHash:  CalculateContentHash(GetContentWithoutMetadata(string(content))),
+			Changed:  true, // The caller reported the file as changed
+		}
+	}
+
+	return UpdateAllChangeRequestReferences(root, hashMap, fs)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 )
 
@@ -29,6 +30,7 @@ func TestIntegration_UpdateChangeRequestReferences(t *testing.T) {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tempDir)
+	t.Setenv(config.ChangesDirEnv, tempDir)
 	
 	// Create test file
 	changeRequestContent := `
@@ -96,6 +98,7 @@ func TestIntegration_UpdateChangeRequestReferences_MismatchedHash(t *testing.T)
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tempDir)
+	t.Setenv(config.ChangesDirEnv, tempDir)
 	
 	// Create test file
 	changeRequestContent := `
@@ -417,6 +420,9 @@ func TestIntegration_UpdateChangeRequestReferences_PreventFilePathCorruption(t *
 	// Setup temporary directory
 	tempDir, cleanup := setupTempDir(t)
 	defer cleanup()
+	// The change request lives in a configured changes directory outside the
+	// working directory
+	t.Setenv(config.ChangesDirEnv, tempDir)
 	
 	// Use real file system
 	fs := io.NewOSFileSystem()
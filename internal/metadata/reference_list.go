@@ -0,0 +1,193 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoReferenceList is returned by ParseReferenceList when a change request
+// has no front matter, front matter that is not valid YAML or no
+// user-stories list
+var ErrNoReferenceList = errors.New("change request has no user-stories list")
+
+// listedReference is a reference read from the user-stories list, with the
+// position of its content hash value in the change request
+type listedReference struct {
+	Reference
+	hashLine   int // 1-based line of the content hash value, 0 when missing
+	hashColumn int // 1-based column of the content hash value
+	fileLine   int // 1-based line of the file value
+	fileColumn int // 1-based column of the file value
+}
+
+// ParseReferenceList parses the user-stories list of the front matter of a
+// change request as YAML, so the title, file and content-hash fields of each
+// story may come in any order, next to other fields such as status, which are
+// ignored. Stories without a file are skipped. Line is set to the line of
+// each story in content. It returns ErrNoReferenceList when content has no
+// such list, e.g. for legacy blueprints listing their stories in the body.
+func ParseReferenceList(content string) ([]Reference, error) {
+	listed, err := parseReferenceList(content)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]Reference, len(listed))
+	for i, ref := range listed {
+		references[i] = ref.Reference
+	}
+	return references, nil
+}
+
+// parseReferenceList is ParseReferenceList keeping the positions of the file
+// and content hash values
+func parseReferenceList(content string) ([]listedReference, error) {
+	frontMatter, _, ok := models.SplitFrontMatter(content)
+	if !ok {
+		return nil, ErrNoReferenceList
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontMatter), &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoReferenceList, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, ErrNoReferenceList
+	}
+
+	list := mappingValue(doc.Content[0], "user-stories")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil, ErrNoReferenceList
+	}
+
+	// The front matter starts on the line after the opening fence
+	const lineOffset = 1
+
+	references := []listedReference{}
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		file := mappingValue(item, "file")
+		if file == nil || strings.TrimSpace(file.Value) == "" {
+			continue
+		}
+
+		ref := listedReference{
+			Reference: Reference{
+				FilePath: strings.TrimSpace(file.Value),
+				Line:     item.Line + lineOffset,
+			},
+			fileLine:   file.Line + lineOffset,
+			fileColumn: file.Column,
+		}
+		if title := mappingValue(item, "title"); title != nil {
+			ref.Title = strings.TrimSpace(title.Value)
+		}
+		if hash := mappingValue(item, "content-hash"); hash != nil {
+			ref.ContentHash = strings.TrimSpace(hash.Value)
+			ref.hashLine = hash.Line + lineOffset
+			ref.hashColumn = hash.Column
+		}
+		references = append(references, ref)
+	}
+	return references, nil
+}
+
+// mappingValue returns the value of key in a YAML mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceListedHashes replaces the content hash of every listed reference to
+// a changed file of hashMap with its new hash, in place, leaving the rest of
+// content untouched. References already at the new hash are skipped. It
+// returns the updated content and the number of references updated.
+func replaceListedHashes(content string, listed []listedReference, hashMap ContentChangeMap) (string, int) {
+	lines := strings.SplitAfter(content, "\n")
+	updatedReferences := 0
+
+	// From the last reference, so that a replacement never moves the
+	// columns of the references before it on the same line
+	for i := len(listed) - 1; i >= 0; i-- {
+		ref := listed[i]
+		hashInfo, ok := hashMap[ref.FilePath]
+		if !ok || !hashInfo.Changed || ref.ContentHash == hashInfo.NewHash || ref.hashLine == 0 || ref.hashLine > len(lines) {
+			continue
+		}
+
+		// The column points at the value, or at its opening quote
+		line := lines[ref.hashLine-1]
+		start := ref.hashColumn - 1
+		if start < 0 || start > len(line) {
+			start = 0
+		}
+		index := strings.Index(line[start:], ref.ContentHash)
+		if ref.ContentHash == "" || index < 0 {
+			continue
+		}
+		index += start
+
+		lines[ref.hashLine-1] = line[:index] + hashInfo.NewHash + line[index+len(ref.ContentHash):]
+		updatedReferences++
+
+		logger.Debug("Updated reference hash",
+			zap.String("file", ref.FilePath),
+			zap.String("old_hash", ref.ContentHash),
+			zap.String("new_hash", hashInfo.NewHash))
+	}
+
+	return strings.Join(lines, ""), updatedReferences
+}
+
+// replaceListedPaths replaces the file path of every listed reference for
+// which rewrite returns a new path, in place, leaving the rest of content
+// untouched. It returns the updated content and the number of references
+// updated.
+func replaceListedPaths(content string, listed []listedReference, rewrite func(path string) (string, bool)) (string, int) {
+	lines := strings.SplitAfter(content, "\n")
+	updatedReferences := 0
+
+	// From the last reference, so that a replacement never moves the
+	// columns of the references before it on the same line
+	for i := len(listed) - 1; i >= 0; i-- {
+		ref := listed[i]
+		newPath, ok := rewrite(ref.FilePath)
+		if !ok || ref.fileLine == 0 || ref.fileLine > len(lines) {
+			continue
+		}
+
+		// The column points at the value, or at its opening quote
+		line := lines[ref.fileLine-1]
+		start := ref.fileColumn - 1
+		if start < 0 || start > len(line) {
+			start = 0
+		}
+		index := strings.Index(line[start:], ref.FilePath)
+		if index < 0 {
+			continue
+		}
+		index += start
+
+		lines[ref.fileLine-1] = line[:index] + newPath + line[index+len(ref.FilePath):]
+		updatedReferences++
+	}
+
+	return strings.Join(lines, ""), updatedReferences
+}
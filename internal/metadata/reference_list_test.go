@@ -0,0 +1,138 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+const reorderedBlueprint = `---
+name: Login
+created-at: 2025-03-17T12:00:00Z
+user-stories:
+  - file: docs/user-stories/01-login.md
+    content-hash: hash-1
+    title: Login
+    status: in-progress
+  - content-hash: "hash-2"
+    status: done
+    title: Logout
+    file: docs/user-stories/02-logout.md
+  - title: Draft without a file
+---
+
+# Blueprint
+`
+
+func TestParseReferenceList_ReorderedFields(t *testing.T) {
+	references, err := ParseReferenceList(reorderedBlueprint)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Reference{
+		{Title: "Login", FilePath: "docs/user-stories/01-login.md", ContentHash: "hash-1", Line: 5},
+		{Title: "Logout", FilePath: "docs/user-stories/02-logout.md", ContentHash: "hash-2", Line: 9},
+	}, references)
+}
+
+func TestParseReferenceList_NoList(t *testing.T) {
+	for name, content := range map[string]string{
+		"no front matter": "# Blueprint\n",
+		"no list":         "---\nname: Login\n---\n",
+		"invalid YAML":    "---\nuser-stories:\n  - title: Login: basic\n    file: a.md\n---\n",
+	} {
+		_, err := ParseReferenceList(content)
+		assert.ErrorIs(t, err, ErrNoReferenceList, name)
+	}
+}
+
+func TestExtractReferences_LegacyFallback(t *testing.T) {
+	// Titles holding a colon are not valid YAML but match the legacy layout
+	content := `---
+name: Login
+user-stories:
+  - title: Login: basic
+    file: docs/user-stories/01-login.md
+    content-hash: hash-1
+---
+`
+	references := ExtractReferences(content)
+	require.Len(t, references, 1)
+	assert.Equal(t, "Login: basic", references[0].Title)
+	assert.Equal(t, "hash-1", references[0].ContentHash)
+}
+
+func TestUpdateChangeRequestReferences_ReorderedFields(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/changes-request/login.blueprint.md", []byte(reorderedBlueprint))
+	hashMap := ContentChangeMap{
+		"docs/user-stories/01-login.md":  {FilePath: "docs/user-stories/01-login.md", OldHash: "hash-1", NewHash: "new-hash-1", Changed: true},
+		"docs/user-stories/02-logout.md": {FilePath: "docs/user-stories/02-logout.md", OldHash: "hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+
+	updated, count, mismatched, err := UpdateChangeRequestReferences("docs/changes-request/login.blueprint.md", hashMap, fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, 2, count)
+	assert.Empty(t, mismatched)
+
+	content, err := fs.ReadFile("docs/changes-request/login.blueprint.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "    content-hash: new-hash-1\n")
+	assert.Contains(t, string(content), `  - content-hash: "new-hash-2"`+"\n")
+	assert.Contains(t, string(content), "    status: in-progress\n", "other fields are kept")
+}
+
+func TestUpdateReferencePaths_ReorderedFields(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/changes-request/login.blueprint.md", []byte(reorderedBlueprint))
+	renames := map[string]string{
+		"docs/user-stories/01-login.md":  "docs/user-stories/03-login.md",
+		"docs/user-stories/02-logout.md": "docs/user-stories/04-logout.md",
+	}
+
+	count, err := updateReferencePaths("docs/changes-request/login.blueprint.md", renames, ".", fs)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	content, err := fs.ReadFile("docs/changes-request/login.blueprint.md")
+	require.NoError(t, err)
+	references, err := ParseReferenceList(string(content))
+	require.NoError(t, err)
+	require.Len(t, references, 2)
+	assert.Equal(t, "docs/user-stories/03-login.md", references[0].FilePath)
+	assert.Equal(t, "hash-1", references[0].ContentHash)
+	assert.Equal(t, "docs/user-stories/04-logout.md", references[1].FilePath)
+	assert.Equal(t, "hash-2", references[1].ContentHash)
+}
+
+func TestReplaceReferencePaths_FlowStyle(t *testing.T) {
+	content := "---\nuser-stories:\n  - {file: a.md, content-hash: h1}\n  - {content-hash: h2, file: \"b.md\"}\n---\n"
+	rename := map[string]string{"a.md": "docs/a.md", "b.md": "docs/b.md"}
+
+	updated, count := replaceReferencePaths(content, func(path string) (string, bool) {
+		newPath, ok := rename[path]
+		return newPath, ok
+	})
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "---\nuser-stories:\n  - {file: docs/a.md, content-hash: h1}\n  - {content-hash: h2, file: \"docs/b.md\"}\n---\n", updated)
+}
+
+func TestReplaceReferenceHashes_FlowStyleSameLine(t *testing.T) {
+	content := "---\nuser-stories: [{file: a.md, content-hash: old-hash-a}, {file: b.md, content-hash: old-hash-b}]\n---\n"
+	hashMap := ContentChangeMap{
+		"a.md": {FilePath: "a.md", OldHash: "old-hash-a", NewHash: "a1", Changed: true},
+		"b.md": {FilePath: "b.md", OldHash: "old-hash-b", NewHash: "b1", Changed: true},
+	}
+
+	// The shorter first hash does not move the column of the second one
+	updated, count := replaceReferenceHashes(content, hashMap)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "---\nuser-stories: [{file: a.md, content-hash: a1}, {file: b.md, content-hash: b1}]\n---\n", updated)
+}
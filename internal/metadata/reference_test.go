@@ -6,9 +6,15 @@
 package metadata
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/config"
 	"github.com/user-story-matrix/usm/internal/io"
 )
 
@@ -82,6 +88,61 @@ func TestFindChangeRequestFiles(t *testing.T) {
 	assert.Contains(t, files, "docs/changes-request/not-a-blueprint.md")
 }
 
+func TestFindChangeRequestFilesIn(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("teams/billing/invoices.blueprint.md", []byte("# Invoices"))
+	fs.AddFile("teams/search/ranking.blueprint.md", []byte("# Ranking"))
+	fs.AddFile("teams/search/notes.txt", []byte("not a change request"))
+
+	// A directory listed twice lists its files once; missing ones are skipped
+	files, err := FindChangeRequestFilesIn([]string{"teams/billing", "teams/search", "teams/billing/", "teams/missing"}, fs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"teams/billing/invoices.blueprint.md",
+		"teams/search/ranking.blueprint.md",
+	}, files)
+
+	_, err = FindChangeRequestFilesIn([]string{"teams/missing"}, fs)
+	assert.ErrorIs(t, err, ErrNoChangeRequestDir)
+}
+
+func TestFindChangeRequestFilesIn_Subdirectories(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "billing", "2025"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "billing", "invoices.blueprint.md"), []byte("# Invoices"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "billing", "2025", "refunds.blueprint.md"), []byte("# Refunds"), 0644))
+
+	// Nested directories list each file once
+	files, err := FindChangeRequestFilesIn([]string{filepath.Join(root, "billing"), filepath.Join(root, "billing", "2025")}, io.NewOSFileSystem())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "billing", "invoices.blueprint.md"),
+		filepath.Join(root, "billing", "2025", "refunds.blueprint.md"),
+	}, files)
+}
+
+func TestUpdateAllChangeRequestReferences_SeveralDirectories(t *testing.T) {
+	t.Setenv(config.ChangesDirEnv, "teams/billing:teams/search")
+	fs := io.NewMockFileSystem()
+	blueprint := "---\nname: %s\nuser-stories:\n  - title: Login\n    file: docs/user-stories/login.md\n    content-hash: old-hash\n---\n"
+	fs.AddFile("teams/billing/invoices.blueprint.md", []byte(fmt.Sprintf(blueprint, "Invoices")))
+	fs.AddFile("teams/search/ranking.blueprint.md", []byte(fmt.Sprintf(blueprint, "Ranking")))
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/login.md": {FilePath: "docs/user-stories/login.md", OldHash: "old-hash", NewHash: "new-hash", Changed: true},
+	}
+	updated, _, count, _, err := UpdateAllChangeRequestReferences("", hashMap, fs)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, updated, 2)
+
+	for _, path := range []string{"teams/billing/invoices.blueprint.md", "teams/search/ranking.blueprint.md"} {
+		content, err := fs.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "content-hash: new-hash")
+	}
+}
+
 func TestUpdateChangeRequestReferences(t *testing.T) {
 	// Setup
 	mockFS := io.NewMockFileSystem()
@@ -124,6 +185,59 @@ User stories:
 	assert.Contains(t, string(updatedContent), "content-hash: newhash456")
 }
 
+func TestPreviewChangeRequestReferences(t *testing.T) {
+	fs := setupReferenceTestFiles()
+	path := "docs/changes-request/cr1.blueprint.md"
+	before, err := fs.ReadFile(path)
+	assert.NoError(t, err)
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "new-hash-1", Changed: true},
+		"docs/user-stories/story2.md": {FilePath: "docs/user-stories/story2.md", OldHash: "old-hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+
+	diff, count, err := PreviewChangeRequestReferences(path, hashMap, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, `--- a/docs/changes-request/cr1.blueprint.md
++++ b/docs/changes-request/cr1.blueprint.md
+@@ -5,8 +5,8 @@
+   - title: Story 1
+     file: docs/user-stories/story1.md
+-    content-hash: old-hash-1
++    content-hash: new-hash-1
+   - title: Story 2
+     file: docs/user-stories/story2.md
+-    content-hash: old-hash-2
++    content-hash: new-hash-2
+ ---
+ 
+`, diff)
+
+	// Nothing is written
+	after, err := fs.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	// The preview matches what the update then does
+	_, updatedCount, _, err := UpdateChangeRequestReferences(path, hashMap, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, count, updatedCount)
+}
+
+func TestPreviewChangeRequestReferences_NoChanges(t *testing.T) {
+	fs := setupReferenceTestFiles()
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "old-hash-1", Changed: false},
+	}
+
+	diff, count, err := PreviewChangeRequestReferences("docs/changes-request/cr2.blueprint.md", hashMap, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, diff)
+}
+
 func TestUpdateChangeRequestReferences_NoChanges(t *testing.T) {
 	fs := setupReferenceTestFiles()
 
@@ -149,6 +263,61 @@ func TestUpdateChangeRequestReferences_NoChanges(t *testing.T) {
 	assert.Contains(t, string(content), "content-hash: old-hash-1")
 }
 
+func TestUpdateChangeRequestReferences_RunTwice(t *testing.T) {
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "new-hash-1", Changed: true},
+		"docs/user-stories/story2.md": {FilePath: "docs/user-stories/story2.md", OldHash: "old-hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+
+	for _, path := range []string{"docs/changes-request/cr1.blueprint.md", "legacy.blueprint.md"} {
+		t.Run(path, func(t *testing.T) {
+			fs := setupReferenceTestFiles().(*io.MockFileSystem)
+			fs.AddFile("legacy.blueprint.md", []byte("# Legacy\n\n- title: Story 1\n  file: docs/user-stories/story1.md\n  content-hash: old-hash-1\n"+
+				"- title: Story 2\n  file: docs/user-stories/story2.md\n  content-hash: old-hash-2\n"))
+
+			updated, _, _, err := UpdateChangeRequestReferences(path, hashMap, fs)
+			require.NoError(t, err)
+			assert.True(t, updated)
+			after, err := fs.ReadFile(path)
+			require.NoError(t, err)
+
+			// The references are already at the new hashes: nothing to do,
+			// and nothing reported as mismatched
+			updated, count, mismatches, err := UpdateChangeRequestReferences(path, hashMap, fs)
+			require.NoError(t, err)
+			assert.False(t, updated)
+			assert.Zero(t, count)
+			assert.Empty(t, mismatches)
+
+			again, err := fs.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(after), string(again))
+
+			diff, count, err := PreviewChangeRequestReferences(path, hashMap, fs)
+			require.NoError(t, err)
+			assert.Zero(t, count)
+			assert.Empty(t, diff)
+		})
+	}
+}
+
+func TestUpdateChangeRequestReferences_PartlyUpdated(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("cr.blueprint.md", []byte("# CR\n\n- title: Story 1\n  file: docs/user-stories/story1.md\n  content-hash: new-hash-1\n"+
+		"- title: Story 2\n  file: docs/user-stories/story2.md\n  content-hash: old-hash-2\n"))
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "new-hash-1", Changed: true},
+		"docs/user-stories/story2.md": {FilePath: "docs/user-stories/story2.md", OldHash: "old-hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+
+	// An interrupted run left one reference updated: only the other one is
+	updated, count, mismatches, err := UpdateChangeRequestReferences("cr.blueprint.md", hashMap, fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, 1, count)
+	assert.Empty(t, mismatches)
+}
+
 func TestFilterChangedContent(t *testing.T) {
 	// Create a hash map with both changed and unchanged content
 	hashMap := make(ContentChangeMap)
@@ -224,6 +393,44 @@ User stories:
 	assert.Equal(t, 0, len(mismatches))
 }
 
+func TestUpdateAllChangeRequestReferencesDetailed_PerFileCounts(t *testing.T) {
+	fs := setupReferenceTestFiles()
+
+	// Story 1 is shared by both change requests, story 2 only by the first
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", OldHash: "old-hash-1", NewHash: "new-hash-1", Changed: true},
+		"docs/user-stories/story2.md": {FilePath: "docs/user-stories/story2.md", OldHash: "old-hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+
+	result, err := UpdateAllChangeRequestReferencesDetailed("", hashMap, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"docs/changes-request/cr1.blueprint.md": 2,
+		"docs/changes-request/cr2.blueprint.md": 1,
+	}, result.ReferencesPerFile)
+	assert.Equal(t, 3, result.ReferencesUpdated)
+	assert.ElementsMatch(t, []string{"docs/changes-request/cr1.blueprint.md", "docs/changes-request/cr2.blueprint.md"}, result.UpdatedFiles)
+	assert.Empty(t, result.Mismatched)
+}
+
+func TestUpdateAllChangeRequestReferencesDetailed_Changes(t *testing.T) {
+	fs := setupReferenceTestFiles()
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story2.md": {FilePath: "docs/user-stories/story2.md", OldHash: "old-hash-2", NewHash: "new-hash-2", Changed: true},
+	}
+
+	result, err := UpdateAllChangeRequestReferencesDetailed("", hashMap, fs)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReferenceChange{{
+		ChangeRequest: "docs/changes-request/cr1.blueprint.md",
+		Title:         "Story 2",
+		FilePath:      "docs/user-stories/story2.md",
+		OldHash:       "old-hash-2",
+		NewHash:       "new-hash-2",
+	}}, result.Changes)
+}
+
 func TestUpdateAllChangeRequestReferences_NoChanges(t *testing.T) {
 	// Setup
 	mockFS := io.NewMockFileSystem()
@@ -378,4 +585,305 @@ user-stories:
 	for _, pattern := range corruptionPatterns {
 		assert.NotContains(t, string(updatedContent), pattern, "Found corruption pattern: %s", pattern)
 	}
-} 
\ No newline at end of file
+} 
+func TestFindReferencingChangeRequests(t *testing.T) {
+	fs := setupReferenceTestFiles()
+
+	files, err := FindReferencingChangeRequests("docs/user-stories/story1.md", "", fs)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"docs/changes-request/cr1.blueprint.md",
+		"docs/changes-request/cr2.blueprint.md",
+	}, files)
+
+	files, err = FindReferencingChangeRequests("./docs/user-stories/story2.md", "", fs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/changes-request/cr1.blueprint.md"}, files)
+
+	files, err = FindReferencingChangeRequests("docs/user-stories/unknown.md", "", fs)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFindReferencingChangeRequests_NoChangeRequestDir(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	_, err := FindReferencingChangeRequests("docs/user-stories/story1.md", "", fs)
+	assert.ErrorIs(t, err, ErrNoChangeRequestDir)
+}
+
+func TestFindUnreferencedStories(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		full := filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+	}
+	write("docs/user-stories/01-login.md", "# Login\n")
+	write("docs/user-stories/02-logout.md", "# Logout\n")
+	write("docs/user-stories/auth/03-signup.md", "# Signup\n")
+	write("docs/user-stories/node_modules/04-vendored.md", "# Vendored\n")
+	write("docs/changes-request/auth.blueprint.md", `---
+name: Auth
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: hash-1
+---
+`)
+
+	stories, err := FindUnreferencedStories(root, io.NewOSFileSystem())
+	require.NoError(t, err)
+	var titles []string
+	for _, story := range stories {
+		titles = append(titles, story.Title)
+	}
+	assert.ElementsMatch(t, []string{"Logout", "Signup"}, titles)
+}
+
+func TestFindUnreferencedStories_NoChangeRequestDir(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+
+	stories, err := FindUnreferencedStories("", fs)
+	require.NoError(t, err)
+	require.Len(t, stories, 1)
+	assert.Equal(t, "docs/user-stories/01-login.md", stories[0].FilePath)
+}
+
+func TestUpdateAllChangeRequestReferences_NoChangeRequestDir(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {
+			FilePath: "docs/user-stories/story1.md",
+			OldHash:  "old-hash-1",
+			NewHash:  "new-hash-1",
+			Changed:  true,
+		},
+	}
+
+	_, err := FindChangeRequestFiles("", fs)
+	assert.ErrorIs(t, err, ErrNoChangeRequestDir)
+
+	updated, unchanged, count, mismatched, err := UpdateAllChangeRequestReferences("", hashMap, fs)
+	assert.NoError(t, err)
+	assert.Empty(t, updated)
+	assert.Empty(t, unchanged)
+	assert.Zero(t, count)
+	assert.Empty(t, mismatched)
+}
+
+func TestUpdateAllChangeRequestReferences_UnreadableDir(t *testing.T) {
+	fs := &failingReadDirFS{MockFileSystem: io.NewMockFileSystem()}
+	fs.AddDirectory("docs/changes-request")
+	hashMap := ContentChangeMap{
+		"docs/user-stories/story1.md": {FilePath: "docs/user-stories/story1.md", NewHash: "new", Changed: true},
+	}
+
+	_, _, _, _, err := UpdateAllChangeRequestReferences("", hashMap, fs)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNoChangeRequestDir)
+}
+
+// failingReadDirFS is a mock file system whose directories cannot be listed
+type failingReadDirFS struct {
+	*io.MockFileSystem
+}
+
+func (fs *failingReadDirFS) ReadDir(path string) ([]os.DirEntry, error) {
+	return nil, fmt.Errorf("permission denied: %s", path)
+}
+
+func TestUpdateReferencesForFiles(t *testing.T) {
+	fs := setupReferenceTestFiles().(*io.MockFileSystem)
+	story := "---\nfile_path: docs/user-stories/story1.md\n_content_hash: old-hash-1\n---\n\n# Story 1\n\nThis is story 1, now edited.\n"
+	fs.AddFile("docs/user-stories/story1.md", []byte(story))
+	newHash := CalculateContentHash(GetContentWithoutMetadata(story))
+
+	updated, unchanged, referencesUpdated, mismatches, err := UpdateReferencesForFiles(
+		[]string{"./docs/user-stories/story1.md", "docs/user-stories/deleted.md"}, "", fs)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"docs/changes-request/cr1.blueprint.md",
+		"docs/changes-request/cr2.blueprint.md",
+	}, updated)
+	assert.Equal(t, []string{"docs/changes-request/not-a-blueprint.md"}, unchanged)
+	assert.Equal(t, 2, referencesUpdated)
+	assert.Empty(t, mismatches)
+
+	content, err := fs.ReadFile("docs/changes-request/cr1.blueprint.md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "content-hash: "+newHash)
+	assert.Contains(t, string(content), "content-hash: old-hash-2", "story 2 was not reported as changed")
+}
+
+func TestUpdateReferencesForFiles_NothingReadable(t *testing.T) {
+	fs := setupReferenceTestFiles()
+
+	updated, _, referencesUpdated, _, err := UpdateReferencesForFiles([]string{"docs/user-stories/missing.md"}, "", fs)
+
+	assert.NoError(t, err)
+	assert.Empty(t, updated)
+	assert.Equal(t, 0, referencesUpdated)
+}
+
+func TestParseChangeRequest(t *testing.T) {
+	content := `---
+name: Login flow
+created-at: 2025-03-18T19:08:04Z
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: abc123
+  - title: Logout
+    file: docs/user-stories/02-logout.md
+    content-hash: def456
+---
+
+# Blueprint
+`
+
+	info, err := ParseChangeRequest(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Login flow", info.Name)
+	assert.Equal(t, time.Date(2025, 3, 18, 19, 8, 4, 0, time.UTC), info.CreatedAt)
+	assert.Empty(t, info.FilePath)
+	if assert.Len(t, info.References, 2) {
+		assert.Equal(t, "Login", info.References[0].Title)
+		assert.Equal(t, "docs/user-stories/01-login.md", info.References[0].FilePath)
+		assert.Equal(t, "abc123", info.References[0].ContentHash)
+		assert.Equal(t, "docs/user-stories/02-logout.md", info.References[1].FilePath)
+	}
+}
+
+func TestParseChangeRequest_NoMetadata(t *testing.T) {
+	_, err := ParseChangeRequest("# Blueprint\n")
+
+	assert.ErrorIs(t, err, ErrNoChangeRequestMetadata)
+}
+
+func TestExtractReferences_IrregularFormatting(t *testing.T) {
+	content := "## User Stories\n" +
+		"- title: Login\n" +
+		"  file: docs/user-stories/login.md\n" +
+		"  content-hash: hash1\n" +
+		"    -   title :   Logout  \n" +
+		"        file:docs/user-stories/logout.md   \n" +
+		"        content-hash :  hash2\t\n" +
+		"- title: Signup\r\n" +
+		"\tfile: docs/user-stories/signup.md\r\n" +
+		"\tcontent-hash: hash3\r\n" +
+		"- title: Profile\n" +
+		"  file: docs/user-stories/my profile.md\n" +
+		"  content-hash: hash4"
+
+	references := ExtractReferences(content)
+
+	assert.Equal(t, []Reference{
+		{Title: "Login", FilePath: "docs/user-stories/login.md", ContentHash: "hash1", Line: 2},
+		{Title: "Logout", FilePath: "docs/user-stories/logout.md", ContentHash: "hash2", Line: 5},
+		{Title: "Signup", FilePath: "docs/user-stories/signup.md", ContentHash: "hash3", Line: 8},
+		{Title: "Profile", FilePath: "docs/user-stories/my profile.md", ContentHash: "hash4", Line: 11},
+	}, references)
+}
+
+func TestUpdateChangeRequestReferences_IrregularFormatting(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	path := "docs/changes-request/reformatted.blueprint.md"
+	fs.AddFile(path, []byte("## User Stories\n"+
+		"    -   title :   Logout  \n"+
+		"        file:docs/user-stories/logout.md   \n"+
+		"        content-hash :  oldhash\t\n"+
+		"- title: Signup\r\n"+
+		"\tfile: docs/user-stories/signup.md\r\n"+
+		"\tcontent-hash: oldsignup"))
+
+	hashMap := ContentChangeMap{
+		"docs/user-stories/logout.md": {FilePath: "docs/user-stories/logout.md", OldHash: "oldhash", NewHash: "newhash", Changed: true},
+		"docs/user-stories/signup.md": {FilePath: "docs/user-stories/signup.md", OldHash: "oldsignup", NewHash: "newsignup", Changed: true},
+	}
+
+	updated, count, mismatches, err := UpdateChangeRequestReferences(path, hashMap, fs)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, 2, count)
+	assert.Empty(t, mismatches)
+
+	content, err := fs.ReadFile(path)
+	assert.NoError(t, err)
+	// Only the hashes change; the original formatting is kept
+	assert.Equal(t, "## User Stories\n"+
+		"    -   title :   Logout  \n"+
+		"        file:docs/user-stories/logout.md   \n"+
+		"        content-hash :  newhash\t\n"+
+		"- title: Signup\r\n"+
+		"\tfile: docs/user-stories/signup.md\r\n"+
+		"\tcontent-hash: newsignup", string(content))
+}
+
+func TestNormalizeReferencePath(t *testing.T) {
+	root := "/repo"
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "docs/user-stories/01-login.md", want: "docs/user-stories/01-login.md"},
+		{path: "./docs/user-stories/../user-stories/01-login.md", want: "docs/user-stories/01-login.md"},
+		{path: "/repo/docs/user-stories/01-login.md", want: "docs/user-stories/01-login.md"},
+		{path: "/elsewhere/docs/user-stories/01-login.md", wantErr: true},
+		{path: "../other/docs/user-stories/01-login.md", wantErr: true},
+		{path: "docs/../../01-login.md", wantErr: true},
+		{path: "..", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeReferencePath(tt.path, root)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, ErrReferenceOutsideRoot, tt.path)
+			continue
+		}
+		assert.NoError(t, err, tt.path)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+}
+
+func TestNormalizeChangeRequestReferencePaths(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	path := "/repo/docs/changes-request/login.blueprint.md"
+	fs.AddFile(path, []byte("## User Stories\n"+
+		"- title: Login\n"+
+		"  file: /repo/docs/user-stories/01-login.md\n"+
+		"  content-hash: hash1\n"+
+		"- title: Logout\n"+
+		"  file: docs/user-stories/02-logout.md\n"+
+		"  content-hash: hash2\n"+
+		"- title: Elsewhere\n"+
+		"  file: ../other/01-elsewhere.md\n"+
+		"  content-hash: hash3\n"))
+
+	count, err := NormalizeChangeRequestReferencePaths(path, "/repo", fs)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	content, err := fs.ReadFile(path)
+	assert.NoError(t, err)
+	// Paths outside the repository are left for the doctor to report
+	assert.Equal(t, "## User Stories\n"+
+		"- title: Login\n"+
+		"  file: docs/user-stories/01-login.md\n"+
+		"  content-hash: hash1\n"+
+		"- title: Logout\n"+
+		"  file: docs/user-stories/02-logout.md\n"+
+		"  content-hash: hash2\n"+
+		"- title: Elsewhere\n"+
+		"  file: ../other/01-elsewhere.md\n"+
+		"  content-hash: hash3\n", string(content))
+
+	// Already normalized files are not rewritten
+	count, err = NormalizeChangeRequestReferencePaths(path, "/repo", fs)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// ErrRenumberCollision is returned when a renumbered story would overwrite a
+// file that is not itself renumbered
+var ErrRenumberCollision = errors.New("renumbered story would overwrite an existing file")
+
+// numberedStory is a story file of a directory with a numeric prefix
+type numberedStory struct {
+	name   string
+	number int
+	rest   string // The file name after the numeric prefix, e.g. "-login.md"
+}
+
+// RenumberStories renumbers the numbered stories of dir (01-, 02-, ...) so
+// that their prefixes are contiguous from 01, keeping their order; stories
+// sharing a number are ordered by name. Renamed stories get their file_path
+// updated like ScaffoldUserStory, and every change request reference to them
+// is pointed at the new path. Nothing is renamed if a new name is taken by a
+// file that is not renumbered, or if a change request whose workflow is
+// locked references a renamed story, the error then wrapping
+// ErrChangeRequestLocked, or if safe mode refuses to write one of them. It
+// returns the renamed paths, old to new.
+func RenumberStories(dir string, fs io.FileSystem) (renames map[string]string, err error) {
+	return RenumberStoriesIn(".", dir, fs)
+}
+
+// RenumberStoriesIn is RenumberStories for the project at root: file_path
+// values and change request references are relative to root, and safe mode
+// checks the stories against it. Each story is written to a temporary file
+// with the mode of the original, then renamed into place.
+func RenumberStoriesIn(root, dir string, fs io.FileSystem) (renames map[string]string, err error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var stories []numberedStory
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		prefix := models.ExtractSequentialNumberFromFilename(entry.Name())
+		if prefix == "" {
+			continue
+		}
+		number, err := strconv.Atoi(prefix)
+		if err != nil {
+			continue
+		}
+		stories = append(stories, numberedStory{name: entry.Name(), number: number, rest: entry.Name()[len(prefix):]})
+	}
+	sort.SliceStable(stories, func(i, j int) bool {
+		if stories[i].number != stories[j].number {
+			return stories[i].number < stories[j].number
+		}
+		return stories[i].name < stories[j].name
+	})
+
+	renames = make(map[string]string)
+	for i, story := range stories {
+		if newName := fmt.Sprintf("%02d", i+1) + story.rest; newName != story.name {
+			renames[filepath.Join(dir, story.name)] = filepath.Join(dir, newName)
+		}
+	}
+	if len(renames) == 0 {
+		return renames, nil
+	}
+
+	// A new name may be the old name of another renamed story, but nothing else
+	for oldPath, newPath := range renames {
+		if _, renamed := renames[newPath]; !renamed && fs.Exists(newPath) {
+			return nil, fmt.Errorf("%w: %s", ErrRenumberCollision, newPath)
+		}
+		// The story is renamed and rewritten, so both paths must be writable
+		for _, path := range []string{oldPath, newPath} {
+			if err := checkWritePath(path, root, fs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	locked, err := lockedChangeRequestsReferencing(root, renames, fs)
+	if err != nil {
+		return nil, err
+	}
+	if len(locked) > 0 {
+		return nil, fmt.Errorf("%w: %s would need updating, unlock it first", ErrChangeRequestLocked, strings.Join(locked, ", "))
+	}
+
+	// Read every story before writing any, so that a story written over the
+	// old name of another one never loses the other's content
+	contents := make(map[string][]byte, len(renames))
+	modes := make(map[string]os.FileMode, len(renames))
+	for oldPath := range renames {
+		info, err := fs.Stat(oldPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file info for %s: %w", oldPath, err)
+		}
+		content, err := fs.ReadFile(oldPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", oldPath, err)
+		}
+		contents[oldPath], modes[oldPath] = content, info.Mode()
+	}
+	targets := make(map[string]bool, len(renames))
+	for oldPath, newPath := range renames {
+		tmpPath := filepath.Join(filepath.Dir(newPath), "."+filepath.Base(newPath)+".tmp")
+		if err := fs.WriteFile(tmpPath, contents[oldPath], modes[oldPath]); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", tmpPath, err)
+		}
+		if err := fs.Rename(tmpPath, newPath); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", newPath, err)
+		}
+		targets[newPath] = true
+	}
+	for oldPath := range renames {
+		if targets[oldPath] {
+			continue
+		}
+		if err := fs.Remove(oldPath); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", oldPath, err)
+		}
+	}
+
+	for _, newPath := range renames {
+		if _, _, err := UpdateFileMetadata(newPath, root, fs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := updateAllReferencePaths(root, renames, fs); err != nil {
+		return nil, err
+	}
+	return renames, nil
+}
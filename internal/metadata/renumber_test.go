@@ -0,0 +1,163 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestRenumberStories(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("docs/user-stories/02-logout.md", []byte("# Logout\n"))
+	fs.AddFile("docs/user-stories/02-reset.md", []byte("# Reset\n"))
+	fs.AddFile("docs/user-stories/05-profile.md", []byte("# Profile\n"))
+	fs.AddFile("docs/user-stories/notes.md", []byte("# Notes\n"))
+	fs.AddFile("docs/changes-request/cr.blueprint.md", []byte(`---
+name: cr
+---
+
+- title: Reset
+  file: docs/user-stories/02-reset.md
+  content-hash: abc
+- title: Profile
+  file: ./docs/user-stories/05-profile.md
+  content-hash: def
+- title: Login
+  file: docs/user-stories/01-login.md
+  content-hash: ghi
+`))
+
+	renames, err := RenumberStories("docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"docs/user-stories/02-reset.md":   "docs/user-stories/03-reset.md",
+		"docs/user-stories/05-profile.md": "docs/user-stories/04-profile.md",
+	}, renames)
+
+	assert.False(t, fs.Exists("docs/user-stories/05-profile.md"))
+	assert.True(t, fs.Exists("docs/user-stories/02-logout.md"))
+	assert.True(t, fs.Exists("docs/user-stories/notes.md"))
+
+	content, err := fs.ReadFile("docs/user-stories/03-reset.md")
+	require.NoError(t, err)
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/03-reset.md", meta.FilePath)
+	assert.Equal(t, "# Reset\n", GetContentWithoutMetadata(string(content)))
+
+	cr, err := fs.ReadFile("docs/changes-request/cr.blueprint.md")
+	require.NoError(t, err)
+	refs := ExtractReferences(string(cr))
+	require.Len(t, refs, 3)
+	assert.Equal(t, "docs/user-stories/03-reset.md", refs[0].FilePath)
+	assert.Equal(t, "abc", refs[0].ContentHash)
+	assert.Equal(t, "docs/user-stories/04-profile.md", refs[1].FilePath)
+	assert.Equal(t, "docs/user-stories/01-login.md", refs[2].FilePath)
+}
+
+func TestRenumberStoriesIn(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	require.NoError(t, fs.WriteFile("/project/docs/user-stories/01-login.md", []byte("# Login\n"), 0644))
+	require.NoError(t, fs.WriteFile("/project/docs/user-stories/03-reset.md", []byte("# Reset\n"), 0600))
+	fs.AddFile("/project/docs/changes-request/cr.blueprint.md", []byte("---\nname: cr\n---\n\n- title: Reset\n  file: docs/user-stories/03-reset.md\n  content-hash: abc\n"))
+
+	renames, err := RenumberStoriesIn("/project", "/project/docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"/project/docs/user-stories/03-reset.md": "/project/docs/user-stories/02-reset.md"}, renames)
+
+	// The mode of the story is kept and no temporary file is left behind
+	info, err := fs.Stat("/project/docs/user-stories/02-reset.md")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode())
+	assert.False(t, fs.Exists("/project/docs/user-stories/.02-reset.md.tmp"))
+
+	// Paths are relative to root
+	content, err := fs.ReadFile("/project/docs/user-stories/02-reset.md")
+	require.NoError(t, err)
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/02-reset.md", meta.FilePath)
+	cr, err := fs.ReadFile("/project/docs/changes-request/cr.blueprint.md")
+	require.NoError(t, err)
+	refs := ExtractReferences(string(cr))
+	require.Len(t, refs, 1)
+	assert.Equal(t, "docs/user-stories/02-reset.md", refs[0].FilePath)
+}
+
+func TestRenumberStories_SwapsNames(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/1-login.md", []byte("# Login\n"))
+	fs.AddFile("docs/user-stories/01-b.md", []byte("# B\n"))
+	fs.AddFile("docs/user-stories/01-a.md", []byte("# A\n"))
+
+	renames, err := RenumberStories("docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"docs/user-stories/01-b.md":    "docs/user-stories/02-b.md",
+		"docs/user-stories/1-login.md": "docs/user-stories/03-login.md",
+	}, renames)
+
+	content, err := fs.ReadFile("docs/user-stories/01-a.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# A\n", string(content))
+	assert.False(t, fs.Exists("docs/user-stories/1-login.md"))
+}
+
+func TestRenumberStories_AlreadyContiguous(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("docs/user-stories/02-logout.md", []byte("# Logout\n"))
+
+	renames, err := RenumberStories("docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Empty(t, renames)
+
+	content, err := fs.ReadFile("docs/user-stories/01-login.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Login\n", string(content))
+}
+
+func TestRenumberStories_LockedChangeRequest(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("docs/user-stories/03-reset.md", []byte("# Reset\n"))
+	cr := `---
+name: cr
+---
+
+- title: Reset
+  file: docs/user-stories/03-reset.md
+  content-hash: abc
+`
+	fs.AddFile("docs/changes-request/cr.blueprint.md", []byte(cr))
+	lockChangeRequest(fs, "docs/changes-request/cr.blueprint.md", true)
+
+	renames, err := RenumberStories("docs/user-stories", fs)
+	assert.ErrorIs(t, err, ErrChangeRequestLocked)
+	assert.Nil(t, renames)
+
+	// Nothing is renamed
+	assert.True(t, fs.Exists("docs/user-stories/03-reset.md"))
+	assert.False(t, fs.Exists("docs/user-stories/02-reset.md"))
+	content, err := fs.ReadFile("docs/changes-request/cr.blueprint.md")
+	require.NoError(t, err)
+	assert.Equal(t, cr, string(content))
+
+	// A locked change request referencing none of the renamed stories is fine
+	lockChangeRequest(fs, "docs/changes-request/cr.blueprint.md", false)
+	fs.AddFile("docs/changes-request/other.blueprint.md", []byte("---\nname: other\n---\n\n- title: Login\n  file: docs/user-stories/01-login.md\n  content-hash: def\n"))
+	lockChangeRequest(fs, "docs/changes-request/other.blueprint.md", true)
+
+	renames, err = RenumberStories("docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"docs/user-stories/03-reset.md": "docs/user-stories/02-reset.md"}, renames)
+}
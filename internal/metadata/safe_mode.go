@@ -0,0 +1,75 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// ErrWriteOutsideRoot is returned when safe mode stops a metadata or
+// reference update from writing a file outside the root directory
+var ErrWriteOutsideRoot = errors.New("refusing to write outside the root directory")
+
+// safeMode makes the metadata and reference updates check that the files
+// they write are inside the root directory or a configured docs directory
+var safeMode = true
+
+// SetSafeMode sets whether metadata and reference updates refuse to write
+// files outside the root directory. Safe mode is on by default.
+func SetSafeMode(enabled bool) {
+	safeMode = enabled
+}
+
+// checkWritePath returns an error wrapping ErrWriteOutsideRoot when safe mode
+// is on and path is neither inside root, the working directory when empty,
+// nor inside the user stories and change requests directories configured for
+// it, which may be absolute paths elsewhere. Symbolic links are resolved
+// first, so a story linked from outside these directories is refused too.
+func checkWritePath(path, root string, fs io.FileSystem) error {
+	if !safeMode {
+		return nil
+	}
+	if root == "" {
+		root = "."
+	}
+
+	target, err := realPath(path, fs)
+	if err != nil {
+		return fmt.Errorf("%w: cannot resolve %s: %v", ErrWriteOutsideRoot, path, err)
+	}
+
+	writeRoots := append([]string{root, config.UserStoriesDirIn(root)}, config.ChangesDirsIn(root)...)
+	for _, writeRoot := range writeRoots {
+		realRoot, err := realPath(writeRoot, fs)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(realRoot, target)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not inside %s", ErrWriteOutsideRoot, path, root)
+}
+
+// realPath returns the absolute path of path with its symbolic links
+// resolved. For a file that does not exist yet, the links of its directory
+// are resolved; a path that does not exist at all is only made absolute.
+func realPath(path string, fs io.FileSystem) (string, error) {
+	if resolved, err := fs.EvalSymlinks(path); err == nil {
+		return filepath.Abs(resolved)
+	}
+	if dir, err := fs.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Abs(filepath.Join(dir, filepath.Base(path)))
+	}
+	return filepath.Abs(path)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestCheckWritePath(t *testing.T) {
+	root := t.TempDir()
+	fs := io.NewOSFileSystem()
+
+	assert.NoError(t, checkWritePath(filepath.Join(root, "docs", "story.md"), root, fs))
+	assert.NoError(t, checkWritePath("docs/story.md", "", fs))
+
+	for _, path := range []string{
+		filepath.Join(root, "..", "outside.md"),
+		filepath.Join(filepath.Dir(root), "outside.md"),
+		root,
+	} {
+		assert.ErrorIs(t, checkWritePath(path, root, fs), ErrWriteOutsideRoot, path)
+	}
+	assert.ErrorIs(t, checkWritePath("../outside.md", "", fs), ErrWriteOutsideRoot)
+}
+
+func TestCheckWritePath_ConfiguredDirectories(t *testing.T) {
+	root := t.TempDir()
+	shared := t.TempDir()
+	fs := io.NewOSFileSystem()
+	story := filepath.Join(shared, "stories", "login.md")
+	changeRequest := filepath.Join(shared, "changes", "login.blueprint.md")
+
+	assert.ErrorIs(t, checkWritePath(story, root, fs), ErrWriteOutsideRoot)
+	assert.ErrorIs(t, checkWritePath(changeRequest, root, fs), ErrWriteOutsideRoot)
+
+	// Absolute docs directories outside root may be written
+	t.Setenv(config.UserStoriesDirEnv, filepath.Join(shared, "stories"))
+	t.Setenv(config.ChangesDirEnv, "docs/changes-request"+string(filepath.ListSeparator)+filepath.Join(shared, "changes"))
+	assert.NoError(t, checkWritePath(story, root, fs))
+	assert.NoError(t, checkWritePath(changeRequest, root, fs))
+	assert.ErrorIs(t, checkWritePath(filepath.Join(shared, "other.md"), root, fs), ErrWriteOutsideRoot)
+}
+
+func TestCheckWritePath_SymbolicLinks(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("project/docs/user-stories/01-login.md", []byte("# Login"))
+	fs.AddFile("elsewhere/02-linked.md", []byte("# Linked"))
+	fs.AddSymlink("project/docs/user-stories/02-linked.md", "../../../elsewhere/02-linked.md")
+	fs.AddSymlink("project/docs/user-stories/shared", "../../../elsewhere")
+
+	assert.NoError(t, checkWritePath("project/docs/user-stories/01-login.md", "project", fs))
+
+	// The links are inside root, but the files they lead to are not
+	assert.ErrorIs(t, checkWritePath("project/docs/user-stories/02-linked.md", "project", fs), ErrWriteOutsideRoot)
+	assert.ErrorIs(t, checkWritePath("project/docs/user-stories/shared/02-linked.md", "project", fs), ErrWriteOutsideRoot)
+	assert.ErrorIs(t, checkWritePath("project/docs/user-stories/shared/03-new.md", "project", fs), ErrWriteOutsideRoot)
+}
+
+func TestUpdateFileMetadata_RefusesFileOutsideRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "docs")
+	require.NoError(t, os.MkdirAll(root, 0755))
+	outside := filepath.Join(dir, "outside.md")
+	original := "# Outside\nNot a user story of root.\n"
+	require.NoError(t, os.WriteFile(outside, []byte(original), 0644))
+
+	fs := io.NewOSFileSystem()
+	updated, _, err := UpdateFileMetadata(filepath.Join(root, "..", "outside.md"), root, fs)
+	assert.ErrorIs(t, err, ErrWriteOutsideRoot)
+	assert.False(t, updated)
+
+	content, err := os.ReadFile(outside)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(content))
+
+	SetSafeMode(false)
+	defer SetSafeMode(true)
+	updated, _, err = UpdateFileMetadata(outside, root, fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+}
+
+func TestUpdateChangeRequestReferences_RefusesPathOutsideRoot(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	crPath := "../outside.blueprint.md"
+	original := `---
+name: Outside
+user-stories:
+  - title: Login
+    file: docs/user-stories/login.md
+    content-hash: oldhash
+---
+
+# Blueprint
+`
+	fs.AddFile(crPath, []byte(original))
+	hashMap := ContentChangeMap{
+		"docs/user-stories/login.md": {OldHash: "oldhash", NewHash: "newhash", Changed: true},
+	}
+
+	updated, _, _, err := UpdateChangeRequestReferences(crPath, hashMap, fs)
+	assert.ErrorIs(t, err, ErrWriteOutsideRoot)
+	assert.False(t, updated)
+
+	content, err := fs.ReadFile(crPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(content))
+}
+
+func TestUpdateReferencePaths_RefusesPathOutsideRoot(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	crPath := "../outside.blueprint.md"
+	original := "---\nname: Outside\nuser-stories:\n  - title: Login\n    file: docs/user-stories/02-login.md\n    content-hash: abc\n---\n"
+	fs.AddFile(crPath, []byte(original))
+
+	_, err := updateReferencePaths(crPath, map[string]string{"docs/user-stories/02-login.md": "docs/user-stories/01-login.md"}, ".", fs)
+	assert.ErrorIs(t, err, ErrWriteOutsideRoot)
+
+	content, err := fs.ReadFile(crPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(content))
+}
+
+func TestRenumberStories_RefusesLinkedStory(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("../elsewhere/03-linked.md", []byte("# Linked\n"))
+	fs.AddSymlink("docs/user-stories/03-linked.md", "../../../elsewhere/03-linked.md")
+
+	renames, err := RenumberStories("docs/user-stories", fs)
+	assert.ErrorIs(t, err, ErrWriteOutsideRoot)
+	assert.Nil(t, renames)
+	assert.True(t, fs.Exists("docs/user-stories/03-linked.md"))
+	assert.False(t, fs.Exists("docs/user-stories/02-linked.md"))
+}
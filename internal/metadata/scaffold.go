@@ -0,0 +1,112 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// userStorySkeleton is the body of a scaffolded user story, filled with its title
+const userStorySkeleton = `# %s
+
+As a <type of user>,
+I want <some goal>,
+so that <some reason>.
+
+## Acceptance criteria
+
+`
+
+// ScaffoldUserStory writes a new user story titled title in dir, creating
+// dir if needed. The file is numbered after the highest numbered story in
+// dir (01-, 02-, ...) and holds the "As a / I want / so that" skeleton and an
+// empty acceptance criteria section. Its front matter is written by
+// UpdateFileMetadata, with file_path relative to the current directory.
+// It returns the path of the new file.
+func ScaffoldUserStory(title, dir string, fs io.FileSystem) (string, error) {
+	title = strings.TrimSpace(title)
+	return writeNewUserStory(title, fmt.Sprintf(userStorySkeleton, title), dir, fs)
+}
+
+// FeatureRequestToUserStory writes a new user story in dir from a feature
+// request, numbered and given front matter like ScaffoldUserStory. The body
+// holds the request's title, description, user story statement and acceptance
+// criteria; the skeleton statement is used when the request has none.
+// It returns the path of the new file.
+func FeatureRequestToUserStory(fr models.FeatureRequest, dir string, fs io.FileSystem) (string, error) {
+	title := strings.TrimSpace(fr.Title)
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("# %s\n\n", title))
+	if description := strings.TrimSpace(fr.Description); description != "" {
+		body.WriteString(description + "\n\n")
+	}
+	if statement := strings.TrimSpace(fr.UserStory); statement != "" {
+		body.WriteString(statement + "\n\n")
+	} else {
+		body.WriteString("As a <type of user>,\nI want <some goal>,\nso that <some reason>.\n\n")
+	}
+	body.WriteString("## Acceptance criteria\n\n")
+	body.WriteString(models.FormatAcceptanceCriteria(fr.AcceptanceCriteria, models.CriteriaBulletStyle()))
+
+	return writeNewUserStory(title, body.String(), dir, fs)
+}
+
+// DuplicateStory copies the user story at srcPath as the next numbered story
+// of destDir, named after its title. The copy keeps the body and custom
+// front-matter fields such as tags, but gets a new file_path, fresh
+// created_at and last_updated timestamps and a recomputed content hash. An
+// implemented flag is not carried over. It returns the path of the new file.
+func DuplicateStory(srcPath, destDir string, fs io.FileSystem) (string, error) {
+	content, err := fs.ReadFile(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+
+	copied := string(content)
+	for _, key := range []string{"file_path", "created_at", "last_updated", "_content_hash", "implemented"} {
+		copied = RemoveCustomField(copied, key)
+	}
+
+	title := models.ExtractTitleFromContent(GetContentWithoutMetadata(copied))
+	return writeNewUserStory(title, copied, destDir, fs)
+}
+
+// writeNewUserStory writes content as the next numbered story of dir and
+// adds its front matter. It returns the path of the new file.
+func writeNewUserStory(title, content, dir string, fs io.FileSystem) (string, error) {
+	if models.SlugifyTitle(title) == "" {
+		return "", fmt.Errorf("a user story title needs at least one letter or digit: %q", title)
+	}
+
+	if err := fs.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	filename := models.GenerateFilename(models.GetNextSequentialNumber(entries), title)
+	path := filepath.Join(dir, filename)
+	if fs.Exists(path) {
+		return "", fmt.Errorf("file already exists: %s", path)
+	}
+
+	if err := fs.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, _, err := UpdateFileMetadata(path, ".", fs); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
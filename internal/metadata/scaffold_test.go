@@ -0,0 +1,170 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestScaffoldUserStory(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("docs/user-stories/03-logout.md", []byte("# Logout\n"))
+
+	path, err := ScaffoldUserStory("Reset password", "docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/04-reset-password.md", path)
+
+	content, err := fs.ReadFile(path)
+	require.NoError(t, err)
+
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/04-reset-password.md", meta.FilePath)
+	assert.False(t, meta.CreatedAt.IsZero())
+	body := GetContentWithoutMetadata(string(content))
+	assert.Equal(t, CalculateContentHash(body), meta.ContentHash)
+
+	assert.Contains(t, body, "# Reset password\n")
+	assert.Contains(t, body, "As a <type of user>,\nI want <some goal>,\nso that <some reason>.\n")
+	assert.Contains(t, body, "## Acceptance criteria\n")
+
+	story, err := models.LoadUserStoryFromFile(path, content)
+	require.NoError(t, err)
+	assert.Equal(t, "Reset password", story.Title)
+	assert.Equal(t, 0, story.AcceptanceCriteriaCount())
+}
+
+func TestScaffoldUserStory_NewDirectory(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	path, err := ScaffoldUserStory("  Login  ", "docs/user-stories/auth", fs)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/auth/01-login.md", path)
+	assert.True(t, fs.Exists(path))
+}
+
+func TestScaffoldUserStory_InvalidTitle(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	_, err := ScaffoldUserStory("???", "docs/user-stories", fs)
+	assert.Error(t, err)
+	assert.False(t, fs.Exists("docs/user-stories"))
+}
+
+func TestFeatureRequestToUserStory(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+
+	fr := models.FeatureRequest{
+		Title:              "Export user data to CSV",
+		Description:        "Users need their data in a spreadsheet.",
+		UserStory:          "As a user, I want to export my data to CSV, so that I can analyze it",
+		AcceptanceCriteria: []string{"A CSV file is downloaded", " ", "Dates use ISO 8601"},
+	}
+
+	path, err := FeatureRequestToUserStory(fr, "docs/user-stories", fs)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/02-export-user-data-to-csv.md", path)
+
+	content, err := fs.ReadFile(path)
+	require.NoError(t, err)
+
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, path, meta.FilePath)
+	body := GetContentWithoutMetadata(string(content))
+	assert.Equal(t, CalculateContentHash(body), meta.ContentHash)
+
+	story, err := models.LoadUserStoryFromFile(path, content)
+	require.NoError(t, err)
+	assert.Equal(t, "Export user data to CSV", story.Title)
+	assert.Contains(t, body, "Users need their data in a spreadsheet.\n")
+	assert.Empty(t, models.ValidateUserStory(story))
+	assert.Equal(t, []string{"A CSV file is downloaded", "Dates use ISO 8601"}, story.AcceptanceCriteria())
+}
+
+func TestFeatureRequestToUserStory_BulletStyle(t *testing.T) {
+	models.SetBulletStyle(models.BulletNumbered)
+	defer models.SetBulletStyle("")
+
+	fs := io.NewMockFileSystem()
+	fr := models.FeatureRequest{Title: "Dark mode", AcceptanceCriteria: []string{"Follows the OS theme", "Can be toggled"}}
+
+	path, err := FeatureRequestToUserStory(fr, "docs/user-stories", fs)
+	require.NoError(t, err)
+
+	content, err := fs.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1. Follows the OS theme\n2. Can be toggled\n")
+
+	story, err := models.LoadUserStoryFromFile(path, content)
+	require.NoError(t, err)
+	assert.Equal(t, fr.AcceptanceCriteria, story.AcceptanceCriteria())
+}
+
+func TestFeatureRequestToUserStory_MissingStatement(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	path, err := FeatureRequestToUserStory(models.FeatureRequest{Title: "Dark mode"}, "docs/user-stories", fs)
+	require.NoError(t, err)
+
+	content, err := fs.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "As a <type of user>,\nI want <some goal>,\nso that <some reason>.\n")
+
+	_, err = FeatureRequestToUserStory(models.FeatureRequest{}, "docs/user-stories", fs)
+	assert.Error(t, err)
+}
+
+func TestDuplicateStory(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	body := "# Login\n\nAs a user, I want to log in.\n\n## Acceptance criteria\n\n- Accepts valid credentials\n"
+	fs.AddFile("docs/user-stories/01-login.md", []byte("---\n"+
+		"file_path: docs/user-stories/01-login.md\n"+
+		"created_at: 2024-01-02T03:04:05Z\n"+
+		"last_updated: 2024-01-02T03:04:05Z\n"+
+		"_content_hash: stale\n"+
+		"implemented: true\n"+
+		"tags: [auth]\n"+
+		"---\n\n"+body))
+	fs.AddFile("docs/user-stories/copies/01-signup.md", []byte("# Signup\n"))
+
+	path, err := DuplicateStory("docs/user-stories/01-login.md", "docs/user-stories/copies", fs)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/user-stories/copies/02-login.md", path)
+
+	content, err := fs.ReadFile(path)
+	require.NoError(t, err)
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, path, meta.FilePath)
+	assert.Equal(t, CalculateContentHash(body), meta.ContentHash)
+	assert.True(t, meta.CreatedAt.After(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, meta.LastUpdated.After(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, body, GetContentWithoutMetadata(string(content)))
+
+	story, err := models.LoadUserStoryFromFile(path, content)
+	require.NoError(t, err)
+	assert.False(t, story.IsImplemented)
+	assert.Equal(t, []string{"auth"}, story.Tags)
+
+	// The source is left untouched
+	source, err := fs.ReadFile("docs/user-stories/01-login.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(source), "implemented: true\n")
+}
+
+func TestDuplicateStory_MissingSource(t *testing.T) {
+	_, err := DuplicateStory("docs/user-stories/01-missing.md", "docs/user-stories", io.NewMockFileSystem())
+	assert.Error(t, err)
+}
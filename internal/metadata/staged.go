@@ -0,0 +1,116 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"go.uber.org/zap"
+)
+
+// ErrNotGitRepository is returned when the staged files cannot be listed,
+// usually because root is not inside a git work tree
+var ErrNotGitRepository = errors.New("not a git repository")
+
+// listStagedFiles returns the files added, copied, modified or renamed in the
+// git index, relative to root and limited to the files under it. It is a
+// variable so that tests can run without git.
+var listStagedFiles = func(root string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--cached", "--name-only", "--relative", "--diff-filter=ACMR", "-z")
+	cmd.Dir = root
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotGitRepository, err)
+	}
+	return splitGitPaths(output), nil
+}
+
+// splitGitPaths splits the NUL-delimited paths printed by git -z
+func splitGitPaths(output []byte) []string {
+	var files []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, filepath.FromSlash(file))
+		}
+	}
+	return files
+}
+
+// UpdateStagedMetadata updates the metadata of the user stories staged in
+// the git index of root, e.g. from a pre-commit hook, then the change request
+// references to the stories whose content changed. Staged files outside the
+// user stories directory, in skipped directories or not markdown are ignored.
+// The updated files are not staged again. It returns an error wrapping
+// ErrNotGitRepository when git cannot list the staged files, so that callers
+// can fall back to UpdateAllUserStoryMetadata.
+// Returns:
+// - []string: list of updated user stories, relative to root
+// - ContentChangeMap: map of file paths to hash change information
+// - []string: list of updated change requests, relative to root
+// - error: any error that occurred
+func UpdateStagedMetadata(root string, fs io.FileSystem) ([]string, ContentChangeMap, []string, error) {
+	staged, err := listStagedFiles(root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	userStoriesDir := config.UserStoriesDirIn(root)
+	updatedFiles := []string{}
+	hashMap := make(ContentChangeMap)
+
+	for _, relPath := range staged {
+		file := filepath.Join(root, relPath)
+		if !isUserStoryFile(file, userStoriesDir) {
+			continue
+		}
+
+		updated, fileHashMap, err := UpdateFileMetadata(file, root, fs)
+		if err != nil {
+			return updatedFiles, hashMap, nil, err
+		}
+		if updated {
+			updatedFiles = append(updatedFiles, relPath)
+			hashMap[relPath] = fileHashMap
+		}
+	}
+
+	logger.Debug("Updated staged user story metadata",
+		zap.Int("staged", len(staged)),
+		zap.Int("updated", len(updatedFiles)))
+
+	updatedChangeRequests, _, _, _, err := UpdateAllChangeRequestReferences(root, hashMap, fs)
+	if err != nil {
+		return updatedFiles, hashMap, nil, fmt.Errorf("failed to update change request references: %w", err)
+	}
+
+	return updatedFiles, hashMap, updatedChangeRequests, nil
+}
+
+// isUserStoryFile reports whether file is a markdown file that
+// FindMarkdownFiles would find under userStoriesDir
+func isUserStoryFile(file, userStoriesDir string) bool {
+	if !strings.HasSuffix(strings.ToLower(file), ".md") {
+		return false
+	}
+
+	rel, err := filepath.Rel(userStoriesDir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	for _, dir := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+		if ShouldSkipDirectory(dir) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// stubStagedFiles makes listStagedFiles return files, or err, for the test
+func stubStagedFiles(t *testing.T, files []string, err error) {
+	original := listStagedFiles
+	listStagedFiles = func(root string) ([]string, error) {
+		return files, err
+	}
+	t.Cleanup(func() { listStagedFiles = original })
+}
+
+func TestUpdateStagedMetadata(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("/project/docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("/project/docs/user-stories/02-logout.md", []byte("# Logout\n"))
+	fs.AddFile("/project/docs/user-stories/node_modules/03-vendored.md", []byte("# Vendored\n"))
+	fs.AddFile("/project/README.md", []byte("# Readme\n"))
+	fs.AddFile("/project/docs/changes-request/cr.blueprint.md", []byte(`---
+name: cr
+user-stories:
+  - title: Login
+    file: docs/user-stories/01-login.md
+    content-hash: old-hash
+---
+`))
+	stubStagedFiles(t, []string{
+		filepath.FromSlash("docs/user-stories/01-login.md"),
+		filepath.FromSlash("docs/user-stories/node_modules/03-vendored.md"),
+		"README.md",
+		filepath.FromSlash("docs/user-stories/notes.txt"),
+	}, nil)
+
+	updated, hashMap, updatedRefs, err := UpdateStagedMetadata("/project", fs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/user-stories/01-login.md"}, updated)
+	assert.Contains(t, hashMap, "docs/user-stories/01-login.md")
+	assert.Equal(t, []string{"docs/changes-request/cr.blueprint.md"}, updatedRefs)
+
+	// Unstaged and ignored files are left alone
+	for _, path := range []string{"/project/docs/user-stories/02-logout.md", "/project/docs/user-stories/node_modules/03-vendored.md", "/project/README.md"} {
+		content, err := fs.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "_content_hash", path)
+	}
+
+	cr, err := fs.ReadFile("/project/docs/changes-request/cr.blueprint.md")
+	require.NoError(t, err)
+	refs := ExtractReferences(string(cr))
+	require.Len(t, refs, 1)
+	assert.Equal(t, hashMap["docs/user-stories/01-login.md"].NewHash, refs[0].ContentHash)
+}
+
+func TestUpdateStagedMetadata_NotGitRepository(t *testing.T) {
+	stubStagedFiles(t, nil, fmt.Errorf("%w: exit status 128", ErrNotGitRepository))
+
+	_, _, _, err := UpdateStagedMetadata("/project", io.NewMockFileSystem())
+	assert.ErrorIs(t, err, ErrNotGitRepository)
+}
+
+func TestListStagedFiles_NotGitRepository(t *testing.T) {
+	_, err := listStagedFiles(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotGitRepository)
+}
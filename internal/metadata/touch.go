@@ -0,0 +1,45 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// ErrNoStoryMetadata is returned by TouchStory for a story without a
+// metadata section
+var ErrNoStoryMetadata = errors.New("user story has no metadata section")
+
+// TouchStory sets the last_updated timestamp of a story to now, e.g. to
+// record that it was re-read and approved, and writes the file. Unlike a
+// metadata update it does not depend on the content changing; the content,
+// its hash and the other fields are left untouched. Stories without metadata
+// return ErrNoStoryMetadata, as they need a full metadata update first.
+func TouchStory(path string, fs io.FileSystem) error {
+	content, err := fs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if _, _, ok := models.SplitMetadata(string(content)); !ok {
+		return fmt.Errorf("%w: %s", ErrNoStoryMetadata, path)
+	}
+
+	touched := SetCustomField(string(content), "last_updated", models.FormatTimestamp(time.Now()))
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to get file info for %s: %w", path, err)
+	}
+	if err := fs.WriteFile(path, []byte(touched), info.Mode()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
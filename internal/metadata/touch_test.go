@@ -0,0 +1,52 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestTouchStory(t *testing.T) {
+	story := `---
+file_path: docs/user-stories/01-login.md
+created_at: 2025-03-17T12:00:00Z
+last_updated: 2025-03-18T08:30:00Z
+_content_hash: abc123
+priority: high
+---
+
+# Login
+`
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte(story))
+
+	before := time.Now().Add(-time.Second)
+	require.NoError(t, TouchStory("docs/user-stories/01-login.md", fs))
+
+	content, err := fs.ReadFile("docs/user-stories/01-login.md")
+	require.NoError(t, err)
+	meta, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.True(t, meta.LastUpdated.After(before), "last_updated should be now, got %s", meta.LastUpdated)
+
+	// Only the last_updated line changed
+	want := strings.Replace(story, "last_updated: 2025-03-18T08:30:00Z", "last_updated: "+meta.RawMetadata["last_updated"], 1)
+	assert.Equal(t, want, string(content))
+}
+
+func TestTouchStory_NoMetadata(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+
+	err := TouchStory("docs/user-stories/01-login.md", fs)
+	assert.ErrorIs(t, err, ErrNoStoryMetadata)
+}
@@ -16,6 +16,14 @@ type Metadata struct {
 	LastUpdated  time.Time `yaml:"last_updated"`
 	ContentHash  string    `yaml:"_content_hash"`
 	RawMetadata  map[string]string
+	CustomFields []CustomField // Fields not managed by usm, in their original order
+}
+
+// CustomField is a front-matter field added by an author, kept verbatim so
+// that multi-line values such as YAML lists survive metadata updates
+type CustomField struct {
+	Key string
+	Raw string // The full field text, including the key and any continuation lines
 }
 
 // ContentHashMap represents the changes in a file's content hash
@@ -29,6 +37,37 @@ type ContentHashMap struct {
 // ContentChangeMap maps file paths to their ContentHashMap
 type ContentChangeMap map[string]ContentHashMap
 
+// MetadataUpdateResult summarizes the metadata update of every user story.
+// Paths are relative to the root.
+type MetadataUpdateResult struct {
+	UpdatedFiles   []string
+	UnchangedFiles []string
+	HashMap        ContentChangeMap // Hash changes of the updated files
+	Failed         map[string]error // Files that could not be updated, when continuing on errors
+}
+
+// ReferenceUpdateResult summarizes the update of the references of every
+// change request
+type ReferenceUpdateResult struct {
+	UpdatedFiles      []string
+	UnchangedFiles    []string
+	ReferencesUpdated int            // Total over all change requests
+	ReferencesPerFile map[string]int // References updated in each updated change request
+	Mismatched        []MismatchedReference
+	Changes           []ReferenceChange // Every reference updated, in change request order
+	Locked            []string          // Locked change requests left with outdated references
+}
+
+// ReferenceChange describes the update of one user story reference in a
+// change request
+type ReferenceChange struct {
+	ChangeRequest string // Change request path, relative to the root
+	Title         string // Story title, as written in the reference
+	FilePath      string // Story path, as written in the reference
+	OldHash       string
+	NewHash       string
+}
+
 // MetadataOptions provides configuration options for metadata operations
 type MetadataOptions struct {
 	SkipReferences bool // Whether to skip updating references in change requests
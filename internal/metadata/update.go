@@ -6,8 +6,11 @@
 package metadata
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/user-story-matrix/usm/internal/io"
@@ -28,7 +31,9 @@ var SkippedDirectories = []string{
 	".github", // Added .github directory to skip
 }
 
-// UpdateFileMetadata updates the metadata section of a file
+// UpdateFileMetadata updates the metadata section of a file, writing it where
+// SetMetadataPlacement says; metadata found in the other place is moved. In
+// safe mode, a file outside root is not written; see SetSafeMode.
 // Returns:
 // - bool: whether the file was updated
 // - ContentHashMap: information about content hash changes
@@ -62,6 +67,9 @@ func UpdateFileMetadata(filePath, root string, fs io.FileSystem) (bool, ContentH
 
 	// Calculate content hash
 	contentWithoutMetadata := GetContentWithoutMetadata(string(content))
+	if metadataPlacement == Footer {
+		contentWithoutMetadata = footerBody(contentWithoutMetadata)
+	}
 	contentHash := CalculateContentHash(contentWithoutMetadata)
 	
 	logger.Debug("Calculated content hash", 
@@ -77,19 +85,26 @@ func UpdateFileMetadata(filePath, root string, fs io.FileSystem) (bool, ContentH
 	hashMap.Changed = existingMetadata.ContentHash != contentHash
 
 	// Generate new metadata
-	newMetadata := GenerateMetadata(filePath, root, fileInfo, existingMetadata, contentHash)
+	fields := generateManagedFields(filePath, root, fileInfo, existingMetadata, contentHash)
+	newMetadata := formatMetadataSection(fields, existingMetadata.CustomFields)
 	
 	logger.Debug("Generated new metadata", 
 		zap.String("file", filePath),
 		zap.String("metadata", newMetadata))
 
-	// Check if metadata has changed (to avoid unnecessary updates)
-	currentMetadataBytes := metadataRegex.Find(content)
-	
-	// FIXED CONDITION: A file needs updating if any of these conditions are true:
-	// 1. The file has no metadata section at all (len(currentMetadataBytes) == 0)
-	// 2. The existing metadata doesn't match the new metadata
-	needsUpdate := len(currentMetadataBytes) == 0 || string(currentMetadataBytes) != newMetadata
+	// Edit the values of an existing metadata section in place when possible,
+	// so that the diff only shows the values that changed
+	newContent, patched := patchManagedFields(string(content), fields)
+	if !patched {
+		newContent = newMetadata + contentWithoutMetadata
+		if metadataPlacement == Footer {
+			newContent = withMetadataFooter(contentWithoutMetadata, newMetadata)
+		}
+	}
+
+	// A file needs updating if it has no metadata section at all, if the
+	// existing metadata doesn't match the new metadata or if it has to move
+	needsUpdate := newContent != string(content)
 	
 	if !needsUpdate {
 		// No changes needed
@@ -100,12 +115,13 @@ func UpdateFileMetadata(filePath, root string, fs io.FileSystem) (bool, ContentH
 	}
 
 	// Update the file with new metadata
-	newContent := newMetadata + contentWithoutMetadata
-	
 	logger.Debug("Writing updated content", 
 		zap.String("file", filePath),
 		zap.Int("content_length", len(newContent)))
 	
+	if err := checkWritePath(filePath, root, fs); err != nil {
+		return false, hashMap, err
+	}
 	err = fs.WriteFile(filePath, []byte(newContent), fileInfo.Mode())
 	if err != nil {
 		return false, hashMap, fmt.Errorf("failed to write updated file %s: %w", filePath, err)
@@ -142,33 +158,148 @@ func ShouldSkipDirectory(dirName string) bool {
 	return false
 }
 
-// FindMarkdownFiles recursively finds all markdown files in a directory
+// maxScanDepth limits how deep FindMarkdownFiles descends, 0 for no limit
+var maxScanDepth = 0
+
+// SetMaxScanDepth limits the directory levels scanned by FindMarkdownFiles and
+// FindMarkdownFilesContext, 0 for no limit
+func SetMaxScanDepth(depth int) {
+	maxScanDepth = depth
+}
+
+// ParseMaxScanDepth parses a maximum scan depth, a non-negative number; an
+// empty value means no limit
+func ParseMaxScanDepth(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth < 0 {
+		return 0, fmt.Errorf("invalid maximum depth %q, use a non-negative number", value)
+	}
+	return depth, nil
+}
+
+// followSymlinks makes FindMarkdownFiles descend into symbolic links to
+// directories
+var followSymlinks = false
+
+// SetFollowSymlinks sets whether scans descend into symbolic links to
+// directories. They are not followed by default; symbolic links to markdown
+// files are always included.
+func SetFollowSymlinks(follow bool) {
+	followSymlinks = follow
+}
+
+// FindMarkdownFiles recursively finds all markdown files in a directory, down
+// to the depth set with SetMaxScanDepth
 func FindMarkdownFiles(dir string, fs io.FileSystem) ([]string, error) {
+	return FindMarkdownFilesContext(context.Background(), dir, fs)
+}
+
+// FindMarkdownFilesContext is like FindMarkdownFiles but stops scanning and
+// returns ctx.Err() as soon as the context is cancelled or its deadline passes
+func FindMarkdownFilesContext(ctx context.Context, dir string, fs io.FileSystem) ([]string, error) {
+	return FindMarkdownFilesMaxDepth(ctx, dir, fs, maxScanDepth)
+}
+
+// FindMarkdownFilesMaxDepth is like FindMarkdownFilesContext but only looks
+// maxDepth levels deep: 1 for the files of dir, 2 to include its
+// subdirectories, and so on. 0 means no limit.
+func FindMarkdownFilesMaxDepth(ctx context.Context, dir string, fs io.FileSystem, maxDepth int) ([]string, error) {
+	scan := &markdownScan{ctx: ctx, fs: fs, maxDepth: maxDepth}
+	if followSymlinks {
+		scan.visited = make(map[string]bool)
+		if realDir, err := fs.EvalSymlinks(dir); err == nil {
+			scan.visited[realDir] = true
+		}
+	}
+	return scan.find(dir, 1)
+}
+
+// markdownScan holds the settings and progress of a markdown file scan
+type markdownScan struct {
+	ctx      context.Context
+	fs       io.FileSystem
+	maxDepth int
+	// visited holds the real path of every directory scanned when following
+	// symbolic links, so that a link to an ancestor is not scanned forever.
+	// It is nil when links to directories are not followed.
+	visited map[string]bool
+}
+
+// find scans dir, at the given depth from the scan root (1 for the root
+// itself)
+func (s *markdownScan) find(dir string, depth int) ([]string, error) {
 	var files []string
 
+	if err := s.ctx.Err(); err != nil {
+		return files, err
+	}
+
 	// Check if the directory exists
-	if !fs.Exists(dir) {
+	if !s.fs.Exists(dir) {
 		return files, fmt.Errorf("directory not found: %s", dir)
 	}
 
-	entries, err := fs.ReadDir(dir)
+	entries, err := s.fs.ReadDir(dir)
 	if err != nil {
 		return files, fmt.Errorf("failed to read directory %s: %w", dir, err)
 	}
 
 	for _, entry := range entries {
+		if err := s.ctx.Err(); err != nil {
+			return files, err
+		}
+
 		path := filepath.Join(dir, entry.Name())
 
+		// Symbolic links to files are scanned like the files themselves,
+		// links to directories only when following them
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			info, err := s.fs.Stat(path)
+			if err != nil {
+				logger.Debug("Skipping broken symbolic link", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			if info.IsDir() && s.visited == nil {
+				logger.Debug("Skipping symbolic link to a directory", zap.String("dir", path))
+				continue
+			}
+			isDir = info.IsDir()
+		}
+
 		// Skip ignored directories
-		if entry.IsDir() {
+		if isDir {
 			base := filepath.Base(path)
 			if ShouldSkipDirectory(base) {
 				logger.Debug("Skipping directory", zap.String("dir", path))
 				continue
 			}
+			if s.maxDepth > 0 && depth >= s.maxDepth {
+				logger.Debug("Skipping directory beyond the maximum depth", zap.String("dir", path))
+				continue
+			}
+			if s.visited != nil {
+				realDir, err := s.fs.EvalSymlinks(path)
+				if err != nil {
+					logger.Warn("Error resolving directory", zap.String("dir", path), zap.Error(err))
+					continue
+				}
+				if s.visited[realDir] {
+					logger.Debug("Skipping directory already scanned", zap.String("dir", path), zap.String("real_path", realDir))
+					continue
+				}
+				s.visited[realDir] = true
+			}
 
 			// Recursively process subdirectories
-			subfiles, err := FindMarkdownFiles(path, fs)
+			subfiles, err := s.find(path, depth+1)
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				return files, ctxErr
+			}
 			if err != nil {
 				logger.Warn("Error scanning subdirectory", 
 					zap.String("dir", path), 
@@ -187,45 +318,107 @@ func FindMarkdownFiles(dir string, fs io.FileSystem) ([]string, error) {
 	return files, nil
 }
 
-// UpdateAllUserStoryMetadata updates metadata for all user story files
+// UpdateAllUserStoryMetadata updates metadata for all user story files.
+// Files whose modification time and size match the hash cache of root are
+// reported unchanged without being read; see HashCache.
 // Returns:
 // - []string: list of updated files
 // - []string: list of unchanged files
 // - ContentChangeMap: map of file paths to hash change information
 // - error: any error that occurred
 func UpdateAllUserStoryMetadata(userStoriesDir, root string, fs io.FileSystem) ([]string, []string, ContentChangeMap, error) {
+	return UpdateAllUserStoryMetadataContext(context.Background(), userStoriesDir, root, fs)
+}
+
+// UpdateAllUserStoryMetadataContext is like UpdateAllUserStoryMetadata but
+// checks ctx between files. On cancellation it returns ctx.Err() along with
+// the files processed so far; files already rewritten stay updated. A file
+// that cannot be updated is logged and skipped.
+func UpdateAllUserStoryMetadataContext(ctx context.Context, userStoriesDir, root string, fs io.FileSystem) ([]string, []string, ContentChangeMap, error) {
+	result, err := UpdateAllUserStoryMetadataDetailed(ctx, userStoriesDir, root, fs, true)
+	return result.UpdatedFiles, result.UnchangedFiles, result.HashMap, err
+}
+
+// UpdateAllUserStoryMetadataDetailed is UpdateAllUserStoryMetadataContext
+// returning a MetadataUpdateResult. When continueOnError is false it stops at
+// the first file that cannot be updated and returns its error; files processed
+// before stay updated and are returned with their hashes, so that the
+// references to them can still be updated. Otherwise it updates every other
+// file and records each failure in Failed.
+func UpdateAllUserStoryMetadataDetailed(ctx context.Context, userStoriesDir, root string, fs io.FileSystem, continueOnError bool) (MetadataUpdateResult, error) {
 	// Find all markdown files in the user stories directory
-	files, err := FindMarkdownFiles(userStoriesDir, fs)
+	files, err := FindMarkdownFilesContext(ctx, userStoriesDir, fs)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return MetadataUpdateResult{}, ctxErr
+	}
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to find markdown files: %w", err)
+		return MetadataUpdateResult{}, fmt.Errorf("failed to find markdown files: %w", err)
 	}
 
 	if len(files) == 0 {
 		logger.Warn("No markdown files found in directory", zap.String("dir", userStoriesDir))
-		return nil, nil, nil, nil
+		return MetadataUpdateResult{}, nil
 	}
 
 	updatedFiles := make([]string, 0, len(files))
 	unchangedFiles := make([]string, 0, len(files))
 	hashMap := make(ContentChangeMap)
+	failed := make(map[string]error)
 	errors := make([]string, 0) // Track any errors during processing
+	result := func() MetadataUpdateResult {
+		return MetadataUpdateResult{
+			UpdatedFiles:   updatedFiles,
+			UnchangedFiles: unchangedFiles,
+			HashMap:        hashMap,
+			Failed:         failed,
+		}
+	}
+	cache := LoadHashCache(root, fs)
+	seen := make([]string, 0, len(files))
+	defer func() {
+		if err := cache.Save(root, fs); err != nil {
+			logger.Warn("Failed to save hash cache", zap.Error(err))
+		}
+	}()
 
 	// Update metadata for each file
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			logger.Debug("User story metadata update cancelled",
+				zap.Int("processed", len(updatedFiles)+len(unchangedFiles)),
+				zap.Int("total", len(files)))
+			return result(), err
+		}
+
 		logger.Debug("Processing file", zap.String("file", file))
 
+		relPath, err := filepath.Rel(root, file)
+		if err != nil {
+			relPath = file // Use full path if relative path can't be determined
+		}
+		seen = append(seen, relPath)
+
+		// Skip reading and hashing files untouched since their metadata was last written
+		if info, err := fs.Stat(file); err == nil && cache.Fresh(relPath, info) {
+			logger.Debug("Unchanged since last run", zap.String("file", file))
+			unchangedFiles = append(unchangedFiles, relPath)
+			continue
+		}
+
 		updated, fileHashMap, err := UpdateFileMetadata(file, root, fs)
 		if err != nil {
+			if !continueOnError {
+				return result(), err
+			}
 			logger.Error("Failed to update metadata", 
 				zap.String("file", file), 
 				zap.Error(err))
 			errors = append(errors, fmt.Sprintf("%s: %s", file, err.Error()))
+			failed[relPath] = err
 			continue
 		}
-
-		relPath, err := filepath.Rel(root, file)
-		if err != nil {
-			relPath = file // Use full path if relative path can't be determined
+		if info, err := fs.Stat(file); err == nil {
+			cache.Store(relPath, info, fileHashMap.NewHash)
 		}
 
 		if updated {
@@ -236,6 +429,8 @@ func UpdateAllUserStoryMetadata(userStoriesDir, root string, fs io.FileSystem) (
 		}
 	}
 
+	cache.Retain(seen)
+
 	// If there were any errors, log a summary
 	if len(errors) > 0 {
 		logger.Warn("Some files could not be updated", 
@@ -257,5 +452,5 @@ func UpdateAllUserStoryMetadata(userStoriesDir, root string, fs io.FileSystem) (
 		zap.Int("unchanged", stats["unchanged"]),
 		zap.Int("errors", stats["errors"]))
 
-	return updatedFiles, unchangedFiles, hashMap, nil
+	return result(), nil
 } 
\ No newline at end of file
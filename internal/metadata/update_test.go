@@ -6,8 +6,11 @@
 package metadata
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -162,6 +165,64 @@ func (fs *WriteTrackingMockFileSystem) GetWrittenData(path string) []byte {
 	return fs.writtenData[path]
 }
 
+// TestUpdateFileMetadata_LastUpdatedPolicy verifies that last_updated follows the policy when only a custom field changes
+func TestUpdateFileMetadata_LastUpdatedPolicy(t *testing.T) {
+	defer SetLastUpdatedPolicy(OnlyOnContentChange)
+
+	body := "# Story\nThe body does not change.\n"
+	lastUpdated := "2022-06-20T15:45:00Z"
+	story := func(priority string) []byte {
+		return []byte("---\nfile_path: story.md\ncreated_at: 2022-06-01T10:00:00Z\nlast_updated: " + lastUpdated +
+			"\n_content_hash: " + CalculateContentHash(body) + "\npriority: " + priority + "\n---\n\n" + body)
+	}
+
+	// Only on content change: editing a custom field keeps last_updated
+	fs := io.NewMockFileSystem()
+	fs.AddFile("story.md", story("high"))
+	updated, _, err := UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// On any change: the first update records the fields without bumping
+	SetLastUpdatedPolicy(OnAnyChange)
+	updated, hashMap, err := UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, hashMap.Changed)
+	content, err := fs.ReadFile("story.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "last_updated: "+lastUpdated)
+	assert.Contains(t, string(content), "_fields_hash: ")
+	assert.Contains(t, string(content), "priority: high\n")
+
+	// Nothing changed since: the file is left alone
+	updated, _, err = UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// Editing the custom field bumps last_updated
+	fs.AddFile("story.md", []byte(strings.Replace(string(content), "priority: high", "priority: low", 1)))
+	updated, hashMap, err = UpdateFileMetadata("story.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, hashMap.Changed, "references only track the body")
+	content, err = fs.ReadFile("story.md")
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "last_updated: "+lastUpdated)
+	assert.Contains(t, string(content), "priority: low\n")
+}
+
+func TestParseLastUpdatedPolicy(t *testing.T) {
+	for value, want := range map[string]LastUpdatedPolicy{"": OnlyOnContentChange, "content": OnlyOnContentChange, " Any ": OnAnyChange} {
+		policy, err := ParseLastUpdatedPolicy(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, policy, value)
+	}
+
+	_, err := ParseLastUpdatedPolicy("always")
+	assert.Error(t, err)
+}
+
 // TestUpdateFileMetadata_AddsMetadataToNewFile verifies that metadata is added to a file without metadata
 func TestUpdateFileMetadata_AddsMetadataToNewFile(t *testing.T) {
 	// This test has been implemented as an integration test using a real filesystem
@@ -169,6 +230,28 @@ func TestUpdateFileMetadata_AddsMetadataToNewFile(t *testing.T) {
 	t.Skip("Implemented as an integration test with real filesystem in update_integration_test.go")
 }
 
+// TestUpdateFileMetadata_EmptyFrontMatter verifies that an empty front-matter
+// block is filled in rather than kept under a second block
+func TestUpdateFileMetadata_EmptyFrontMatter(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("test.md", []byte("---\n---\n# Test\n\n---\nAside\n---\n"))
+
+	updated, hashMap, err := UpdateFileMetadata("test.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, CalculateContentHash("# Test\n\n---\nAside\n---\n"), hashMap.NewHash)
+
+	content, err := fs.ReadFile("test.md")
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(content), "file_path:"))
+	assert.True(t, strings.HasSuffix(string(content), "---\n\n# Test\n\n---\nAside\n---\n"))
+
+	// The rewritten file is stable
+	updated, _, err = UpdateFileMetadata("test.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
+
 // TestFindMarkdownFiles_FindsAllMarkdownFiles verifies that FindMarkdownFiles finds all markdown files in a directory
 func TestFindMarkdownFiles_FindsAllMarkdownFiles(t *testing.T) {
 	fs := io.NewMockFileSystem()
@@ -228,6 +311,230 @@ func TestFindMarkdownFiles_SkipsIgnoredDirectories(t *testing.T) {
 	assert.NotContains(t, files, "docs/build/build.md")
 }
 
+// TestFindMarkdownFilesContext_Cancelled verifies that a cancelled scan returns the context error
+func TestFindMarkdownFilesContext_Cancelled(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddFile("docs/file.md", []byte("# File"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	files, err := FindMarkdownFilesContext(ctx, "docs", fs)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, files)
+
+	// The context-free variant still scans the whole tree
+	files, err = FindMarkdownFiles("docs", fs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/file.md"}, files)
+}
+
+// TestFindMarkdownFilesMaxDepth verifies that files below the maximum depth are excluded
+func TestFindMarkdownFilesMaxDepth(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{"top.md", "auth/login.md", "auth/sso/saml.md", "node_modules/pkg.md"} {
+		full := filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+		require.NoError(t, os.WriteFile(full, []byte("# Story"), 0644))
+	}
+	fs := io.NewOSFileSystem()
+
+	tests := []struct {
+		maxDepth int
+		expected []string
+	}{
+		{maxDepth: 0, expected: []string{"auth/login.md", "auth/sso/saml.md", "top.md"}},
+		{maxDepth: 1, expected: []string{"top.md"}},
+		{maxDepth: 2, expected: []string{"auth/login.md", "top.md"}},
+		{maxDepth: 3, expected: []string{"auth/login.md", "auth/sso/saml.md", "top.md"}},
+	}
+	for _, tt := range tests {
+		files, err := FindMarkdownFilesMaxDepth(context.Background(), dir, fs, tt.maxDepth)
+		require.NoError(t, err)
+
+		var relative []string
+		for _, file := range files {
+			rel, err := filepath.Rel(dir, file)
+			require.NoError(t, err)
+			relative = append(relative, filepath.ToSlash(rel))
+		}
+		sort.Strings(relative)
+		assert.Equal(t, tt.expected, relative, "max depth %d", tt.maxDepth)
+	}
+}
+
+// TestFindMarkdownFiles_UsesMaxScanDepth verifies that the package-wide limit applies to FindMarkdownFiles
+func TestFindMarkdownFiles_UsesMaxScanDepth(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "auth"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "top.md"), []byte("# Top"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "auth", "login.md"), []byte("# Login"), 0644))
+
+	SetMaxScanDepth(1)
+	defer SetMaxScanDepth(0)
+
+	files, err := FindMarkdownFiles(dir, io.NewOSFileSystem())
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "top.md")}, files)
+}
+
+// TestFindMarkdownFiles_Symlinks verifies that symlinked stories are found and symlinked directories skipped by default
+func TestFindMarkdownFiles_Symlinks(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("shared")
+	fs.AddFile("shared/billing.md", []byte("# Billing"))
+	fs.AddDirectory("docs")
+	fs.AddFile("docs/login.md", []byte("# Login"))
+	fs.AddSymlink("docs/billing.md", "../shared/billing.md")
+	fs.AddSymlink("docs/shared", "../shared")
+	fs.AddSymlink("docs/missing.md", "../shared/missing.md")
+
+	files, err := FindMarkdownFiles("docs", fs)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"docs/login.md", "docs/billing.md"}, files)
+
+	// Opting in follows the directory link
+	SetFollowSymlinks(true)
+	defer SetFollowSymlinks(false)
+
+	files, err = FindMarkdownFiles("docs", fs)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"docs/login.md", "docs/billing.md", "docs/shared/billing.md"}, files)
+}
+
+// TestFindMarkdownFiles_SymlinkCycle verifies that following a link to an ancestor terminates
+func TestFindMarkdownFiles_SymlinkCycle(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs")
+	fs.AddFile("docs/login.md", []byte("# Login"))
+	fs.AddSymlink("docs/loop", ".")
+
+	SetFollowSymlinks(true)
+	defer SetFollowSymlinks(false)
+
+	files, err := FindMarkdownFiles("docs", fs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/login.md"}, files)
+}
+
+// TestFindMarkdownFiles_SymlinkCycleOnDisk verifies cycle detection with real symbolic links
+func TestFindMarkdownFiles_SymlinkCycleOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "auth"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "auth", "login.md"), []byte("# Login"), 0644))
+	if err := os.Symlink("..", filepath.Join(dir, "auth", "up")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+
+	SetFollowSymlinks(true)
+	defer SetFollowSymlinks(false)
+
+	files, err := FindMarkdownFiles(dir, io.NewOSFileSystem())
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "auth", "login.md")}, files)
+}
+
+func TestParseMaxScanDepth(t *testing.T) {
+	depth, err := ParseMaxScanDepth("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, depth)
+
+	depth, err = ParseMaxScanDepth(" 3 ")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, depth)
+
+	_, err = ParseMaxScanDepth("-1")
+	assert.Error(t, err)
+	_, err = ParseMaxScanDepth("deep")
+	assert.Error(t, err)
+}
+
+// TestUpdateAllUserStoryMetadataContext_DeadlineExceeded verifies that no file is touched after the deadline
+func TestUpdateAllUserStoryMetadataContext_DeadlineExceeded(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddDirectory("docs/user-stories")
+	fs.AddFile("docs/user-stories/story1.md", []byte("# Story 1"))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	updated, unchanged, hashMap, err := UpdateAllUserStoryMetadataContext(ctx, "docs/user-stories", "", fs)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, updated)
+	assert.Empty(t, unchanged)
+	assert.Empty(t, hashMap)
+
+	content, err := fs.ReadFile("docs/user-stories/story1.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Story 1", string(content))
+}
+
+// unreadableFileSystem is a mock file system that fails to read one file
+type unreadableFileSystem struct {
+	*io.MockFileSystem
+	unreadable string
+}
+
+// ReadFile fails for the unreadable file
+func (fs *unreadableFileSystem) ReadFile(path string) ([]byte, error) {
+	if path == fs.unreadable {
+		return nil, fmt.Errorf("permission denied: %s", path)
+	}
+	return fs.MockFileSystem.ReadFile(path)
+}
+
+func newUnreadableFileSystem() *unreadableFileSystem {
+	fs := &unreadableFileSystem{MockFileSystem: io.NewMockFileSystem(), unreadable: "docs/user-stories/02-broken.md"}
+	fs.AddFile("docs/user-stories/01-first.md", []byte("# First"))
+	fs.AddFile("docs/user-stories/02-broken.md", []byte("# Broken"))
+	fs.AddFile("docs/user-stories/03-last.md", []byte("# Last"))
+	return fs
+}
+
+func TestUpdateAllUserStoryMetadataDetailed_ContinueOnError(t *testing.T) {
+	fs := newUnreadableFileSystem()
+
+	result, err := UpdateAllUserStoryMetadataDetailed(context.Background(), "docs/user-stories", ".", fs, true)
+	require.NoError(t, err)
+
+	// The other files are still updated
+	assert.ElementsMatch(t, []string{"docs/user-stories/01-first.md", "docs/user-stories/03-last.md"}, result.UpdatedFiles)
+	assert.Len(t, result.HashMap, 2)
+	for _, path := range result.UpdatedFiles {
+		content, err := fs.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "_content_hash: ")
+	}
+
+	// The failure is reported for the unreadable file only
+	require.Len(t, result.Failed, 1)
+	assert.ErrorContains(t, result.Failed["docs/user-stories/02-broken.md"], "permission denied")
+}
+
+func TestUpdateAllUserStoryMetadataDetailed_FailFast(t *testing.T) {
+	fs := newUnreadableFileSystem()
+
+	result, err := UpdateAllUserStoryMetadataDetailed(context.Background(), "docs/user-stories", ".", fs, false)
+	assert.ErrorContains(t, err, "permission denied")
+
+	// Files before the failure stay updated, files after it are untouched
+	assert.Equal(t, []string{"docs/user-stories/01-first.md"}, result.UpdatedFiles)
+	assert.Empty(t, result.Failed)
+	assert.Contains(t, result.HashMap, "docs/user-stories/01-first.md")
+	content, err := fs.ReadFile("docs/user-stories/03-last.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Last", string(content))
+}
+
+func TestUpdateAllUserStoryMetadata_SkipsFailedFiles(t *testing.T) {
+	fs := newUnreadableFileSystem()
+
+	updated, _, _, err := UpdateAllUserStoryMetadata("docs/user-stories", ".", fs)
+	require.NoError(t, err)
+	assert.Len(t, updated, 2)
+}
+
 // TestShouldSkipDirectory tests that the function correctly identifies directories to skip
 func TestShouldSkipDirectory(t *testing.T) {
 	// Test directories that should be skipped
@@ -384,4 +691,112 @@ func TestUpdateFileMetadata_SkipsUpdateForUnchangedContent(t *testing.T) {
 	// Check if any new write operations occurred
 	assert.Equal(t, initialWriteOps, len(fs.WriteOps), 
 		"No write operations should happen for unchanged content")
-} 
\ No newline at end of file
+}
+
+// TestUpdateFileMetadata_PreservesCustomFields verifies that author-defined fields survive a content change
+func TestUpdateFileMetadata_PreservesCustomFields(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	fs.AddFile("custom.md", []byte(`---
+priority: high
+file_path: custom.md
+created_at: 2022-05-15T10:30:00Z
+last_updated: 2022-05-16T10:30:00Z
+_content_hash: oldhash
+tags:
+  - auth
+  - login
+---
+
+# Custom
+This content changed since the last update.
+`))
+
+	updated, hashMap, err := UpdateFileMetadata("custom.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.True(t, hashMap.Changed)
+
+	content, err := fs.ReadFile("custom.md")
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "last_updated: 2022-05-16T10:30:00Z", "last_updated should be bumped")
+	assert.Contains(t, string(content), "---\npriority: high\nfile_path: custom.md\n")
+	assert.Contains(t, string(content), "_content_hash: "+hashMap.NewHash+"\ntags:\n  - auth\n  - login\n---\n")
+
+	// The custom fields are still extracted after the update
+	extracted, err := ExtractMetadata(string(content))
+	require.NoError(t, err)
+	assert.Equal(t, "high", extracted.RawMetadata["priority"])
+	assert.Equal(t, []CustomField{
+		{Key: "priority", Raw: "priority: high"},
+		{Key: "tags", Raw: "tags:\n  - auth\n  - login"},
+	}, extracted.CustomFields)
+
+	// A second run is stable
+	updated, _, err = UpdateFileMetadata("custom.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func TestUpdateFileMetadata_MinimalDiff(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	original := `---
+# Reviewed by the product team
+priority:   high
+file_path: minimal.md
+created_at: "2022-05-15T10:30:00Z"
+
+last_updated: '2022-05-16T10:30:00Z'
+_content_hash:   oldhash
+tags: [auth, login]
+---
+
+
+# Minimal
+This content changed since the last update.
+`
+	fs.AddFile("minimal.md", []byte(original))
+
+	updated, hashMap, err := UpdateFileMetadata("minimal.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	content, err := fs.ReadFile("minimal.md")
+	require.NoError(t, err)
+
+	// Only the last_updated and _content_hash lines differ
+	originalLines := strings.Split(original, "\n")
+	updatedLines := strings.Split(string(content), "\n")
+	require.Len(t, updatedLines, len(originalLines))
+	var changed []int
+	for i := range originalLines {
+		if originalLines[i] != updatedLines[i] {
+			changed = append(changed, i)
+		}
+	}
+	assert.Equal(t, []int{6, 7}, changed)
+	assert.Regexp(t, `^last_updated: '\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z'$`, updatedLines[6])
+	assert.Equal(t, "_content_hash:   "+hashMap.NewHash, updatedLines[7])
+
+	// A second run is stable
+	updated, _, err = UpdateFileMetadata("minimal.md", "", fs)
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func TestUpdateFileMetadata_RebuildsIncompleteMetadata(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	// Without a created_at field the section cannot be patched
+	fs.AddFile("partial.md", []byte("---\npriority: high\n_content_hash: oldhash\n---\n\n# Partial\n"))
+
+	updated, _, err := UpdateFileMetadata("partial.md", "", fs)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	content, err := fs.ReadFile("partial.md")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "---\nfile_path: partial.md\ncreated_at: "))
+	assert.Contains(t, string(content), "priority: high\n---\n\n# Partial\n")
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"go.uber.org/zap"
+)
+
+// WatchPollInterval is how often the watched tree is scanned for changes
+var WatchPollInterval = 500 * time.Millisecond
+
+// WatchDebounce is how long a file must stay unchanged before its metadata is
+// updated, so that a burst of saves results in a single update
+var WatchDebounce = 300 * time.Millisecond
+
+// fileStamp identifies a version of a file without reading it
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher tracks the markdown files below a directory between polls
+type watcher struct {
+	dir     string
+	root    string
+	fs      io.FileSystem
+	stamps  map[string]fileStamp
+	pending map[string]time.Time // Changed files and when the change was last seen
+}
+
+// WatchAndUpdate watches the user stories below dir and updates the metadata
+// of each markdown file shortly after it is saved, then updates the change
+// request references to the stories whose content changed. Directories are
+// skipped like in FindMarkdownFiles and dotfiles are ignored. Files rewritten
+// by the watcher itself are not treated as new changes. It blocks until stop
+// is closed and only returns an error if dir cannot be scanned at start.
+func WatchAndUpdate(dir, root string, fs io.FileSystem, stop <-chan struct{}) error {
+	w := newWatcher(dir, root, fs)
+	if err := w.snapshot(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(WatchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-ticker.C:
+			if _, err := w.poll(now); err != nil {
+				logger.Warn("Failed to scan user stories", zap.String("dir", dir), zap.Error(err))
+			}
+		}
+	}
+}
+
+// newWatcher creates a watcher with no known files
+func newWatcher(dir, root string, fs io.FileSystem) *watcher {
+	return &watcher{
+		dir:     dir,
+		root:    root,
+		fs:      fs,
+		stamps:  make(map[string]fileStamp),
+		pending: make(map[string]time.Time),
+	}
+}
+
+// snapshot records the current state of every watched file without updating them
+func (w *watcher) snapshot() error {
+	files, err := w.scan()
+	if err != nil {
+		return err
+	}
+	w.stamps = files
+	return nil
+}
+
+// poll detects the files changed since the previous poll and updates the ones
+// that have not changed again for WatchDebounce. It returns the files whose
+// metadata was rewritten.
+func (w *watcher) poll(now time.Time) ([]string, error) {
+	files, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	for path, stamp := range files {
+		if previous, ok := w.stamps[path]; !ok || previous != stamp {
+			w.pending[path] = now
+		}
+	}
+	for path := range w.pending {
+		if _, ok := files[path]; !ok {
+			delete(w.pending, path)
+		}
+	}
+	w.stamps = files
+
+	var ready []string
+	for path, changedAt := range w.pending {
+		if now.Sub(changedAt) >= WatchDebounce {
+			ready = append(ready, path)
+			delete(w.pending, path)
+		}
+	}
+	if len(ready) == 0 {
+		return nil, nil
+	}
+	sort.Strings(ready)
+
+	return w.update(ready), nil
+}
+
+// update rewrites the metadata of files and the references to the ones whose
+// content changed, remembering the resulting stamps so the watcher's own
+// writes are not picked up as changes
+func (w *watcher) update(files []string) []string {
+	var updated []string
+	hashMap := make(ContentChangeMap)
+
+	for _, file := range files {
+		changed, fileHashMap, err := UpdateFileMetadata(file, w.root, w.fs)
+		if err != nil {
+			logger.Warn("Failed to update metadata", zap.String("file", file), zap.Error(err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		updated = append(updated, file)
+		w.restamp(file)
+
+		relPath, err := filepath.Rel(w.root, file)
+		if err != nil {
+			relPath = file
+		}
+		hashMap[relPath] = fileHashMap
+	}
+
+	updatedRefs, _, _, _, err := UpdateAllChangeRequestReferences(w.root, hashMap, w.fs)
+	if err != nil {
+		logger.Warn("Failed to update change request references", zap.Error(err))
+	}
+	for _, ref := range updatedRefs {
+		w.restamp(filepath.Join(w.root, ref))
+	}
+
+	return updated
+}
+
+// restamp records the current stamp of a watched file after the watcher wrote it
+func (w *watcher) restamp(path string) {
+	if _, ok := w.stamps[path]; !ok {
+		return
+	}
+	info, err := w.fs.Stat(path)
+	if err != nil {
+		return
+	}
+	w.stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// scan returns the stamp of every watched markdown file
+func (w *watcher) scan() (map[string]fileStamp, error) {
+	files, err := FindMarkdownFiles(w.dir, w.fs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find markdown files: %w", err)
+	}
+
+	stamps := make(map[string]fileStamp, len(files))
+	for _, file := range files {
+		if strings.HasPrefix(filepath.Base(file), ".") {
+			continue
+		}
+		info, err := w.fs.Stat(file)
+		if err != nil {
+			continue
+		}
+		stamps[file] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestWatcher_Poll(t *testing.T) {
+	root := t.TempDir()
+	storiesDir := filepath.Join(root, "docs", "user-stories")
+	require.NoError(t, os.MkdirAll(storiesDir, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs", "changes-request"), 0755))
+
+	fs := io.NewOSFileSystem()
+	storyPath := filepath.Join(storiesDir, "login.md")
+	require.NoError(t, os.WriteFile(storyPath, []byte("# Login\n\nAs a user I want to log in\n"), 0644))
+	_, initial, err := UpdateFileMetadata(storyPath, root, fs)
+	require.NoError(t, err)
+
+	crPath := filepath.Join(root, "docs", "changes-request", "login.blueprint.md")
+	crContent := "---\nname: Login\n---\n\n## User Stories\n" +
+		"- title: Login\n  file: docs/user-stories/login.md\n  content-hash: " + initial.NewHash + "\n"
+	require.NoError(t, os.WriteFile(crPath, []byte(crContent), 0644))
+
+	w := newWatcher(storiesDir, root, fs)
+	require.NoError(t, w.snapshot())
+
+	// Nothing changed yet
+	updated, err := w.poll(time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+
+	content, err := os.ReadFile(storyPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(storyPath, append(content, []byte("\n- [ ] Criterion\n")...), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(storiesDir, ".login.md"), []byte("# Editor backup\n"), 0644))
+
+	// The change is seen but not acted upon until the debounce delay passes
+	changedAt := time.Now()
+	updated, err = w.poll(changedAt)
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+
+	updated, err = w.poll(changedAt.Add(WatchDebounce))
+	require.NoError(t, err)
+	assert.Equal(t, []string{storyPath}, updated)
+
+	content, err = os.ReadFile(storyPath)
+	require.NoError(t, err)
+	newHash := CalculateContentHash(GetContentWithoutMetadata(string(content)))
+	assert.NotEqual(t, initial.NewHash, newHash)
+
+	cr, err := os.ReadFile(crPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(cr), "content-hash: "+newHash)
+
+	// The watcher's own write is not picked up as a new change
+	updated, err = w.poll(changedAt.Add(2 * WatchDebounce))
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+	updated, err = w.poll(changedAt.Add(3 * WatchDebounce))
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+
+	backup, err := os.ReadFile(filepath.Join(storiesDir, ".login.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Editor backup\n", string(backup))
+}
+
+func TestWatchAndUpdate_Stop(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("/project/docs/user-stories/login.md", []byte("# Login\n"))
+
+	stop := make(chan struct{})
+	close(stop)
+
+	assert.NoError(t, WatchAndUpdate("/project/docs/user-stories", "/project", fs, stop))
+}
+
+func TestWatchAndUpdate_MissingDirectory(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	err := WatchAndUpdate("/project/docs/user-stories", "/project", fs, make(chan struct{}))
+	assert.Error(t, err)
+}
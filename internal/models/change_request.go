@@ -7,6 +7,7 @@ package models
 
 import (
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -50,7 +51,7 @@ Please provide a detailed implementation plan.
 	template = strings.ReplaceAll(template, "{{name}}", name)
 	
 	// Fill in the creation date
-	now := time.Now().Format(time.RFC3339)
+	now := FormatTimestamp(time.Now())
 	template = strings.ReplaceAll(template, "{{created_at}}", now)
 	
 	// Fill in user stories
@@ -83,6 +84,20 @@ func GenerateChangeRequestFilename(name string) string {
 	return fmt.Sprintf("%s-%s-%s.blueprint.md", date, timeStr, slug)
 }
 
+// WorkflowStateFilePath returns the path of the file holding the workflow
+// state of a change request: ".<file name>.step", next to it
+func WorkflowStateFilePath(changeRequestPath string) string {
+	dir := filepath.Dir(changeRequestPath)
+	base := filepath.Base(changeRequestPath)
+	return filepath.Join(dir, "."+base+".step")
+}
+
+// WorkflowLock is the part of a workflow state file recording whether the
+// workflow of the change request has been locked
+type WorkflowLock struct {
+	Locked bool `json:",omitempty"` // Set by LockWorkflow, the state then refuses changes
+}
+
 // LoadChangeRequestFromContent loads a change request from content
 func LoadChangeRequestFromContent(filePath string, content []byte) (ChangeRequest, error) {
 	cr := ChangeRequest{
@@ -104,15 +119,16 @@ func LoadChangeRequestFromContent(filePath string, content []byte) (ChangeReques
 	
 	// Parse creation date
 	if createdAt, ok := metadata["created-at"]; ok {
-		t, err := time.Parse(time.RFC3339, createdAt)
+		t, err := ParseTimestamp(createdAt)
 		if err == nil {
 			cr.CreatedAt = t
 		}
 	}
 	
 	// Parse user stories - this is more complex and would need YAML parsing
-	// For simplicity, we'll use a regex approach for now
-	userStoriesRegex := regexp.MustCompile(`(?m)^  - title: (.*)$\n^    file: (.*)$\n^    content-hash: (.*)$`)
+	// For simplicity, we'll use a regex approach for now, tolerating
+	// reformatted indentation and spacing around the colons
+	userStoriesRegex := regexp.MustCompile(`(?m)^[ \t]*-[ \t]*title[ \t]*:(.*)$\n^[ \t]*file[ \t]*:(.*)$\n^[ \t]*content-hash[ \t]*:(.*)$`)
 	matches := userStoriesRegex.FindAllStringSubmatch(contentStr, -1)
 	
 	for _, match := range matches {
@@ -121,9 +137,9 @@ func LoadChangeRequestFromContent(filePath string, content []byte) (ChangeReques
 		}
 		
 		cr.UserStories = append(cr.UserStories, UserStoryReference{
-			Title:       match[1],
-			FilePath:    match[2],
-			ContentHash: match[3],
+			Title:       strings.TrimSpace(match[1]),
+			FilePath:    strings.TrimSpace(match[2]),
+			ContentHash: strings.TrimSpace(match[3]),
 		})
 	}
 	
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BulletStyle is the list marker written before each acceptance criterion
+type BulletStyle string
+
+// Supported acceptance criteria markers
+const (
+	BulletDash     BulletStyle = "-"
+	BulletAsterisk BulletStyle = "*"
+	BulletNumbered BulletStyle = "1."
+)
+
+// criterionBulletRegex matches a "- ", "* " or "N. " list item, capturing its text
+var criterionBulletRegex = regexp.MustCompile(`^(?:[-*]|\d+\.)\s+(.*)$`)
+
+// bulletStyle is the marker used when writing acceptance criteria
+var bulletStyle = BulletDash
+
+// SetBulletStyle sets the marker used when writing acceptance criteria.
+// An empty style restores the "-" default.
+func SetBulletStyle(style BulletStyle) {
+	if style == "" {
+		style = BulletDash
+	}
+	bulletStyle = style
+}
+
+// CriteriaBulletStyle returns the marker used when writing acceptance criteria
+func CriteriaBulletStyle() BulletStyle {
+	return bulletStyle
+}
+
+// ParseBulletStyle parses a bullet style given as a marker ("-", "*", "1.")
+// or by name ("dash", "asterisk", "numbered"). An empty value is the "-"
+// default.
+func ParseBulletStyle(value string) (BulletStyle, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "-", "dash":
+		return BulletDash, nil
+	case "*", "asterisk":
+		return BulletAsterisk, nil
+	case "1.", "numbered":
+		return BulletNumbered, nil
+	default:
+		return "", fmt.Errorf("unknown bullet style %q, use -, * or 1.", value)
+	}
+}
+
+// FormatAcceptanceCriteria writes criteria as a list in the given style, one
+// per line. Blank criteria are skipped and numbered lists count from 1.
+func FormatAcceptanceCriteria(criteria []string, style BulletStyle) string {
+	var sb strings.Builder
+	n := 0
+	for _, criterion := range criteria {
+		if criterion = strings.TrimSpace(criterion); criterion == "" {
+			continue
+		}
+		n++
+		marker := string(style)
+		if style == BulletNumbered {
+			marker = fmt.Sprintf("%d.", n)
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", marker, criterion))
+	}
+	return sb.String()
+}
+
+// acceptanceCriteria returns the list items between the acceptance criteria
+// heading and the next heading, without their markers
+func acceptanceCriteria(body string) []string {
+	var criteria []string
+	inSection := false
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			inSection = acceptanceCriteriaHeadingRegex.MatchString(line)
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if match := criterionBulletRegex.FindStringSubmatch(line); match != nil {
+			criteria = append(criteria, strings.TrimSpace(match[1]))
+		}
+	}
+
+	return criteria
+}
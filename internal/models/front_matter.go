@@ -0,0 +1,136 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import "strings"
+
+// frontMatterFence is the line opening and closing a front-matter block
+const frontMatterFence = "---"
+
+// SplitFrontMatter splits markdown content into its front matter and body.
+//
+// The front matter is the text between a "---" line at the very start of the
+// content and the next "---" line, without the fences; trailing spaces and
+// carriage returns are allowed on the fence lines. The body starts after the
+// closing fence and the blank lines that follow it. An empty block ("---"
+// immediately followed by "---") is front matter with no fields.
+//
+// Content not starting with a fence, or whose opening fence is never closed,
+// has no front matter: it is returned whole as the body with hasFrontMatter
+// false.
+func SplitFrontMatter(content string) (frontMatter string, body string, hasFrontMatter bool) {
+	first, rest, ok := strings.Cut(content, "\n")
+	if !ok || !isFence(first) {
+		return "", content, false
+	}
+
+	// Find the closing fence line, tracking where it starts in rest
+	offset := 0
+	for {
+		line, next, more := strings.Cut(rest[offset:], "\n")
+		if isFence(line) {
+			frontMatter = strings.TrimSuffix(rest[:offset], "\n")
+			if !more {
+				return frontMatter, "", true
+			}
+			return frontMatter, trimLeadingBlankLines(next), true
+		}
+		if !more {
+			return "", content, false
+		}
+		offset += len(line) + 1
+	}
+}
+
+// isFence reports whether line is a front-matter fence
+func isFence(line string) bool {
+	return strings.TrimRight(line, " \t\r") == frontMatterFence
+}
+
+// trimLeadingBlankLines removes the whitespace-only lines at the start of s,
+// keeping the indentation of its first non-blank line
+func trimLeadingBlankLines(s string) string {
+	end := len(s) - len(strings.TrimLeft(s, " \t\r\n"))
+	if i := strings.LastIndexByte(s[:end], '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// MetadataFooterMarker is the line opening and closing a metadata block
+// written at the end of a file, for renderers that don't support front matter
+const MetadataFooterMarker = "<!-- usm:meta -->"
+
+// SplitFooterMetadata splits markdown content ending with a metadata footer
+// into the footer fields and the body.
+//
+// The footer is the text between the last two MetadataFooterMarker lines, the
+// closing one being the last non-blank line of the content. The body is the
+// content before the opening marker, without its trailing blank lines but
+// ending with a newline. Content without such a footer is returned whole as
+// the body with hasFooter false.
+func SplitFooterMetadata(content string) (metadata string, body string, hasFooter bool) {
+	bodyEnd, start, end, ok := FooterMetadataSpan(content)
+	if !ok {
+		return "", content, false
+	}
+	return content[start:end], trimTrailingBlankLines(content[:bodyEnd]), true
+}
+
+// FooterMetadataSpan returns the offsets of the metadata footer of content:
+// where the opening marker line starts, and where the fields between the
+// markers start and end
+func FooterMetadataSpan(content string) (footerStart, start, end int, ok bool) {
+	trimmed := strings.TrimRight(content, " \t\r\n")
+	closing := strings.LastIndexByte(trimmed, '\n') + 1
+	if closing == 0 || !isFooterMarker(trimmed[closing:]) {
+		return 0, 0, 0, false
+	}
+
+	// Walk back line by line to the opening marker
+	lineEnd := closing - 1
+	for lineEnd >= 0 {
+		lineStart := strings.LastIndexByte(content[:lineEnd], '\n') + 1
+		if isFooterMarker(content[lineStart:lineEnd]) {
+			start = lineEnd + 1
+			end = closing - 1
+			if end < start {
+				end = start // No fields between the markers
+			}
+			return lineStart, start, end, true
+		}
+		lineEnd = lineStart - 1
+	}
+	return 0, 0, 0, false
+}
+
+// SplitMetadata splits markdown content into its usm metadata and body,
+// reading the front matter, or the metadata footer when there is none. See
+// SplitFrontMatter and SplitFooterMetadata.
+func SplitMetadata(content string) (metadata string, body string, hasMetadata bool) {
+	if metadata, body, ok := SplitFrontMatter(content); ok {
+		return metadata, body, true
+	}
+	return SplitFooterMetadata(content)
+}
+
+// isFooterMarker reports whether line is a metadata footer marker
+func isFooterMarker(line string) bool {
+	return strings.TrimRight(line, " \t\r") == MetadataFooterMarker
+}
+
+// trimTrailingBlankLines removes the whitespace-only lines at the end of s,
+// keeping the newline ending its last non-blank line
+func trimTrailingBlankLines(s string) string {
+	end := len(strings.TrimRight(s, " \t\r\n"))
+	if end == 0 {
+		return ""
+	}
+	if i := strings.IndexByte(s[end:], '\n'); i >= 0 {
+		return s[:end+i+1]
+	}
+	return s
+}
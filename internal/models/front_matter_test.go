@@ -0,0 +1,157 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		frontMatter    string
+		body           string
+		hasFrontMatter bool
+	}{
+		{
+			name:           "front matter and body",
+			content:        "---\nfile_path: a.md\ntags: [x]\n---\n\n# Title\n",
+			frontMatter:    "file_path: a.md\ntags: [x]",
+			body:           "# Title\n",
+			hasFrontMatter: true,
+		},
+		{
+			name:    "no front matter",
+			content: "# Title\n\nSome text\n",
+			body:    "# Title\n\nSome text\n",
+		},
+		{
+			name:           "empty block",
+			content:        "---\n---\n# Title\n",
+			body:           "# Title\n",
+			hasFrontMatter: true,
+		},
+		{
+			name:    "unclosed fence",
+			content: "---\nfile_path: a.md\n# Title\n",
+			body:    "---\nfile_path: a.md\n# Title\n",
+		},
+		{
+			name:    "fence with trailing text",
+			content: "--- yaml\nfile_path: a.md\n---\n# Title\n",
+			body:    "--- yaml\nfile_path: a.md\n---\n# Title\n",
+		},
+		{
+			name:    "fence not at the start",
+			content: "# Title\n---\nfile_path: a.md\n---\nText\n",
+			body:    "# Title\n---\nfile_path: a.md\n---\nText\n",
+		},
+		{
+			name:           "rules in the body are kept",
+			content:        "---\nfile_path: a.md\n---\n# Title\n\n---\nAside\n---\n",
+			frontMatter:    "file_path: a.md",
+			body:           "# Title\n\n---\nAside\n---\n",
+			hasFrontMatter: true,
+		},
+		{
+			name:           "CRLF and trailing spaces on fences",
+			content:        "--- \r\nfile_path: a.md\r\n---\t\r\n\r\n# Title\r\n",
+			frontMatter:    "file_path: a.md\r",
+			body:           "# Title\r\n",
+			hasFrontMatter: true,
+		},
+		{
+			name:           "closing fence at end of file",
+			content:        "---\nfile_path: a.md\n---",
+			frontMatter:    "file_path: a.md",
+			hasFrontMatter: true,
+		},
+		{
+			name:           "indentation of the first body line is kept",
+			content:        "---\na: b\n---\n\n    code\n",
+			frontMatter:    "a: b",
+			body:           "    code\n",
+			hasFrontMatter: true,
+		},
+		{
+			name:    "single fence line",
+			content: "---",
+			body:    "---",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontMatter, body, ok := SplitFrontMatter(tt.content)
+			assert.Equal(t, tt.frontMatter, frontMatter)
+			assert.Equal(t, tt.body, body)
+			assert.Equal(t, tt.hasFrontMatter, ok)
+		})
+	}
+}
+
+func TestSplitFooterMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		metadata  string
+		body      string
+		hasFooter bool
+	}{
+		{
+			name:      "footer after the body",
+			content:   "# Title\n\nText\n\n<!-- usm:meta -->\nfile_path: a.md\ntags: [x]\n<!-- usm:meta -->\n",
+			metadata:  "file_path: a.md\ntags: [x]",
+			body:      "# Title\n\nText\n",
+			hasFooter: true,
+		},
+		{
+			name:      "empty footer and trailing blank lines",
+			content:   "# Title\n<!-- usm:meta -->\n<!-- usm:meta -->  \n\n",
+			body:      "# Title\n",
+			hasFooter: true,
+		},
+		{
+			name:    "no footer",
+			content: "# Title\n",
+			body:    "# Title\n",
+		},
+		{
+			name:    "single marker",
+			content: "# Title\n<!-- usm:meta -->\n",
+			body:    "# Title\n<!-- usm:meta -->\n",
+		},
+		{
+			name:    "text after the footer",
+			content: "<!-- usm:meta -->\na: b\n<!-- usm:meta -->\nMore\n",
+			body:    "<!-- usm:meta -->\na: b\n<!-- usm:meta -->\nMore\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, body, ok := SplitFooterMetadata(tt.content)
+			assert.Equal(t, tt.metadata, metadata)
+			assert.Equal(t, tt.body, body)
+			assert.Equal(t, tt.hasFooter, ok)
+		})
+	}
+}
+
+func TestSplitMetadata(t *testing.T) {
+	metadata, body, ok := SplitMetadata("---\na: b\n---\n# Title\n")
+	assert.True(t, ok)
+	assert.Equal(t, "a: b", metadata)
+	assert.Equal(t, "# Title\n", body)
+
+	metadata, body, ok = SplitMetadata("# Title\n\n<!-- usm:meta -->\na: c\n<!-- usm:meta -->\n")
+	assert.True(t, ok)
+	assert.Equal(t, "a: c", metadata)
+	assert.Equal(t, "# Title\n", body)
+}
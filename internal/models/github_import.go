@@ -0,0 +1,82 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// githubIssue holds the fields of a GitHub issues API object used by the import.
+// Every field is optional so that partial exports still decode.
+type githubIssue struct {
+	Number      int             `json:"number"`
+	Title       *string         `json:"title"`
+	Body        *string         `json:"body"`
+	CreatedAt   string          `json:"created_at"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+// ImportFeatureRequestsFromGitHub maps a JSON array of GitHub issues, as returned
+// by the issues API, into feature requests. The title becomes the Title, the
+// body the Description, and an "As a ... I want ... so that ..." statement in
+// the body the UserStory. Acceptance criteria listed under an
+// "Acceptance criteria" heading are imported too. Pull requests are skipped.
+//
+// Issues that cannot be decoded or have no title are skipped and reported in
+// the returned error, which joins one error per problem; the requests that
+// could be imported are returned alongside it.
+func ImportFeatureRequestsFromGitHub(data []byte) ([]FeatureRequest, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of GitHub issues: %w", err)
+	}
+
+	var requests []FeatureRequest
+	var problems []error
+	for i, item := range items {
+		var issue githubIssue
+		if err := json.Unmarshal(item, &issue); err != nil {
+			problems = append(problems, fmt.Errorf("issue at index %d: %w", i, err))
+			continue
+		}
+		if issue.PullRequest != nil && string(issue.PullRequest) != "null" {
+			continue
+		}
+
+		name := fmt.Sprintf("issue at index %d", i)
+		if issue.Number != 0 {
+			name = fmt.Sprintf("issue #%d", issue.Number)
+		}
+		if issue.Title == nil || strings.TrimSpace(*issue.Title) == "" {
+			problems = append(problems, fmt.Errorf("%s: missing title", name))
+			continue
+		}
+
+		fr := FeatureRequest{Title: strings.TrimSpace(*issue.Title)}
+		if issue.Body != nil {
+			body := strings.TrimSpace(strings.ReplaceAll(*issue.Body, "\r\n", "\n"))
+			fr.Description = body
+			fr.UserStory = userStoryStatement(body)
+			fr.AcceptanceCriteria = acceptanceCriteria(body)
+		}
+		if issue.CreatedAt != "" {
+			createdAt, err := time.Parse(time.RFC3339, issue.CreatedAt)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: invalid created_at %q", name, issue.CreatedAt))
+			} else {
+				fr.CreatedAt = createdAt
+			}
+		}
+
+		requests = append(requests, fr)
+	}
+
+	return requests, errors.Join(problems...)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportFeatureRequestsFromGitHub(t *testing.T) {
+	data := []byte(`[
+		{
+			"number": 12,
+			"title": "Export user data to CSV",
+			"body": "Users need their data in a spreadsheet.\r\n\r\nAs a user,\r\nI want to export my data to CSV,\r\nso that I can analyze it.\r\n\r\n## Acceptance criteria\r\n- A CSV file is downloaded\r\n- Dates use ISO 8601\r\n",
+			"created_at": "2025-03-17T12:00:00Z"
+		},
+		{"number": 13, "title": "Dark mode", "body": null},
+		{"number": 14, "title": "Fix typo", "pull_request": {"url": "https://api.github.com/repos/o/r/pulls/14"}}
+	]`)
+
+	requests, err := ImportFeatureRequestsFromGitHub(data)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	assert.Equal(t, "Export user data to CSV", requests[0].Title)
+	assert.Contains(t, requests[0].Description, "Users need their data in a spreadsheet.")
+	assert.Equal(t, "As a user, I want to export my data to CSV, so that I can analyze it.", requests[0].UserStory)
+	assert.Equal(t, []string{"A CSV file is downloaded", "Dates use ISO 8601"}, requests[0].AcceptanceCriteria)
+	assert.Equal(t, time.Date(2025, 3, 17, 12, 0, 0, 0, time.UTC), requests[0].CreatedAt)
+
+	assert.Equal(t, "Dark mode", requests[1].Title)
+	assert.Empty(t, requests[1].Description)
+	assert.Empty(t, requests[1].UserStory)
+}
+
+func TestImportFeatureRequestsFromGitHub_PartialResults(t *testing.T) {
+	data := []byte(`[
+		{"number": 1, "body": "No title here"},
+		"not an issue",
+		{"number": 3, "title": "Keep me", "created_at": "yesterday"},
+		{"title": "   "}
+	]`)
+
+	requests, err := ImportFeatureRequestsFromGitHub(data)
+	require.Error(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Keep me", requests[0].Title)
+	assert.True(t, requests[0].CreatedAt.IsZero())
+
+	assert.Contains(t, err.Error(), "issue #1: missing title")
+	assert.Contains(t, err.Error(), "issue at index 1")
+	assert.Contains(t, err.Error(), `issue #3: invalid created_at "yesterday"`)
+	assert.Contains(t, err.Error(), "issue at index 3: missing title")
+}
+
+func TestImportFeatureRequestsFromGitHub_NotAnArray(t *testing.T) {
+	requests, err := ImportFeatureRequestsFromGitHub([]byte(`{"message": "Not Found"}`))
+	assert.Error(t, err)
+	assert.Nil(t, requests)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import "strings"
+
+// Markers delimiting a region of a story left out of its content hash, e.g. a
+// table of contents regenerated by another tool
+const (
+	IgnoreRegionStart = "<!-- usm:ignore -->"
+	IgnoreRegionEnd   = "<!-- usm:endignore -->"
+)
+
+// StripIgnoredRegions empties the regions of content between an
+// IgnoreRegionStart marker and the next IgnoreRegionEnd marker, keeping the
+// markers, so editing inside a region leaves the result unchanged while
+// adding or removing a region does not. A start marker without an end marker
+// is kept as text, along with everything after it.
+func StripIgnoredRegions(content string) string {
+	if !strings.Contains(content, IgnoreRegionStart) {
+		return content
+	}
+
+	var sb strings.Builder
+	rest := content
+	for {
+		start := strings.Index(rest, IgnoreRegionStart)
+		if start < 0 {
+			break
+		}
+		start += len(IgnoreRegionStart)
+		end := strings.Index(rest[start:], IgnoreRegionEnd)
+		if end < 0 {
+			break
+		}
+		sb.WriteString(rest[:start])
+		rest = rest[start+end:]
+	}
+	sb.WriteString(rest)
+	return sb.String()
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripIgnoredRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no region", "# Title\n", "# Title\n"},
+		{"region emptied", "a\n<!-- usm:ignore -->\nx\n<!-- usm:endignore -->\nb\n", "a\n<!-- usm:ignore --><!-- usm:endignore -->\nb\n"},
+		{"several regions", "<!-- usm:ignore -->x<!-- usm:endignore -->-<!-- usm:ignore -->y<!-- usm:endignore -->", "<!-- usm:ignore --><!-- usm:endignore -->-<!-- usm:ignore --><!-- usm:endignore -->"},
+		{"unclosed region kept", "a\n<!-- usm:ignore -->\nx\n", "a\n<!-- usm:ignore -->\nx\n"},
+		{"stray end marker kept", "a<!-- usm:endignore -->b", "a<!-- usm:endignore -->b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StripIgnoredRegions(tt.content))
+		})
+	}
+}
+
+func TestGenerateContentHash_IgnoredRegions(t *testing.T) {
+	assert.Equal(t,
+		GenerateContentHash("a\n<!-- usm:ignore -->\nold\n<!-- usm:endignore -->\n"),
+		GenerateContentHash("a\n<!-- usm:ignore -->\nnew\n<!-- usm:endignore -->\n"))
+}
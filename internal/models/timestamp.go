@@ -0,0 +1,51 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"time"
+)
+
+// DateOnlyFormat writes timestamps as plain dates, e.g. 2025-03-18
+const DateOnlyFormat = time.DateOnly
+
+// timestampFormat is the layout used when writing created_at and last_updated
+var timestampFormat = time.RFC3339
+
+// SetTimestampFormat sets the time layout used when writing metadata
+// timestamps. An empty layout restores the RFC3339 default.
+func SetTimestampFormat(layout string) {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	timestampFormat = layout
+}
+
+// TimestampFormat returns the time layout used when writing metadata timestamps
+func TimestampFormat() string {
+	return timestampFormat
+}
+
+// FormatTimestamp formats t with the configured timestamp layout
+func FormatTimestamp(t time.Time) string {
+	return t.Format(timestampFormat)
+}
+
+// ParseTimestamp parses a metadata timestamp. RFC3339 and date-only values
+// are always accepted, whatever layout is configured for writing, so files
+// written with another setting still load.
+func ParseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse(timestampFormat, value)
+	if err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{time.RFC3339, DateOnlyFormat} {
+		if t, layoutErr := time.Parse(layout, value); layoutErr == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
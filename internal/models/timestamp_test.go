@@ -0,0 +1,55 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	defer SetTimestampFormat("")
+	ts := time.Date(2025, 3, 18, 19, 8, 4, 0, time.UTC)
+
+	assert.Equal(t, "2025-03-18T19:08:04Z", FormatTimestamp(ts))
+
+	SetTimestampFormat(DateOnlyFormat)
+	assert.Equal(t, "2025-03-18", FormatTimestamp(ts))
+
+	SetTimestampFormat("")
+	assert.Equal(t, time.RFC3339, TimestampFormat())
+}
+
+func TestParseTimestamp(t *testing.T) {
+	defer SetTimestampFormat("")
+
+	for _, layout := range []string{time.RFC3339, DateOnlyFormat} {
+		SetTimestampFormat(layout)
+
+		parsed, err := ParseTimestamp("2025-03-18T19:08:04Z")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 3, 18, 19, 8, 4, 0, time.UTC), parsed)
+
+		parsed, err = ParseTimestamp("2025-03-18")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 3, 18, 0, 0, 0, 0, time.UTC), parsed)
+
+		_, err = ParseTimestamp("yesterday")
+		assert.Error(t, err)
+	}
+}
+
+func TestLoadUserStoryFromFile_DateOnlyTimestamps(t *testing.T) {
+	content := "---\nfile_path: docs/user-stories/01-login.md\ncreated_at: 2025-03-18\nlast_updated: 2025-03-19\n---\n\n# Login\n"
+
+	us, err := LoadUserStoryFromFile("docs/user-stories/01-login.md", []byte(content))
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 18, 0, 0, 0, 0, time.UTC), us.CreatedAt)
+	assert.Equal(t, time.Date(2025, 3, 19, 0, 0, 0, 0, time.UTC), us.LastUpdated)
+}
@@ -12,8 +12,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // UserStory represents a user story document
@@ -29,8 +31,28 @@ type UserStory struct {
 	Criteria         []string  `json:"criteria"`
 	IsImplemented    bool      `json:"is_implemented"`
 	MatchScore       float64   `json:"match_score"`
+	Tags             []string  `json:"tags,omitempty"`
+
+	stats storyStats // Counts computed from Content, see bodyStats
+}
+
+// storyStats caches the counts computed from the body of a story, along with
+// the content they were computed from
+type storyStats struct {
+	computed bool
+	content  string
+	words    int
+	criteria int
 }
 
+var (
+	// tagsLineRegex matches the tags key in the front matter, capturing its inline value
+	tagsLineRegex = regexp.MustCompile(`^tags:\s*(.*)$`)
+
+	// acceptanceCriteriaHeadingRegex matches the heading opening the acceptance criteria section
+	acceptanceCriteriaHeadingRegex = regexp.MustCompile(`(?i)^#{2,}\s*acceptance criteria`)
+)
+
 // ExtractTitleFromContent extracts the title from the markdown content
 func ExtractTitleFromContent(content string) string {
 	lines := strings.Split(content, "\n")
@@ -47,20 +69,18 @@ func ExtractTitleFromContent(content string) string {
 func ExtractMetadataFromContent(content string) (map[string]string, error) {
 	metadata := make(map[string]string)
 	
-	// Looking for metadata section at the beginning of the file
+	// Looking for metadata section at the beginning of the file, or in a
+	// footer at its end
 	// Format:
 	// ---
 	// key: value
 	// ---
 	
-	metadataRegex := regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---\s*\n`)
-	matches := metadataRegex.FindStringSubmatch(content)
-	
-	if len(matches) < 2 {
+	metadataContent, _, ok := SplitMetadata(content)
+	if !ok {
 		return metadata, nil
 	}
 	
-	metadataContent := matches[1]
 	lines := strings.Split(metadataContent, "\n")
 	
 	for _, line := range lines {
@@ -82,10 +102,11 @@ func ExtractMetadataFromContent(content string) (map[string]string, error) {
 	return metadata, nil
 }
 
-// GenerateContentHash calculates the SHA-256 hash of the content
+// GenerateContentHash calculates the SHA-256 hash of the content, leaving out
+// its ignored regions (see StripIgnoredRegions)
 func GenerateContentHash(content string) string {
 	hash := sha256.New()
-	_, err := io.WriteString(hash, content)
+	_, err := io.WriteString(hash, StripIgnoredRegions(content))
 	if err != nil {
 		// In case of error, return an empty hash
 		// This should never happen with strings, but we handle it anyway
@@ -174,7 +195,7 @@ so that <some reason>.
 	template = strings.ReplaceAll(template, "{{title}}", title)
 
 	// Fill in the dates
-	now := time.Now().Format(time.RFC3339)
+	now := FormatTimestamp(time.Now())
 	template = strings.ReplaceAll(template, "{{created_at}}", now)
 	template = strings.ReplaceAll(template, "{{last_updated}}", now)
 
@@ -192,8 +213,9 @@ func GenerateUserStoryFilename(sequentialNumber, title string) string {
 }
 
 // LoadUserStoryFromFile loads a user story from file content
-// Note: The IsImplemented flag must be set separately using implementation.UpdateImplementationStatus
-// as it requires scanning all change requests to determine if the story is implemented
+// Note: Only an explicit "implemented" front-matter field sets IsImplemented here; use
+// implementation.UpdateImplementationStatus to also account for implemented change requests,
+// which requires scanning all of them
 func LoadUserStoryFromFile(filePath string, content []byte) (UserStory, error) {
 	us := UserStory{
 		FilePath: filePath,
@@ -219,7 +241,7 @@ func LoadUserStoryFromFile(filePath string, content []byte) (UserStory, error) {
 
 	// Parse creation date
 	if createdAt, ok := metadata["created_at"]; ok {
-		t, err := time.Parse(time.RFC3339, createdAt)
+		t, err := ParseTimestamp(createdAt)
 		if err == nil {
 			us.CreatedAt = t
 		}
@@ -227,12 +249,20 @@ func LoadUserStoryFromFile(filePath string, content []byte) (UserStory, error) {
 
 	// Parse last updated date
 	if lastUpdated, ok := metadata["last_updated"]; ok {
-		t, err := time.Parse(time.RFC3339, lastUpdated)
+		t, err := ParseTimestamp(lastUpdated)
 		if err == nil {
 			us.LastUpdated = t
 		}
 	}
 
+	// Parse tags, which may be written inline or as a block list
+	us.Tags = extractTags(contentStr)
+
+	// A story marked implemented by hand keeps the flag whatever its change requests say
+	if implemented, ok := metadata["implemented"]; ok {
+		us.IsImplemented, _ = strconv.ParseBool(implemented)
+	}
+
 	// Extract sequential number from filename
 	base := filepath.Base(filePath)
 	seqRegex := regexp.MustCompile(`^(\d+)-`)
@@ -265,4 +295,125 @@ func LoadUserStoryFromFile(filePath string, content []byte) (UserStory, error) {
 	}
 
 	return us, nil
-}
\ No newline at end of file
+}
+
+// ParseTags parses an inline tag list such as "[auth, security]" or "auth, security".
+// Surrounding quotes are removed and empty entries are dropped.
+func ParseTags(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.Trim(strings.TrimSpace(tag), `"'`)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// FormatTags renders tags as the inline list written in the front matter
+func FormatTags(tags []string) string {
+	return "[" + strings.Join(tags, ", ") + "]"
+}
+
+// HasTag reports whether the story is tagged with tag, ignoring case
+func (us *UserStory) HasTag(tag string) bool {
+	for _, t := range us.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractTags reads the tags field of the front matter, accepting both the
+// inline form (tags: [a, b]) and a block list of "- a" lines
+func extractTags(content string) []string {
+	frontMatter, _, ok := SplitMetadata(content)
+	if !ok {
+		return nil
+	}
+
+	var tags []string
+	inBlock := false
+	for _, line := range strings.Split(frontMatter, "\n") {
+		if match := tagsLineRegex.FindStringSubmatch(line); match != nil {
+			if strings.TrimSpace(match[1]) != "" {
+				return ParseTags(match[1])
+			}
+			inBlock = true
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "- ") {
+			break
+		}
+		if tag := strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "- ")), `"'`); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Body returns the content of the story without its metadata, as hashed into
+// the content hash. The whole content is returned when the story has no
+// metadata.
+func (us *UserStory) Body() string {
+	_, body, _ := SplitMetadata(us.Content)
+	return body
+}
+
+// WordCount returns the number of words in the story body, excluding the
+// front matter and markdown markers such as headings and bullets
+func (us *UserStory) WordCount() int {
+	return us.bodyStats().words
+}
+
+// AcceptanceCriteriaCount returns the number of bullet points listed under
+// the acceptance criteria heading of the story body
+func (us *UserStory) AcceptanceCriteriaCount() int {
+	return us.bodyStats().criteria
+}
+
+// AcceptanceCriteria returns the text of the bullet points listed under the
+// acceptance criteria heading of the story body, in order
+func (us *UserStory) AcceptanceCriteria() []string {
+	return acceptanceCriteria(us.Body())
+}
+
+// bodyStats returns the counts of the story body, computing them on first
+// use and again only once Content has changed
+func (us *UserStory) bodyStats() storyStats {
+	if !us.stats.computed || us.stats.content != us.Content {
+		body := us.Body()
+		us.stats = storyStats{
+			computed: true,
+			content:  us.Content,
+			words:    countWords(body),
+			criteria: len(acceptanceCriteria(body)),
+		}
+	}
+	return us.stats
+}
+
+// countWords counts the whitespace-separated tokens containing at least one letter or digit
+func countWords(body string) int {
+	count := 0
+	for _, field := range strings.Fields(body) {
+		if strings.IndexFunc(field, isWordRune) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// isWordRune reports whether r can be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const storyWithFrontMatter = `---
+file_path: docs/user-stories/01-login.md
+created_at: 2025-01-01T00:00:00Z
+_content_hash: abc
+---
+
+# Login
+
+As a user,
+I want to log in,
+so that I can see my data.
+
+## Acceptance criteria
+
+- Valid credentials log me in
+- Invalid credentials show an error
+* Locked accounts are rejected
+
+## Notes
+
+- Not a criterion
+`
+
+func TestUserStory_Body(t *testing.T) {
+	us := UserStory{Content: storyWithFrontMatter}
+	body := us.Body()
+	assert.True(t, strings.HasPrefix(body, "# Login\n"))
+	assert.NotContains(t, body, "_content_hash")
+	assert.True(t, strings.HasSuffix(storyWithFrontMatter, body))
+
+	// Without front matter the whole content is the body
+	plain := UserStory{Content: "# Title\n\n---\n\nText\n"}
+	assert.Equal(t, plain.Content, plain.Body())
+
+	empty := UserStory{}
+	assert.Equal(t, "", empty.Body())
+}
+
+func TestUserStory_WordCount(t *testing.T) {
+	us := UserStory{Content: storyWithFrontMatter}
+
+	// Front matter, headings markers and bullets are not counted
+	assert.Equal(t, 36, us.WordCount())
+}
+
+func TestUserStory_AcceptanceCriteriaCount(t *testing.T) {
+	us := UserStory{Content: storyWithFrontMatter}
+
+	assert.Equal(t, 3, us.AcceptanceCriteriaCount())
+}
+
+func TestUserStory_AcceptanceCriteria(t *testing.T) {
+	us := UserStory{Content: "# Title\n\n- Not a criterion\n\n## Acceptance criteria\n\n- One\n* Two  \n\n## Notes\n- Later\n"}
+
+	assert.Equal(t, []string{"One", "Two"}, us.AcceptanceCriteria())
+	empty := UserStory{}
+	assert.Empty(t, empty.AcceptanceCriteria())
+}
+
+func TestUserStory_CountsWithoutFrontMatter(t *testing.T) {
+	us := UserStory{Content: "# Title\n\n## Acceptance Criteria\n- One\n"}
+
+	assert.Equal(t, 4, us.WordCount())
+	assert.Equal(t, 1, us.AcceptanceCriteriaCount())
+}
+
+func TestUserStory_CountsFollowContent(t *testing.T) {
+	us := UserStory{Content: "# Title\n\n## Acceptance criteria\n- One\n"}
+	assert.Equal(t, 1, us.AcceptanceCriteriaCount())
+	assert.Equal(t, 4, us.WordCount())
+
+	// The counts are cached until the content changes
+	assert.True(t, us.stats.computed)
+	assert.Equal(t, us.Content, us.stats.content)
+
+	us.Content = ""
+	assert.Equal(t, 0, us.AcceptanceCriteriaCount())
+	assert.Equal(t, 0, us.WordCount())
+}
+
+func TestUserStory_EmptyContent(t *testing.T) {
+	us := UserStory{}
+
+	assert.Equal(t, 0, us.WordCount())
+	assert.Equal(t, 0, us.AcceptanceCriteriaCount())
+}
+
+func TestLoadUserStoryFromFile_Tags(t *testing.T) {
+	inline := "---\nfile_path: a.md\ntags: [auth, \"security\"]\n---\n\n# A\n"
+	us, err := LoadUserStoryFromFile("a.md", []byte(inline))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth", "security"}, us.Tags)
+
+	block := "---\nfile_path: b.md\ntags:\n  - auth\n  - login\npriority: high\n---\n\n# B\n"
+	us, err = LoadUserStoryFromFile("b.md", []byte(block))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth", "login"}, us.Tags)
+	assert.True(t, us.HasTag("LOGIN"))
+	assert.False(t, us.HasTag("priority"))
+
+	us, err = LoadUserStoryFromFile("c.md", []byte("# C\n\n- tags: no front matter\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, us.Tags)
+}
+
+func TestParseAndFormatTags(t *testing.T) {
+	assert.Equal(t, []string{"auth", "security"}, ParseTags("[auth, security]"))
+	assert.Equal(t, []string{"auth"}, ParseTags("auth, ,"))
+	assert.Empty(t, ParseTags("[]"))
+	assert.Equal(t, "[auth, security]", FormatTags([]string{"auth", "security"}))
+}
+
+func TestAcceptanceCriteria_RoundTripBulletStyles(t *testing.T) {
+	criteria := []string{"One", "Two", "Three"}
+
+	for _, style := range []BulletStyle{BulletDash, BulletAsterisk, BulletNumbered} {
+		t.Run(string(style), func(t *testing.T) {
+			list := FormatAcceptanceCriteria(criteria, style)
+			us := UserStory{Content: "# Title\n\n## Acceptance criteria\n\n" + list}
+
+			assert.Equal(t, criteria, us.AcceptanceCriteria())
+			assert.Equal(t, list, FormatAcceptanceCriteria(us.AcceptanceCriteria(), style))
+		})
+	}
+}
+
+func TestFormatAcceptanceCriteria(t *testing.T) {
+	criteria := []string{" One ", "", "Two"}
+
+	assert.Equal(t, "- One\n- Two\n", FormatAcceptanceCriteria(criteria, BulletDash))
+	assert.Equal(t, "* One\n* Two\n", FormatAcceptanceCriteria(criteria, BulletAsterisk))
+	assert.Equal(t, "1. One\n2. Two\n", FormatAcceptanceCriteria(criteria, BulletNumbered))
+}
+
+func TestParseBulletStyle(t *testing.T) {
+	for value, want := range map[string]BulletStyle{
+		"": BulletDash, "-": BulletDash, "Asterisk": BulletAsterisk, " * ": BulletAsterisk,
+		"1.": BulletNumbered, "numbered": BulletNumbered,
+	} {
+		style, err := ParseBulletStyle(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, style, value)
+	}
+
+	_, err := ParseBulletStyle("+")
+	assert.Error(t, err)
+}
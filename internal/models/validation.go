@@ -0,0 +1,123 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Problems reported by ValidateUserStory
+var (
+	ErrMissingUserStoryStatement = errors.New(`missing "As a ... I want ... so that ..." statement`)
+	ErrMissingRole               = errors.New(`missing the user type after "As a"`)
+	ErrMissingGoal               = errors.New(`missing "I want" clause`)
+	ErrMissingReason             = errors.New(`missing "so that" clause`)
+	ErrMissingAcceptanceCriteria = errors.New("no acceptance criteria listed")
+)
+
+// SplitUserStory splits a one-line "As a <role> I want <goal> so that <reason>"
+// statement into its parts. The "I want" and "so that" clauses are located
+// independently and case-insensitively, so a statement missing one of them
+// still yields the other. Missing parts are returned empty and trailing
+// commas are dropped, so both the feature form and hand-written stories parse.
+func SplitUserStory(statement string) (role, goal, reason string) {
+	statement = strings.TrimSpace(statement)
+	if statement == "" {
+		return "", "", ""
+	}
+
+	const wantClause, reasonClause = " i want ", " so that "
+	lower := strings.ToLower(statement)
+	wantIndex := strings.Index(lower, wantClause)
+	reasonIndex := strings.Index(lower, reasonClause)
+
+	// Each part ends where the next clause starts, or at the end
+	clauseEnd := func(start int) int {
+		end := len(statement)
+		for _, index := range []int{wantIndex, reasonIndex} {
+			if index >= start && index < end {
+				end = index
+			}
+		}
+		return end
+	}
+
+	role = trimClause(trimRolePrefix(statement[:clauseEnd(0)]))
+	if wantIndex >= 0 {
+		start := wantIndex + len(wantClause)
+		goal = trimClause(statement[start:clauseEnd(start)])
+	}
+	if reasonIndex >= 0 {
+		start := reasonIndex + len(reasonClause)
+		reason = trimClause(statement[start:clauseEnd(start)])
+	}
+	return role, goal, reason
+}
+
+// ValidateUserStory checks that the story body follows the
+// "As a / I want / so that" skeleton and lists at least one acceptance
+// criterion. It returns one error per problem found, or nil.
+func ValidateUserStory(us UserStory) []error {
+	var problems []error
+
+	statement := userStoryStatement(us.Body())
+	if statement == "" {
+		problems = append(problems, ErrMissingUserStoryStatement)
+	} else {
+		role, goal, reason := SplitUserStory(statement)
+		if role == "" {
+			problems = append(problems, ErrMissingRole)
+		}
+		if goal == "" {
+			problems = append(problems, ErrMissingGoal)
+		}
+		if reason == "" {
+			problems = append(problems, ErrMissingReason)
+		}
+	}
+
+	if us.AcceptanceCriteriaCount() == 0 {
+		problems = append(problems, ErrMissingAcceptanceCriteria)
+	}
+
+	return problems
+}
+
+// userStoryStatement returns the paragraph starting with "As a", joined on a
+// single line, or an empty string when the body has none
+func userStoryStatement(body string) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if len(lines) == 0 {
+			if lower := strings.ToLower(line); strings.HasPrefix(lower, "as a ") || strings.HasPrefix(lower, "as an ") {
+				lines = append(lines, line)
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
+
+// trimRolePrefix removes the leading "As a" or "As an", in any case
+func trimRolePrefix(s string) string {
+	for _, prefix := range []string{"as an ", "as a "} {
+		if strings.HasPrefix(strings.ToLower(s), prefix) {
+			return s[len(prefix):]
+		}
+	}
+	return s
+}
+
+// trimClause removes surrounding spaces and a trailing comma from a clause
+func trimClause(s string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), ","))
+}
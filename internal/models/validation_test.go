@@ -0,0 +1,81 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitUserStory(t *testing.T) {
+	tests := []struct {
+		statement                string
+		wantRole, wantGoal, want string
+	}{
+		{"As a developer I want to log in so that I can work", "developer", "to log in", "I can work"},
+		{"As an admin, I want reports, so that I can audit.", "admin", "reports", "I can audit."},
+		{"As a developer I want to log in", "developer", "to log in", ""},
+		{"As a developer", "developer", "", ""},
+		{"as a developer i want to log in SO THAT I can work", "developer", "to log in", "I can work"},
+		{"As a developer so that I can work", "developer", "", "I can work"},
+		{"As a developer so that I can work, I want to log in", "developer", "to log in", "I can work"},
+		{"", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		role, goal, reason := SplitUserStory(tt.statement)
+		assert.Equal(t, tt.wantRole, role, tt.statement)
+		assert.Equal(t, tt.wantGoal, goal, tt.statement)
+		assert.Equal(t, tt.want, reason, tt.statement)
+	}
+}
+
+func TestValidateUserStory_Valid(t *testing.T) {
+	us := UserStory{Content: GenerateUserStoryTemplate("Login", "docs/user-stories/01-login.md")}
+
+	assert.Empty(t, ValidateUserStory(us))
+}
+
+func TestValidateUserStory_Problems(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []error
+	}{
+		{
+			name:    "missing statement and criteria",
+			content: "# Login\n\nSome notes.\n",
+			want:    []error{ErrMissingUserStoryStatement, ErrMissingAcceptanceCriteria},
+		},
+		{
+			name:    "missing reason",
+			content: "# Login\n\nAs a user,\nI want to log in.\n\n## Acceptance criteria\n- Works\n",
+			want:    []error{ErrMissingReason},
+		},
+		{
+			name:    "missing goal",
+			content: "# Login\n\nAs a user so that I can work.\n\n## Acceptance criteria\n- Works\n",
+			want:    []error{ErrMissingGoal},
+		},
+		{
+			name:    "lowercase clauses",
+			content: "# Login\n\nas a user i want to log in so that i can work.\n\n## Acceptance criteria\n- Works\n",
+			want:    nil,
+		},
+		{
+			name:    "criteria outside the section",
+			content: "# Login\n\nAs a user I want to log in so that I can work.\n\n## Notes\n- Not a criterion\n",
+			want:    []error{ErrMissingAcceptanceCriteria},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateUserStory(UserStory{Content: tt.content}))
+		})
+	}
+}
@@ -6,6 +6,7 @@
 package search
 
 import (
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +15,77 @@ import (
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
+// DisplayMode controls how implemented stories appear in the results
+type DisplayMode int
+
+const (
+	// HideImplemented leaves implemented stories out of the results
+	HideImplemented DisplayMode = iota
+	// ShowImplemented lists implemented stories alongside the others
+	ShowImplemented
+	// DimImplemented lists implemented stories after all the others
+	DimImplemented
+)
+
+// Next returns the mode following m, cycling hide, show, dim
+func (m DisplayMode) Next() DisplayMode {
+	return (m + 1) % 3
+}
+
+// String returns a short label for the mode
+func (m DisplayMode) String() string {
+	switch m {
+	case ShowImplemented:
+		return "All"
+	case DimImplemented:
+		return "All (implemented last)"
+	default:
+		return "Unimplemented"
+	}
+}
+
 // FilterState represents the current state of filtering
 type FilterState struct {
 	SearchQuery    string
 	ShowAll       bool
+	Mode          DisplayMode
+	Sort          SortMode
 	FilteredCount int
 	TotalCount    int
+
+	// Parsed components of SearchQuery
+	Terms        string   // Additive search terms
+	Phrases      []string // Lowercased exact phrases, without their quotes
+	Exclusions   []string // Lowercased excluded terms, without their "!" or "-"
+	Tags         []string // Required tags
+	ExcludedTags []string // Excluded tags
+	Updated      []string // Values of the "updated:" filters, e.g. "<7d"
+}
+
+// ActiveFilters returns the components of the search query as short labels,
+// e.g. "auth", "\"user profile\"", "-legacy", "tag:security" or
+// "updated:<7d", in that order
+func (s FilterState) ActiveFilters() []string {
+	var filters []string
+	if s.Terms != "" {
+		filters = append(filters, s.Terms)
+	}
+	for _, phrase := range s.Phrases {
+		filters = append(filters, `"`+phrase+`"`)
+	}
+	for _, term := range s.Exclusions {
+		filters = append(filters, "-"+term)
+	}
+	for _, tag := range s.Tags {
+		filters = append(filters, tagPrefix+tag)
+	}
+	for _, tag := range s.ExcludedTags {
+		filters = append(filters, "-"+tagPrefix+tag)
+	}
+	for _, value := range s.Updated {
+		filters = append(filters, updatedPrefix+value)
+	}
+	return filters
 }
 
 // SearchCache represents the cache for search results
@@ -30,11 +96,29 @@ type SearchCache struct {
 	sync.RWMutex                          // For thread-safe access
 }
 
+// FieldWeights sets how much a match in each story field counts towards the
+// score of a story. A field with a weight of zero or less is not searched.
+type FieldWeights struct {
+	Title       float64
+	Description float64 // Also covers the acceptance criteria
+	Path        float64
+}
+
+// DefaultFieldWeights ranks title matches above description matches, and
+// those above path matches
+var DefaultFieldWeights = FieldWeights{Title: 3, Description: 2, Path: 1}
+
+// matchBase offsets fuzzy scores so that every match counts positively; the
+// fuzzy scores themselves go negative for matches that start late in a field
+const matchBase = 100
+
 // Engine represents the search engine for filtering user stories
 type Engine struct {
 	stories []models.UserStory
 	state   FilterState
 	cache   SearchCache
+	weights FieldWeights
+	now     func() time.Time // Reference time of relative "updated:" filters
 	mu      sync.RWMutex
 }
 
@@ -49,14 +133,44 @@ func NewEngine(stories []models.UserStory) *Engine {
 		state: FilterState{
 			TotalCount: len(stories),
 		},
+		weights: DefaultFieldWeights,
+		now:     time.Now,
 	}
 }
 
+// SetFieldWeights changes how much title, description and path matches count
+// when ranking search results
+func (e *Engine) SetFieldWeights(title, desc, path float64) {
+	e.mu.Lock()
+	e.weights = FieldWeights{Title: title, Description: desc, Path: path}
+	e.mu.Unlock()
+
+	// Cached results are ordered by the previous weights
+	e.ClearCache()
+}
+
 // SetShowAll updates the show all flag
 func (e *Engine) SetShowAll(showAll bool) {
+	if showAll {
+		e.SetDisplayMode(ShowImplemented)
+	} else {
+		e.SetDisplayMode(HideImplemented)
+	}
+}
+
+// SetDisplayMode sets how implemented stories appear in the results
+func (e *Engine) SetDisplayMode(mode DisplayMode) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.state.Mode = mode
+	e.state.ShowAll = mode != HideImplemented
+}
+
+// SetSortMode sets the order of the results
+func (e *Engine) SetSortMode(mode SortMode) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.state.ShowAll = showAll
+	e.state.Sort = mode
 }
 
 // Filter applies the current filters and returns matching stories
@@ -64,74 +178,379 @@ func (e *Engine) Filter(query string) []models.UserStory {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	// Split the query into additive terms, exclusion terms and filters
+	q := parseQuery(query)
+
 	// Update search query
 	e.state.SearchQuery = query
-
-	// Start with all stories
-	filtered := make([]models.UserStory, 0, len(e.stories))
-
-	// First filter by implementation status
-	for _, story := range e.stories {
-		if !e.state.ShowAll && story.IsImplemented {
-			continue
-		}
-		filtered = append(filtered, story)
-	}
+	e.state.Terms = q.terms
+	e.state.Phrases = q.phrases
+	e.state.Exclusions = q.exclusions
+	e.state.Tags = q.tags
+	e.state.ExcludedTags = q.excludedTags
+	e.state.Updated = q.updated
 
 	// If no search query, return all stories that match implementation status
 	if query == "" {
+		filtered := make([]models.UserStory, 0, len(e.stories))
+		for _, story := range e.stories {
+			if e.visible(story) {
+				filtered = append(filtered, story)
+			}
+		}
+		filtered = e.arrange(filtered)
 		e.state.FilteredCount = len(filtered)
 		return filtered
 	}
 
-	// Check cache for search results
+	// Check cache for search results; the indices refer to all stories, so
+	// they stay valid whatever the display mode. Results filtered on a
+	// relative age are not cached, as they change with the time.
 	if results, ok := e.cache.SearchResults[query]; ok {
 		// Return cached results
 		matchedStories := make([]models.UserStory, 0, len(results))
 		for _, idx := range results {
-			if idx < len(filtered) {
-				matchedStories = append(matchedStories, filtered[idx])
+			if idx < len(e.stories) && e.visible(e.stories[idx]) {
+				matchedStories = append(matchedStories, e.stories[idx])
 			}
 		}
+		matchedStories = e.arrange(matchedStories)
 		e.state.FilteredCount = len(matchedStories)
 		return matchedStories
 	}
 
-	// Prepare data for fuzzy search
-	searchStrings := make([]string, 0, len(filtered))
-	for _, story := range filtered {
-		// Combine searchable fields with weights
-		searchStr := strings.Join([]string{
-			story.Title,                    // Highest weight
-			story.Description,              // Medium weight
-			strings.Join(story.Criteria, " "), // Lower weight
-		}, " ")
-		searchStrings = append(searchStrings, searchStr)
+	// Drop the stories filtered out by tags or recency, missing an exact
+	// phrase or matching an exclusion term
+	now := e.now()
+	candidates := make([]int, 0, len(e.stories))
+	for i, story := range e.stories {
+		if !q.matchesTags(&story) || !q.matchesRecency(&story, now) {
+			continue
+		}
+		searchStr := strings.Join([]string{story.Title, descriptionText(story)}, " ")
+		if !containsPhrases(searchStr, q.phrases) || isExcluded(searchStr, q.exclusions) {
+			continue
+		}
+		candidates = append(candidates, i)
 	}
 
-	// Perform fuzzy search
-	matches := fuzzy.Find(query, searchStrings)
+	result := make([]models.UserStory, 0, len(candidates))
+	matchIndices := make([]int, 0, len(candidates))
 
-	// Sort stories by match score and update scores
-	result := make([]models.UserStory, 0, len(matches))
-	matchIndices := make([]int, 0, len(matches))
-	for _, match := range matches {
-		story := filtered[match.Index]
-		story.MatchScore = float64(match.Score) / 100.0
-		result = append(result, story)
-		matchIndices = append(matchIndices, match.Index)
+	if q.terms == "" {
+		// Phrase, exclusion and tag-only queries keep every story that wasn't
+		// filtered out
+		for _, idx := range candidates {
+			matchIndices = append(matchIndices, idx)
+			if e.visible(e.stories[idx]) {
+				result = append(result, e.stories[idx])
+			}
+		}
+	} else {
+		// Perform fuzzy search and sort stories by weighted match score
+		for _, match := range e.scoreCandidates(q.terms, candidates) {
+			matchIndices = append(matchIndices, match.index)
+			if !e.visible(e.stories[match.index]) {
+				continue
+			}
+			story := e.stories[match.index]
+			story.MatchScore = match.score / 100.0
+			result = append(result, story)
+		}
 	}
 
 	// Cache the results
-	e.cache.Lock()
-	e.cache.SearchResults[query] = matchIndices
-	e.cache.LastUpdated = time.Now()
-	e.cache.Unlock()
+	if !q.relativeRecency() {
+		e.cache.Lock()
+		e.cache.SearchResults[query] = matchIndices
+		e.cache.LastUpdated = time.Now()
+		e.cache.Unlock()
+	}
 
+	result = e.arrange(result)
 	e.state.FilteredCount = len(result)
 	return result
 }
 
+// scoredStory is a story index with its weighted match score
+type scoredStory struct {
+	index int
+	score float64
+}
+
+// scoreCandidates fuzzy matches terms against the title, description and path
+// of each candidate story. Every field that matches adds its weight times the
+// offset fuzzy score. Matching stories are returned best first, ties keeping
+// the original story order.
+func (e *Engine) scoreCandidates(terms string, candidates []int) []scoredStory {
+	scores := make(map[int]float64, len(candidates))
+
+	fields := []struct {
+		weight float64
+		text   func(models.UserStory) string
+	}{
+		{e.weights.Title, func(s models.UserStory) string { return s.Title }},
+		{e.weights.Description, descriptionText},
+		{e.weights.Path, func(s models.UserStory) string { return s.FilePath }},
+	}
+
+	for _, field := range fields {
+		if field.weight <= 0 {
+			continue
+		}
+		texts := make([]string, len(candidates))
+		for i, idx := range candidates {
+			texts[i] = field.text(e.stories[idx])
+		}
+		for _, match := range fuzzy.Find(terms, texts) {
+			score := float64(match.Score + matchBase)
+			if score < 1 {
+				score = 1
+			}
+			scores[candidates[match.Index]] += field.weight * score
+		}
+	}
+
+	// Queries spanning several fields still match the title and description
+	// together, with a score below one
+	var combined []string
+	var unmatched []int
+	for _, idx := range candidates {
+		if _, ok := scores[idx]; !ok {
+			story := e.stories[idx]
+			combined = append(combined, strings.Join([]string{story.Title, descriptionText(story)}, " "))
+			unmatched = append(unmatched, idx)
+		}
+	}
+	for _, match := range fuzzy.Find(terms, combined) {
+		scores[unmatched[match.Index]] = crossFieldScore(match.Score)
+	}
+
+	matches := make([]scoredStory, 0, len(scores))
+	for _, idx := range candidates {
+		if score, ok := scores[idx]; ok {
+			matches = append(matches, scoredStory{index: idx, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	return matches
+}
+
+// crossFieldScore maps the fuzzy score of a match spanning several fields into
+// (0, 1), below the lowest score of a single-field match
+func crossFieldScore(score int) float64 {
+	offset := float64(score + matchBase)
+	if offset < 1 {
+		offset = 1
+	}
+	if offset > 2*matchBase {
+		offset = 2 * matchBase
+	}
+	return offset / (2*matchBase + 1)
+}
+
+// descriptionText returns the description of a story followed by its criteria
+func descriptionText(story models.UserStory) string {
+	return strings.Join([]string{story.Description, strings.Join(story.Criteria, " ")}, " ")
+}
+
+// visible reports whether the display mode keeps the story in the results
+func (e *Engine) visible(story models.UserStory) bool {
+	return e.state.Mode != HideImplemented || !story.IsImplemented
+}
+
+// arrange puts the most recently updated stories first in SortRecent mode,
+// then moves implemented stories after the others in DimImplemented mode,
+// keeping the relative order of each group
+func (e *Engine) arrange(stories []models.UserStory) []models.UserStory {
+	if e.state.Sort == SortRecent {
+		sortByRecency(stories)
+	}
+	if e.state.Mode == DimImplemented {
+		sort.SliceStable(stories, func(i, j int) bool {
+			return !stories[i].IsImplemented && stories[j].IsImplemented
+		})
+	}
+	return stories
+}
+
+// tagPrefix introduces a tag filter token in a query, e.g. "tag:security"
+const tagPrefix = "tag:"
+
+// parsedQuery holds the parts of a search query
+type parsedQuery struct {
+	terms        string   // Additive terms used for fuzzy matching
+	phrases      []string // Lowercased phrases a story must contain as is
+	exclusions   []string // Lowercased terms that drop matching stories
+	tags         []string // Tags a story must all carry
+	excludedTags []string // Tags a story must not carry
+	recency      []recencyFilter
+	updated      []string // Values of the recency filters, as typed
+}
+
+// parseQuery splits a query into the additive search terms, the exclusion
+// terms, which start with "!" or "-", the "tag:" filters and the "updated:"
+// filters described in parseRecencyFilter. Exclusions also apply to tags, so
+// "-tag:legacy" drops stories tagged legacy; use the opposite comparison
+// rather than excluding an "updated:" filter. A bare "!", "-" or "tag:" and an
+// incomplete or excluded "updated:" filter are ignored. Text between double
+// quotes is an exact phrase, see splitPhrases.
+func parseQuery(query string) parsedQuery {
+	var q parsedQuery
+	var terms []string
+
+	rest, phrases, excludedPhrases := splitPhrases(query)
+	q.phrases = phrases
+	q.exclusions = excludedPhrases
+
+	for _, field := range strings.Fields(rest) {
+		excluded := strings.HasPrefix(field, "!") || strings.HasPrefix(field, "-")
+		if excluded {
+			field = strings.TrimLeft(field, "!-")
+			if field == "" {
+				continue
+			}
+		}
+
+		if len(field) >= len(tagPrefix) && strings.EqualFold(field[:len(tagPrefix)], tagPrefix) {
+			if tag := field[len(tagPrefix):]; tag != "" {
+				if excluded {
+					q.excludedTags = append(q.excludedTags, tag)
+				} else {
+					q.tags = append(q.tags, tag)
+				}
+			}
+			continue
+		}
+
+		if len(field) >= len(updatedPrefix) && strings.EqualFold(field[:len(updatedPrefix)], updatedPrefix) {
+			if filter, ok := parseRecencyFilter(field[len(updatedPrefix):]); ok && !excluded {
+				q.recency = append(q.recency, filter)
+				q.updated = append(q.updated, field[len(updatedPrefix):])
+			}
+			continue
+		}
+
+		if excluded {
+			q.exclusions = append(q.exclusions, strings.ToLower(field))
+			continue
+		}
+		terms = append(terms, field)
+	}
+
+	q.terms = strings.Join(terms, " ")
+	return q
+}
+
+// splitPhrases extracts the phrases between pairs of double quotes from a
+// query, lowercased and with their inner spacing kept, and returns the rest
+// of the query. A phrase whose opening quote follows a "!" or "-" starting a
+// word is an excluded phrase instead. Empty phrases are dropped, and a
+// dangling quote without its closing quote is left in the rest as a literal.
+func splitPhrases(query string) (rest string, phrases, excluded []string) {
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(query, '"')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(query[open+1:], '"')
+		if end < 0 {
+			break
+		}
+		end += open + 1
+
+		before := query[:open]
+		negated := false
+		if trimmed := strings.TrimRight(before, "!-"); len(trimmed) < len(before) &&
+			(trimmed == "" || strings.HasSuffix(trimmed, " ") || strings.HasSuffix(trimmed, "\t")) {
+			before = trimmed
+			negated = true
+		}
+		b.WriteString(before)
+		b.WriteByte(' ')
+
+		if phrase := strings.ToLower(query[open+1 : end]); strings.TrimSpace(phrase) != "" {
+			if negated {
+				excluded = append(excluded, phrase)
+			} else {
+				phrases = append(phrases, phrase)
+			}
+		}
+		query = query[end+1:]
+	}
+	b.WriteString(query)
+	return b.String(), phrases, excluded
+}
+
+// containsPhrases reports whether the text contains every phrase, ignoring case
+func containsPhrases(text string, phrases []string) bool {
+	if len(phrases) == 0 {
+		return true
+	}
+
+	lower := strings.ToLower(text)
+	for _, phrase := range phrases {
+		if !strings.Contains(lower, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesTags reports whether the story carries every required tag and none
+// of the excluded ones
+func (q parsedQuery) matchesTags(story *models.UserStory) bool {
+	for _, tag := range q.tags {
+		if !story.HasTag(tag) {
+			return false
+		}
+	}
+	for _, tag := range q.excludedTags {
+		if story.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRecency reports whether the story passes every "updated:" filter
+func (q parsedQuery) matchesRecency(story *models.UserStory, now time.Time) bool {
+	for _, filter := range q.recency {
+		if !filter.matches(story, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// relativeRecency reports whether a filter depends on the current time
+func (q parsedQuery) relativeRecency() bool {
+	for _, filter := range q.recency {
+		if filter.date.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcluded reports whether the text contains any of the exclusion terms
+func isExcluded(text string, exclusions []string) bool {
+	if len(exclusions) == 0 {
+		return false
+	}
+
+	lower := strings.ToLower(text)
+	for _, excluded := range exclusions {
+		if strings.Contains(lower, excluded) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetState returns the current filter state
 func (e *Engine) GetState() FilterState {
 	e.mu.RLock()
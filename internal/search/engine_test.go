@@ -146,6 +146,142 @@ func TestFilter(t *testing.T) {
 	})
 }
 
+func TestFilterExclusions(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login with password", Description: "Auth flow"},
+		{Title: "Pay with card", Description: "Payment flow"},
+		{Title: "Refund a payment", Description: "Payment refund flow"},
+	}
+
+	titles := func(filtered []models.UserStory) []string {
+		result := make([]string, len(filtered))
+		for i, story := range filtered {
+			result[i] = story.Title
+		}
+		return result
+	}
+
+	t.Run("Exclusion-only query shows everything not matching", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Equal(t, []string{"Pay with card", "Refund a payment"}, titles(engine.Filter("-auth")))
+		assert.Equal(t, []string{"Login with password", "Pay with card"}, titles(engine.Filter("!refund")))
+	})
+
+	t.Run("Exclusions combine with additive terms", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Equal(t, []string{"Pay with card"}, titles(engine.Filter("payment -refund")))
+	})
+
+	t.Run("Bare exclusion markers are ignored", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Len(t, engine.Filter("-"), 3)
+		assert.Len(t, engine.Filter("! -"), 3)
+	})
+}
+
+func TestParseQuery(t *testing.T) {
+	q := parseQuery("payment  -Refund !auth - card")
+	assert.Equal(t, "payment card", q.terms)
+	assert.Equal(t, []string{"refund", "auth"}, q.exclusions)
+
+	q = parseQuery("login tag:Security -tag:legacy tag:")
+	assert.Equal(t, "login", q.terms)
+	assert.Equal(t, []string{"Security"}, q.tags)
+	assert.Equal(t, []string{"legacy"}, q.excludedTags)
+	assert.Empty(t, q.exclusions)
+}
+
+func TestParseQueryPhrases(t *testing.T) {
+	q := parseQuery(`"User Profile" edit -"old  flow" ""`)
+	assert.Equal(t, "edit", q.terms)
+	assert.Equal(t, []string{"user profile"}, q.phrases)
+	assert.Equal(t, []string{"old  flow"}, q.exclusions)
+
+	// A dangling quote is kept as a literal
+	q = parseQuery(`"user profile" "settings`)
+	assert.Equal(t, []string{"user profile"}, q.phrases)
+	assert.Equal(t, `"settings`, q.terms)
+
+	// A dash inside a word doesn't exclude the phrase
+	q = parseQuery(`e-"mail"`)
+	assert.Equal(t, "e-", q.terms)
+	assert.Equal(t, []string{"mail"}, q.phrases)
+}
+
+func TestFilterPhrases(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Edit user profile", Description: "Change the display name"},
+		{Title: "Profile of a user", Description: "Show the user activity"},
+		{Title: "Delete user", Description: "Remove the profile picture"},
+	}
+
+	titles := func(filtered []models.UserStory) []string {
+		result := make([]string, len(filtered))
+		for i, story := range filtered {
+			result[i] = story.Title
+		}
+		return result
+	}
+
+	t.Run("Unquoted words need not be contiguous", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Equal(t, []string{"Edit user profile", "Delete user"}, titles(engine.Filter("user profile")))
+	})
+
+	t.Run("Quoted words match as a contiguous phrase", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Equal(t, []string{"Edit user profile"}, titles(engine.Filter(`"USER PROFILE"`)))
+		assert.Equal(t, []string{"Delete user"}, titles(engine.Filter(`"profile picture"`)))
+	})
+
+	t.Run("Phrases combine with terms and exclusions", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Equal(t, []string{"Profile of a user"}, titles(engine.Filter(`activity "of a user"`)))
+		assert.Empty(t, engine.Filter(`display "of a user"`))
+		assert.Equal(t, []string{"Profile of a user", "Delete user"}, titles(engine.Filter(`user -"user profile"`)))
+	})
+
+	t.Run("Unbalanced quotes are matched literally", func(t *testing.T) {
+		engine := NewEngine(stories)
+		assert.Empty(t, engine.Filter(`"user profile`))
+		assert.NotPanics(t, func() { engine.Filter(`"`) })
+	})
+
+	t.Run("Phrases show in the active filters", func(t *testing.T) {
+		engine := NewEngine(stories)
+		engine.Filter(`edit "User profile"`)
+		assert.Equal(t, []string{"edit", `"user profile"`}, engine.GetState().ActiveFilters())
+	})
+}
+
+func TestFilterTags(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login form", Tags: []string{"auth", "security"}},
+		{Title: "Password reset", Tags: []string{"auth", "legacy"}},
+		{Title: "Invoice export", Tags: []string{"billing"}},
+	}
+
+	engine := NewEngine(stories)
+
+	filtered := engine.Filter("tag:auth")
+	assert.Len(t, filtered, 2)
+
+	filtered = engine.Filter("tag:SECURITY")
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Login form", filtered[0].Title)
+
+	filtered = engine.Filter("tag:auth -tag:legacy")
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Login form", filtered[0].Title)
+
+	filtered = engine.Filter("password tag:auth")
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Password reset", filtered[0].Title)
+
+	filtered = engine.Filter("tag:unknown")
+	assert.Empty(t, filtered)
+}
+
 func TestGetState(t *testing.T) {
 	stories := []models.UserStory{
 		{Title: "Story 1", IsImplemented: false},
@@ -172,4 +308,109 @@ func TestGetState(t *testing.T) {
 	state = engine.GetState()
 	assert.Equal(t, len(filtered), state.FilteredCount) // Only check that filtered count matches result length
 	assert.True(t, state.ShowAll)
-}
\ No newline at end of file
+}
+
+func TestGetStateActiveFilters(t *testing.T) {
+	engine := NewEngine([]models.UserStory{{Title: "Story 1"}})
+
+	engine.Filter("auth login -Legacy tag:security -tag:old updated:<7d updated:")
+	state := engine.GetState()
+	assert.Equal(t, "auth login", state.Terms)
+	assert.Equal(t, []string{"legacy"}, state.Exclusions)
+	assert.Equal(t, []string{"security"}, state.Tags)
+	assert.Equal(t, []string{"old"}, state.ExcludedTags)
+	assert.Equal(t, []string{"<7d"}, state.Updated)
+	assert.Equal(t, []string{"auth login", "-legacy", "tag:security", "-tag:old", "updated:<7d"}, state.ActiveFilters())
+
+	// Clearing the query clears the components
+	engine.Filter("")
+	assert.Empty(t, engine.GetState().ActiveFilters())
+}
+func TestFilterDisplayModes(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login form", IsImplemented: true},
+		{Title: "Login audit"},
+		{Title: "Logout", IsImplemented: true},
+		{Title: "Login throttling"},
+	}
+
+	engine := NewEngine(stories)
+	titles := func(stories []models.UserStory) []string {
+		var result []string
+		for _, story := range stories {
+			result = append(result, story.Title)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Login audit", "Login throttling"}, titles(engine.Filter("")))
+
+	engine.SetDisplayMode(ShowImplemented)
+	assert.True(t, engine.GetState().ShowAll)
+	assert.Equal(t, []string{"Login form", "Login audit", "Logout", "Login throttling"}, titles(engine.Filter("")))
+
+	engine.SetDisplayMode(DimImplemented)
+	assert.True(t, engine.GetState().ShowAll)
+	assert.Equal(t, []string{"Login audit", "Login throttling", "Login form", "Logout"}, titles(engine.Filter("")))
+
+	// Searches keep implemented stories last, including cached results
+	for i := 0; i < 2; i++ {
+		filtered := engine.Filter("login")
+		assert.Len(t, filtered, 3)
+		assert.Equal(t, "Login form", filtered[2].Title)
+	}
+
+	// Cached results follow a mode change
+	engine.SetDisplayMode(HideImplemented)
+	assert.Len(t, engine.Filter("login"), 2)
+}
+
+func TestDisplayModeNext(t *testing.T) {
+	assert.Equal(t, ShowImplemented, HideImplemented.Next())
+	assert.Equal(t, DimImplemented, ShowImplemented.Next())
+	assert.Equal(t, HideImplemented, DimImplemented.Next())
+}
+
+func TestFilter_FieldWeights(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Export report", Description: "Download as CSV", FilePath: "docs/user-stories/login/export.md"},
+		{Title: "Session timeout", Description: "Sign out after the login expires", FilePath: "docs/user-stories/session/timeout.md"},
+		{Title: "Login form", Description: "Enter credentials", FilePath: "docs/user-stories/auth/form.md"},
+	}
+
+	engine := NewEngine(stories)
+
+	// Title matches rank first, then description matches, then path matches
+	filtered := engine.Filter("login")
+	assert.Equal(t, []string{"Login form", "Session timeout", "Export report"}, titles(filtered))
+	assert.Greater(t, filtered[0].MatchScore, filtered[1].MatchScore)
+	assert.Greater(t, filtered[1].MatchScore, filtered[2].MatchScore)
+
+	// Path matches can be made to count most
+	engine.SetFieldWeights(1, 1, 10)
+	filtered = engine.Filter("login")
+	assert.Equal(t, "Export report", filtered[0].Title)
+
+	// A field with no weight is not searched
+	engine.SetFieldWeights(1, 1, 0)
+	filtered = engine.Filter("login")
+	assert.Equal(t, []string{"Login form", "Session timeout"}, titles(filtered))
+}
+
+func TestFilter_CrossFieldQuery(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login form", Description: "Enter credentials"},
+		{Title: "Export report", Description: "Download as CSV"},
+	}
+
+	filtered := NewEngine(stories).Filter("login credentials")
+	assert.Equal(t, []string{"Login form"}, titles(filtered))
+}
+
+func titles(stories []models.UserStory) []string {
+	result := make([]string, len(stories))
+	for i, story := range stories {
+		result[i] = story.Title
+	}
+	return result
+}
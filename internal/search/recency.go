@@ -0,0 +1,116 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package search
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// SortMode controls the order of the results
+type SortMode int
+
+const (
+	// SortRelevance keeps the best matches first, or the story order without a query
+	SortRelevance SortMode = iota
+	// SortRecent lists the most recently updated stories first
+	SortRecent
+)
+
+// Next returns the mode following m, cycling relevance, recent
+func (m SortMode) Next() SortMode {
+	return (m + 1) % 2
+}
+
+// String returns a short label for the mode
+func (m SortMode) String() string {
+	if m == SortRecent {
+		return "Recent"
+	}
+	return "Relevance"
+}
+
+// updatedPrefix introduces a recency filter token in a query, e.g. "updated:<7d"
+const updatedPrefix = "updated:"
+
+// recencyFilter keeps the stories last updated on one side of a point in time,
+// given either as an age relative to now or as an absolute date
+type recencyFilter struct {
+	newer bool          // Keep stories updated after the point rather than before it
+	age   time.Duration // Age of the point relative to now, when date is zero
+	date  time.Time
+}
+
+// parseRecencyFilter parses the value of an "updated:" token. "<7d" keeps the
+// stories updated less than 7 days ago and ">30d" those updated more than 30
+// days ago; ages take an h, d or w unit. With a date, as in ">2025-01-31" or
+// "<2025-01-31T12:00:00Z", the comparison is on the date itself. ok is false
+// when the value is incomplete or malformed.
+func parseRecencyFilter(value string) (recencyFilter, bool) {
+	if len(value) < 2 || (value[0] != '<' && value[0] != '>') {
+		return recencyFilter{}, false
+	}
+	op, operand := value[0], value[1:]
+
+	if age, ok := parseAge(operand); ok {
+		// A smaller age means a more recent update
+		return recencyFilter{newer: op == '<', age: age}, true
+	}
+	if date, err := models.ParseTimestamp(operand); err == nil {
+		return recencyFilter{newer: op == '>', date: date}, true
+	}
+	return recencyFilter{}, false
+}
+
+// parseAge parses a positive whole number of hours, days or weeks, e.g. "7d"
+func parseAge(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+
+	switch strings.ToLower(s[len(s)-1:]) {
+	case "h":
+		return time.Duration(n) * time.Hour, true
+	case "d":
+		return time.Duration(n) * 24 * time.Hour, true
+	case "w":
+		return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// matches reports whether the story was last updated on the kept side of the
+// filter's point in time. Stories without a last update date never match.
+func (f recencyFilter) matches(story *models.UserStory, now time.Time) bool {
+	if story.LastUpdated.IsZero() {
+		return false
+	}
+
+	point := f.date
+	if point.IsZero() {
+		point = now.Add(-f.age)
+	}
+	if f.newer {
+		return story.LastUpdated.After(point)
+	}
+	return story.LastUpdated.Before(point)
+}
+
+// sortByRecency orders stories from the most to the least recently updated,
+// keeping the current order between stories updated at the same time
+func sortByRecency(stories []models.UserStory) {
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].LastUpdated.After(stories[j].LastUpdated)
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func newRecencyEngine() *Engine {
+	now := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	engine := NewEngine([]models.UserStory{
+		{Title: "Login form", LastUpdated: now.Add(-40 * 24 * time.Hour)},
+		{Title: "Login audit", LastUpdated: now.Add(-2 * 24 * time.Hour)},
+		{Title: "Logout", LastUpdated: now.Add(-10 * 24 * time.Hour)},
+		{Title: "Legacy import"},
+	})
+	engine.now = func() time.Time { return now }
+	return engine
+}
+
+func TestFilter_UpdatedRelative(t *testing.T) {
+	engine := newRecencyEngine()
+
+	assert.Equal(t, []string{"Login audit"}, titles(engine.Filter("updated:<7d")))
+	assert.Equal(t, []string{"Login form"}, titles(engine.Filter("updated:>30d")))
+	assert.Equal(t, []string{"Login audit", "Logout"}, titles(engine.Filter("updated:<2w")))
+	assert.Equal(t, []string{"Login audit"}, titles(engine.Filter("login updated:<7d")))
+	assert.Equal(t, []string{"Logout"}, titles(engine.Filter("updated:<30d updated:>7d")))
+}
+
+func TestFilter_UpdatedAbsolute(t *testing.T) {
+	engine := newRecencyEngine()
+
+	assert.Equal(t, []string{"Login audit", "Logout"}, titles(engine.Filter("updated:>2025-03-01")))
+	assert.Equal(t, []string{"Login form"}, titles(engine.Filter("UPDATED:<2025-03-01T00:00:00Z")))
+}
+
+func TestFilter_UpdatedIncomplete(t *testing.T) {
+	engine := newRecencyEngine()
+
+	// Half-typed and excluded filters are ignored rather than hiding every story
+	for _, query := range []string{"updated:", "updated:<", "updated:<7", "updated:soon", "-updated:<7d"} {
+		assert.Len(t, engine.Filter(query), 4, query)
+	}
+}
+
+func TestFilter_SortRecent(t *testing.T) {
+	engine := newRecencyEngine()
+	engine.SetSortMode(SortRecent)
+
+	assert.Equal(t, SortRecent, engine.GetState().Sort)
+	assert.Equal(t, []string{"Login audit", "Logout", "Login form", "Legacy import"}, titles(engine.Filter("")))
+	assert.Equal(t, []string{"Login audit", "Login form"}, titles(engine.Filter("login")))
+
+	engine.SetSortMode(SortRelevance)
+	assert.Equal(t, []string{"Login form", "Login audit", "Logout", "Legacy import"}, titles(engine.Filter("")))
+}
+
+func TestSortModeNext(t *testing.T) {
+	assert.Equal(t, SortRecent, SortRelevance.Next())
+	assert.Equal(t, SortRelevance, SortRecent.Next())
+	assert.Equal(t, "Recent", SortRecent.String())
+}
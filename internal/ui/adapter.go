@@ -6,8 +6,11 @@
 package ui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/user-story-matrix/usm/internal/models"
+	uimodels "github.com/user-story-matrix/usm/internal/ui/models"
 	"github.com/user-story-matrix/usm/internal/ui/pages"
 )
 
@@ -53,6 +56,60 @@ func (a *SelectionAdapter) GetSelected() []int {
 	return a.page.GetSelected()
 }
 
+// SelectedStories returns the selected stories
+func (a *SelectionAdapter) SelectedStories() []models.UserStory {
+	return a.page.SelectedStories()
+}
+
+// WasCancelled reports whether the user quit instead of confirming the selection
+func (a *SelectionAdapter) WasCancelled() bool {
+	return a.page.WasCancelled()
+}
+
+// SetMaxSelections caps how many stories can be selected; 0 means unlimited
+func (a *SelectionAdapter) SetMaxSelections(max int) {
+	a.page.SetMaxSelections(max)
+}
+
+// SetStatusTemplate replaces the status line of the selection page with a
+// template, e.g. "${selected} picked, ${visible} of ${total} shown"
+func (a *SelectionAdapter) SetStatusTemplate(template string) {
+	a.page.SetStatusTemplate(template)
+}
+
+// SetSearchHistory sets the search history used by the selection page
+func (a *SelectionAdapter) SetSearchHistory(history *uimodels.SearchHistory) {
+	a.page.SetSearchHistory(history)
+}
+
+// SearchHistory returns the search history of the selection page
+func (a *SelectionAdapter) SearchHistory() *uimodels.SearchHistory {
+	return a.page.SearchHistory()
+}
+
+// RunSelection runs the selection page full screen and returns the selected
+// stories, whether the user cancelled instead of confirming, and any error
+// running the program
+func RunSelection(stories []models.UserStory, showAll bool) ([]models.UserStory, bool, error) {
+	model, err := tea.NewProgram(NewSelectionAdapter(stories, showAll), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, false, err
+	}
+	return selectionResult(model)
+}
+
+// selectionResult reads the outcome of a finished selection program
+func selectionResult(model tea.Model) ([]models.UserStory, bool, error) {
+	switch m := model.(type) {
+	case *SelectionAdapter:
+		return m.SelectedStories(), m.WasCancelled(), nil
+	case *pages.SelectionPage:
+		return m.SelectedStories(), m.WasCancelled(), nil
+	default:
+		return nil, false, fmt.Errorf("unexpected selection model %T", model)
+	}
+}
+
 // RegisterNewSelectionUIMaker registers the new selection UI implementation
 // For backward compatibility - this function now does nothing since we
 // permanently use the new implementation
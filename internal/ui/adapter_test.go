@@ -0,0 +1,50 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestSelectionResult(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login", FilePath: "docs/user-stories/01-login.md"},
+		{Title: "Logout", FilePath: "docs/user-stories/02-logout.md"},
+	}
+	adapter := NewSelectionAdapter(stories, true)
+	adapter.Init()
+
+	// Pick the first story from the list and confirm
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeySpace}, {Type: tea.KeyEnter}} {
+		adapter.Update(key)
+	}
+
+	selected, cancelled, err := selectionResult(adapter)
+	require.NoError(t, err)
+	assert.False(t, cancelled)
+	assert.Equal(t, stories[:1], selected)
+}
+
+func TestSelectionResult_Cancelled(t *testing.T) {
+	adapter := NewSelectionAdapter([]models.UserStory{{Title: "Login", FilePath: "docs/user-stories/01-login.md"}}, true)
+	adapter.Init()
+	adapter.Update(tea.KeyMsg{Type: tea.KeyEscape})
+
+	selected, cancelled, err := selectionResult(adapter)
+	require.NoError(t, err)
+	assert.True(t, cancelled)
+	assert.Empty(t, selected)
+}
+
+func TestSelectionResult_UnexpectedModel(t *testing.T) {
+	_, _, err := selectionResult(nil)
+	assert.Error(t, err)
+}
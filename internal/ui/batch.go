@@ -0,0 +1,41 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/search"
+)
+
+// SelectMatching chooses stories without the picker, for scripted runs: the
+// stories matching query, as if typed in the picker's search box, best match
+// first. Implemented stories are left out unless showAll is set, and at most
+// max stories are chosen when max is positive. It returns their indices in
+// stories, like SelectionAdapter.GetSelected.
+func SelectMatching(stories []models.UserStory, query string, showAll bool, max int) []int {
+	engine := search.NewEngine(stories)
+	engine.SetShowAll(showAll)
+
+	// The engine returns copies of the stories; find them back by path
+	indices := make(map[string][]int, len(stories))
+	for i, story := range stories {
+		indices[story.FilePath] = append(indices[story.FilePath], i)
+	}
+
+	var selected []int
+	for _, story := range engine.Filter(query) {
+		if max > 0 && len(selected) >= max {
+			break
+		}
+		candidates := indices[story.FilePath]
+		if len(candidates) == 0 {
+			continue
+		}
+		selected = append(selected, candidates[0])
+		indices[story.FilePath] = candidates[1:]
+	}
+	return selected
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestSelectMatching(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login form", FilePath: "01-login.md", Tags: []string{"auth"}},
+		{Title: "Password reset", FilePath: "02-reset.md", Tags: []string{"auth"}, IsImplemented: true},
+		{Title: "Invoice export", FilePath: "03-invoice.md"},
+	}
+
+	// An empty query chooses every unimplemented story
+	assert.Equal(t, []int{0, 2}, SelectMatching(stories, "", false, 0))
+
+	// Implemented stories are only chosen with showAll
+	assert.Equal(t, []int{0}, SelectMatching(stories, "tag:auth", false, 0))
+	assert.ElementsMatch(t, []int{0, 1}, SelectMatching(stories, "tag:auth", true, 0))
+
+	// The cap keeps the first stories
+	assert.Len(t, SelectMatching(stories, "", true, 2), 2)
+
+	assert.Empty(t, SelectMatching(stories, "nothing matches this", true, 0))
+}
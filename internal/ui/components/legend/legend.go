@@ -0,0 +1,80 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package legend
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user-story-matrix/usm/internal/ui/models"
+	"github.com/user-story-matrix/usm/internal/ui/styles"
+)
+
+// Legend is a full-screen overlay listing every keybinding of a key map with
+// its description, as set with key.WithHelp
+type Legend struct {
+	styles *styles.Styles
+	keyMap models.KeyMap
+	width  int
+	height int
+}
+
+// New creates a new Legend component
+func New(styles *styles.Styles, keyMap models.KeyMap) Legend {
+	return Legend{
+		styles: styles,
+		keyMap: keyMap,
+		width:  80,
+		height: 24,
+	}
+}
+
+// SetSize sets the size of the screen the legend is centered in
+func (l Legend) SetSize(width, height int) Legend {
+	l.width = width
+	l.height = height
+	return l
+}
+
+// View renders the legend, one section per group of bindings
+func (l Legend) View() string {
+	sections := l.keyMap.HelpSections()
+
+	// Align the descriptions on the widest key
+	keyWidth := 0
+	for _, section := range sections {
+		for _, binding := range section.Bindings {
+			if w := lipgloss.Width(binding.Help().Key); w > keyWidth {
+				keyWidth = w
+			}
+		}
+	}
+	keyStyle := l.styles.SearchText.Copy().Width(keyWidth + 2)
+	headerStyle := l.styles.Subtle.Copy().Bold(true)
+
+	var sb strings.Builder
+	sb.WriteString(l.styles.Title.Render("Keyboard shortcuts"))
+	sb.WriteString("\n")
+	for _, section := range sections {
+		sb.WriteString("\n")
+		sb.WriteString(headerStyle.Render(section.Title))
+		sb.WriteString("\n")
+		for _, binding := range section.Bindings {
+			if !binding.Enabled() {
+				continue
+			}
+			help := binding.Help()
+			sb.WriteString(keyStyle.Render(help.Key))
+			sb.WriteString(l.styles.Normal.Render(help.Desc))
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString("\n")
+	sb.WriteString(l.styles.Subtle.Render("Press ? or Esc to close"))
+
+	box := l.styles.Border.Copy().Padding(1, 2).Render(sb.String())
+	return lipgloss.Place(l.width, l.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -24,7 +24,7 @@ type SearchBox struct {
 // New creates a new SearchBox component
 func New(styles *styles.Styles) SearchBox {
 	ti := textinput.New()
-	ti.Placeholder = "Type to search user stories, CTRL+a to toggle all/unimplemented ..."
+	ti.Placeholder = "Type to search user stories, CTRL+a to hide/show/dim implemented ..."
 	ti.CharLimit = 100
 	ti.Width = 50
 	
@@ -150,7 +150,7 @@ func (s SearchBox) View() string {
 		// Unfocused state - muted colors
 		label = s.styles.SearchLabel.Copy().
 			PaddingRight(1).
-			Foreground(lipgloss.Color("240")). // Dimmer color when unfocused
+			Foreground(s.styles.SearchDimmed.GetForeground()). // Dimmer color when unfocused
 			Render(labelContent)
 			
 		// Create a dimmed version of the text input
@@ -160,13 +160,11 @@ func (s SearchBox) View() string {
 		// Create a custom dimmed version when unfocused
 		if value != "" {
 			// Show the value in a dimmed style with tag hints
-			textView = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("240")). // Dimmer color
+			textView = s.styles.SearchDimmed.
 				Render(s.textInput.Prompt + value + " (tab to edit, CTRL+a to toggle)")
 		} else {
 			// Show instruction instead of placeholder when unfocused and empty
-			textView = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("237")). // Even dimmer for instruction
+			textView = s.styles.SearchHint.
 				Render(s.textInput.Prompt + "Tab to search user stories")
 		}
 	}
@@ -7,8 +7,10 @@ package statusbar
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/user-story-matrix/usm/internal/search"
 	"github.com/user-story-matrix/usm/internal/ui/models"
 	"github.com/user-story-matrix/usm/internal/ui/styles"
 )
@@ -20,6 +22,7 @@ type StatusBar struct {
 	keyMap          models.KeyMap
 	showHelp        bool
 	lastFilterStatus string
+	template        string // Custom status line, empty for the default one
 	
 	// Cache fields for performance
 	lastState       *models.UIState
@@ -49,6 +52,41 @@ func (s StatusBar) SetWidth(width int) StatusBar {
 	return s
 }
 
+// SetTemplate replaces the status line with template, so embedders can match
+// the wording of their application. The template may use these placeholders:
+//
+//	${selected}  number of selected stories
+//	${max}       maximum number of selections, 0 when unlimited
+//	${hidden}    number of selected stories hidden by the filters
+//	${visible}   number of stories matching the filters
+//	${total}     number of stories
+//	${mode}      implemented filter mode
+//	${sort}      sort mode
+//	${filters}   active search filters, separated by " | "
+//	${message}   transient status message, e.g. a reached selection limit
+//
+// An empty template restores the default status line.
+func (s StatusBar) SetTemplate(template string) StatusBar {
+	s.template = template
+	s.stateChanged = true
+	return s
+}
+
+// expandTemplate fills the placeholders of the status line template
+func (s StatusBar) expandTemplate(state *models.UIState) string {
+	return strings.NewReplacer(
+		"${selected}", strconv.Itoa(state.SelectedCount()),
+		"${max}", strconv.Itoa(state.MaxSelections),
+		"${hidden}", strconv.Itoa(state.HiddenSelectedCount()),
+		"${visible}", strconv.Itoa(state.FilteredStories),
+		"${total}", strconv.Itoa(state.TotalStories),
+		"${mode}", state.ImplementedMode.String(),
+		"${sort}", state.SortMode.String(),
+		"${filters}", strings.Join(state.ActiveFilters, " | "),
+		"${message}", state.StatusMessage,
+	).Replace(s.template)
+}
+
 // ToggleHelp toggles whether to show help
 func (s StatusBar) ToggleHelp() StatusBar {
 	s.showHelp = !s.showHelp
@@ -72,7 +110,11 @@ func (s *StatusBar) shouldUpdate(state *models.UIState) bool {
 		s.lastState.HiddenSelectedCount() != state.HiddenSelectedCount() ||
 		s.lastState.FilteredStories != state.FilteredStories ||
 		s.lastState.TotalStories != state.TotalStories ||
-		s.lastState.ShowImplemented != state.ShowImplemented
+		s.lastState.ImplementedMode != state.ImplementedMode ||
+		s.lastState.SortMode != state.SortMode ||
+		strings.Join(s.lastState.ActiveFilters, "\x00") != strings.Join(state.ActiveFilters, "\x00") ||
+		s.lastState.MaxSelections != state.MaxSelections ||
+		s.lastState.StatusMessage != state.StatusMessage
 }
 
 // View renders the status bar
@@ -90,6 +132,9 @@ func (s StatusBar) View(state *models.UIState) string {
 	
 	// Selection status with hidden selections if any
 	selectionStatus := fmt.Sprintf("✔ %d selected", state.SelectedCount())
+	if state.MaxSelections > 0 {
+		selectionStatus = fmt.Sprintf("✔ %d/%d selected", state.SelectedCount(), state.MaxSelections)
+	}
 	
 	// Add hidden selection count if there are any
 	if hiddenCount := state.HiddenSelectedCount(); hiddenCount > 0 {
@@ -100,15 +145,25 @@ func (s StatusBar) View(state *models.UIState) string {
 	visibleStatus := fmt.Sprintf("%d visible / %d total", state.FilteredStories, state.TotalStories)
 	
 	// Filter status
-	var filterStatus string
-	if state.ShowImplemented {
-		filterStatus = "Filter: All"
-	} else {
-		filterStatus = "Filter: Unimplemented"
+	filterStatus := "Filter: " + state.ImplementedMode.String()
+	if state.SortMode != search.SortRelevance {
+		filterStatus += " | Sort: " + state.SortMode.String()
 	}
 	
-	// Combine the status elements
-	status := fmt.Sprintf("%s | %s | %s", selectionStatus, visibleStatus, filterStatus)
+	// Combine the status elements, listing every component of the search query
+	var status string
+	if s.template != "" {
+		status = s.expandTemplate(state)
+	} else {
+		status = fmt.Sprintf("%s | %s", selectionStatus, visibleStatus)
+		for _, filter := range state.ActiveFilters {
+			status += " | " + filter
+		}
+		status += " | " + filterStatus
+		if state.StatusMessage != "" {
+			status += " | " + state.StatusMessage
+		}
+	}
 	
 	// Render the status bar
 	statusBar := s.styles.StatusBar.Copy().Width(s.width).Render(status)
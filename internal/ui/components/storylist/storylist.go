@@ -33,6 +33,7 @@ type StoryList struct {
 	visibleEnd    int
 	totalCount    int
 	selectedCount int
+	detailed      bool // Show a description line under each title
 	// Cache fields for performance
 	lastRender    string
 	needsRender   bool
@@ -73,6 +74,27 @@ func (l StoryList) Blur() StoryList {
 	return l
 }
 
+// ToggleDetails switches between one line per story and a second line
+// showing the beginning of each story's description
+func (l StoryList) ToggleDetails() StoryList {
+	l.detailed = !l.detailed
+	l.updateVisibleRange()
+	return l
+}
+
+// Detailed reports whether descriptions are shown under the titles
+func (l StoryList) Detailed() bool {
+	return l.detailed
+}
+
+// pageSize returns how many stories fit in the list height
+func (l StoryList) pageSize() int {
+	if l.detailed && l.height > 1 {
+		return l.height / 2
+	}
+	return l.height
+}
+
 // SetItems sets the items in the story list
 func (l StoryList) SetItems(stories []models.UserStory, selectedIDs map[string]bool) StoryList {
 	if stories == nil {
@@ -153,14 +175,14 @@ func (l *StoryList) updateVisibleRange() {
 		l.visibleStart = l.cursor
 	} else if l.cursor >= l.visibleEnd {
 		// Move the window so that cursor is at the end
-		l.visibleStart = l.cursor - l.height + 1
+		l.visibleStart = l.cursor - l.pageSize() + 1
 		if l.visibleStart < 0 {
 			l.visibleStart = 0
 		}
 	}
 	
 	// Calculate visible end based on height
-	l.visibleEnd = l.visibleStart + l.height
+	l.visibleEnd = l.visibleStart + l.pageSize()
 	if l.visibleEnd > len(l.items) {
 		l.visibleEnd = len(l.items)
 	}
@@ -190,6 +212,27 @@ func (l StoryList) ToggleSelection() (StoryList, string) {
 	return l, l.items[l.cursor].Story.FilePath
 }
 
+// SetAllSelected selects or deselects every item in the list and returns their IDs
+func (l StoryList) SetAllSelected(selected bool) (StoryList, []string) {
+	ids := make([]string, 0, len(l.items))
+	items := make([]StoryItem, len(l.items))
+	for i, item := range l.items {
+		item.IsSelected = selected
+		items[i] = item
+		ids = append(ids, item.Story.FilePath)
+	}
+	
+	l.items = items
+	if selected {
+		l.selectedCount = len(items)
+	} else {
+		l.selectedCount = 0
+	}
+	l.needsRender = true
+	
+	return l, ids
+}
+
 // MoveUp moves the cursor up
 func (l StoryList) MoveUp() StoryList {
 	if len(l.items) == 0 {
@@ -234,7 +277,7 @@ func (l StoryList) PageUp() StoryList {
 		return l
 	}
 	
-	l.cursor -= l.height
+	l.cursor -= l.pageSize()
 	if l.cursor < 0 {
 		l.cursor = 0
 	}
@@ -253,7 +296,7 @@ func (l StoryList) PageDown() StoryList {
 		return l
 	}
 	
-	l.cursor += l.height
+	l.cursor += l.pageSize()
 	if l.cursor >= len(l.items) {
 		l.cursor = len(l.items) - 1
 	}
@@ -392,10 +435,7 @@ func (l StoryList) View() string {
 		item := l.items[i]
 		
 		// Build the raw line content without any styling first
-		checkbox := "[ ]"
-		if item.IsSelected {
-			checkbox = "[✓]"
-		}
+		checkbox := l.styles.GetCheckbox(item.IsSelected)
 		
 		impStatus := "U"
 		if item.Story.IsImplemented {
@@ -409,8 +449,12 @@ func (l StoryList) View() string {
 			title = title[:maxTitleWidth-3] + "..."
 		}
 		
-		// Create the full raw line
-		rawLine := fmt.Sprintf(" %s %s %s", checkbox, impStatus, title)
+		// Create the full raw line, marking the story under the cursor
+		marker := " "
+		if l.focused && i == l.cursor && l.styles.CursorMarker != "" {
+			marker = l.styles.CursorMarker
+		}
+		rawLine := fmt.Sprintf("%s%s %s %s", marker, checkbox, impStatus, title)
 		
 		// Simple style selection based on conditions
 		var renderedLine string
@@ -436,17 +480,26 @@ func (l StoryList) View() string {
 		sb.WriteString(renderedLine)
 		sb.WriteString("\n")
 		
+		// In detailed mode, show the beginning of the description under the title
+		if l.detailed {
+			sb.WriteString(l.styles.Implemented.Render("       " + truncate(item.Story.Description, maxTitleWidth)))
+			sb.WriteString("\n")
+		}
+		
 		// Only show shortened filepath on the currently focused item for less visual noise
 		if l.focused && i == l.cursor && item.Story.FilePath != "" {
 			filePath := shortenPath(item.Story.FilePath, commonPrefix)
 			pathLine := fmt.Sprintf("       %s", filePath)
+			if !item.Story.LastUpdated.IsZero() {
+				pathLine += fmt.Sprintf("  (updated %s)", item.Story.LastUpdated.Format("2006-01-02"))
+			}
 			sb.WriteString(l.styles.Implemented.Render(pathLine))
 			sb.WriteString("\n")
 		}
 	}
 	
 	// Show simple indicator for navigation
-	if len(l.items) > l.height {
+	if len(l.items) > l.pageSize() {
 		sb.WriteString(l.styles.Implemented.Render(" ↑/↓ to navigate"))
 	}
 	
@@ -456,6 +509,17 @@ func (l StoryList) View() string {
 	return l.lastRender
 }
 
+// truncate shortens s to at most width runes, ending it with "..." when cut.
+// Line breaks are replaced by spaces.
+func truncate(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if width < 4 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
+
 // SetCursor sets the cursor position
 func (l StoryList) SetCursor(position int) StoryList {
 	if len(l.items) == 0 {
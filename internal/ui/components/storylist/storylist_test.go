@@ -7,8 +7,12 @@ package storylist
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/ui/styles"
 )
 
 func TestCalculateCommonPrefix(t *testing.T) {
@@ -251,4 +255,45 @@ func TestCalculateCommonPrefixBenchmark(t *testing.T) {
 	}
 	
 	t.Logf("Calculated common prefix for %d paths in %v", len(paths), duration)
-} 
\ No newline at end of file
+} 
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a longer description", 10, "a longe..."},
+		{"multi\nline   text", 20, "multi line text"},
+		{"élan vital", 7, "élan..."},
+	}
+
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.width); got != tt.expected {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.expected)
+		}
+	}
+}
+
+func TestViewCheckboxGlyphs(t *testing.T) {
+	s := styles.DefaultStyles()
+	s.CheckboxGlyphs = styles.CheckboxGlyphs{Checked: "(*)", Unchecked: "( )"}
+
+	stories := []models.UserStory{
+		{Title: "Login", FilePath: "docs/user-stories/01-login.md"},
+		{Title: "Logout", FilePath: "docs/user-stories/02-logout.md"},
+	}
+	list := New(s).SetSize(80, 10).SetItems(stories, map[string]bool{"docs/user-stories/01-login.md": true})
+
+	view := list.View()
+	if !strings.Contains(view, "(*) U Login") {
+		t.Errorf("View() should show the selected story with the checked glyph, got:\n%s", view)
+	}
+	if !strings.Contains(view, "( ) U Logout") {
+		t.Errorf("View() should show the other story with the unchecked glyph, got:\n%s", view)
+	}
+	if strings.Contains(view, "[✓]") {
+		t.Errorf("View() should not show the default glyphs, got:\n%s", view)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"path/filepath"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/logger"
+	"github.com/user-story-matrix/usm/internal/metadata"
+	"github.com/user-story-matrix/usm/internal/models"
+	"go.uber.org/zap"
+)
+
+// LoadStoriesFromReader reads the stories to offer in the selection UI from r.
+// The input is either a JSON array of user stories or a newline-delimited
+// list of story paths, as produced by find or grep -l. Each path is resolved
+// through fs; blank lines are ignored.
+func LoadStoriesFromReader(r stdio.Reader, fs io.FileSystem) ([]models.UserStory, error) {
+	data, err := stdio.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stories: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var stories []models.UserStory
+		if err := json.Unmarshal(trimmed, &stories); err != nil {
+			return nil, fmt.Errorf("failed to parse stories as JSON: %w", err)
+		}
+		return stories, nil
+	}
+
+	var stories []models.UserStory
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		path := strings.TrimSpace(scanner.Text())
+		if path == "" {
+			continue
+		}
+
+		content, err := fs.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user story %s: %w", path, err)
+		}
+
+		story, err := models.LoadUserStoryFromFile(path, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse user story %s: %w", path, err)
+		}
+		stories = append(stories, story)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stories: %w", err)
+	}
+
+	return stories, nil
+}
+
+// LoadStoriesFromDirs loads the user stories below each of dirs into a single
+// list for the selection UI. Directories are scanned with the same skip rules
+// as the metadata update, and a story reachable from several directories is
+// listed once. Files that cannot be read or parsed are skipped.
+func LoadStoriesFromDirs(dirs []string, fs io.FileSystem) ([]models.UserStory, error) {
+	var stories []models.UserStory
+	seen := make(map[string]bool)
+
+	for _, dir := range dirs {
+		files, err := metadata.FindMarkdownFiles(dir, fs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+
+		for _, file := range files {
+			content, err := fs.ReadFile(file)
+			if err != nil {
+				logger.Debug("Failed to read user story", zap.String("file", file), zap.Error(err))
+				continue
+			}
+
+			story, err := models.LoadUserStoryFromFile(file, content)
+			if err != nil {
+				logger.Debug("Failed to parse user story", zap.String("file", file), zap.Error(err))
+				continue
+			}
+
+			key := filepath.Clean(story.FilePath)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			stories = append(stories, story)
+		}
+	}
+
+	return stories, nil
+}
+
+// LoadChangedStories loads the user stories of root changed since the git
+// ref, "HEAD" when empty, so that recent edits can be picked first. Files
+// that cannot be read or parsed are skipped. It returns an error wrapping
+// metadata.ErrNotGitRepository when root is not in a git work tree.
+func LoadChangedStories(ref string, root string, fs io.FileSystem) ([]models.UserStory, error) {
+	files, err := metadata.FindChangedUserStories(ref, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var stories []models.UserStory
+	for _, file := range files {
+		content, err := fs.ReadFile(file)
+		if err != nil {
+			logger.Debug("Failed to read user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+
+		story, err := models.LoadUserStoryFromFile(file, content)
+		if err != nil {
+			logger.Debug("Failed to parse user story", zap.String("file", file), zap.Error(err))
+			continue
+		}
+		stories = append(stories, story)
+	}
+
+	return stories, nil
+}
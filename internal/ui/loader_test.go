@@ -0,0 +1,139 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+func TestLoadStoriesFromReader_Paths(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("---\nfile_path: docs/user-stories/01-login.md\n---\n\n# Login\n"))
+	fs.AddFile("docs/user-stories/02-logout.md", []byte("# Logout\n"))
+
+	input := "docs/user-stories/01-login.md\n\n  docs/user-stories/02-logout.md  \n"
+	stories, err := LoadStoriesFromReader(strings.NewReader(input), fs)
+
+	require.NoError(t, err)
+	require.Len(t, stories, 2)
+	assert.Equal(t, "Login", stories[0].Title)
+	assert.Equal(t, "01", stories[0].SequentialNumber)
+	assert.Equal(t, "Logout", stories[1].Title)
+	assert.Equal(t, "docs/user-stories/02-logout.md", stories[1].FilePath)
+}
+
+func TestLoadStoriesFromReader_MissingPath(t *testing.T) {
+	fs := io.NewMockFileSystem()
+
+	_, err := LoadStoriesFromReader(strings.NewReader("docs/user-stories/missing.md\n"), fs)
+
+	assert.ErrorContains(t, err, "docs/user-stories/missing.md")
+}
+
+func TestLoadStoriesFromReader_JSON(t *testing.T) {
+	input := `
+[
+  {"title": "Login", "file_path": "docs/user-stories/01-login.md", "is_implemented": true},
+  {"title": "Logout", "file_path": "docs/user-stories/02-logout.md"}
+]`
+	stories, err := LoadStoriesFromReader(strings.NewReader(input), io.NewMockFileSystem())
+
+	require.NoError(t, err)
+	require.Len(t, stories, 2)
+	assert.Equal(t, "Login", stories[0].Title)
+	assert.True(t, stories[0].IsImplemented)
+	assert.Equal(t, "docs/user-stories/02-logout.md", stories[1].FilePath)
+}
+
+func TestLoadStoriesFromReader_InvalidJSON(t *testing.T) {
+	_, err := LoadStoriesFromReader(strings.NewReader("[{"), io.NewMockFileSystem())
+
+	assert.Error(t, err)
+}
+
+func TestLoadStoriesFromReader_Empty(t *testing.T) {
+	stories, err := LoadStoriesFromReader(strings.NewReader(""), io.NewMockFileSystem())
+
+	assert.NoError(t, err)
+	assert.Empty(t, stories)
+}
+
+func TestLoadStoriesFromDirs(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	fs.AddFile("docs/user-stories/01-login.md", []byte("# Login\n"))
+	fs.AddFile("product/stories/01-billing.md", []byte("# Billing\n"))
+
+	// The first directory is passed twice, so its story must be listed once
+	stories, err := LoadStoriesFromDirs([]string{"docs/user-stories", "product/stories", "./docs/user-stories/"}, fs)
+
+	require.NoError(t, err)
+	var titles []string
+	for _, story := range stories {
+		titles = append(titles, story.Title)
+	}
+	assert.Equal(t, []string{"Login", "Billing"}, titles)
+}
+
+func TestLoadStoriesFromDirs_MissingDirectory(t *testing.T) {
+	_, err := LoadStoriesFromDirs([]string{"docs/missing"}, io.NewMockFileSystem())
+
+	assert.ErrorContains(t, err, "docs/missing")
+}
+
+func TestLoadChangedStories(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=usm", "-c", "user.email=usm@example.com"}, args...)...)
+		cmd.Dir = root
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	write := func(path, content string) {
+		full := filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+	}
+
+	git("init", "-q")
+	write("docs/user-stories/01-login.md", "# Login\n")
+	write("docs/user-stories/02-logout.md", "# Logout\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "stories")
+
+	// Edit a committed story and add a new one
+	write("docs/user-stories/01-login.md", "# Login\nWith a password\n")
+	write("docs/user-stories/03-signup.md", "# Signup\n")
+
+	stories, err := LoadChangedStories("", root, io.NewOSFileSystem())
+	require.NoError(t, err)
+
+	var titles []string
+	for _, story := range stories {
+		titles = append(titles, story.Title)
+	}
+	sort.Strings(titles)
+	assert.Equal(t, []string{"Login", "Signup"}, titles)
+}
+
+func TestLoadChangedStories_NotGitRepository(t *testing.T) {
+	_, err := LoadChangedStories("", t.TempDir(), io.NewOSFileSystem())
+
+	assert.ErrorIs(t, err, metadata.ErrNotGitRepository)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+// DefaultSearchHistorySize is the number of queries kept in the search history
+const DefaultSearchHistorySize = 50
+
+// searchHistoryFileName is the dotfile, under the usm config dir, where queries are persisted
+const searchHistoryFileName = "search_history"
+
+// SearchHistory is a ring buffer of recent search queries, oldest first.
+// It behaves like a shell history: recalling walks backwards from the newest
+// entry and walking past it returns to an empty query.
+type SearchHistory struct {
+	entries  []string
+	start    int
+	count    int
+	position int // Offset from the newest entry while browsing, -1 when not browsing
+}
+
+// NewSearchHistory creates an empty search history holding at most size queries
+func NewSearchHistory(size int) *SearchHistory {
+	if size <= 0 {
+		size = DefaultSearchHistorySize
+	}
+	return &SearchHistory{
+		entries:  make([]string, size),
+		position: -1,
+	}
+}
+
+// Add records a query, ignoring empty queries and consecutive duplicates
+func (h *SearchHistory) Add(query string) {
+	query = strings.TrimSpace(query)
+	h.position = -1
+	if query == "" || (h.count > 0 && h.at(0) == query) {
+		return
+	}
+
+	end := (h.start + h.count) % len(h.entries)
+	h.entries[end] = query
+	if h.count < len(h.entries) {
+		h.count++
+	} else {
+		h.start = (h.start + 1) % len(h.entries)
+	}
+}
+
+// Previous returns the next older query, staying on the oldest one once reached
+func (h *SearchHistory) Previous() (string, bool) {
+	if h.count == 0 {
+		return "", false
+	}
+	if h.position < h.count-1 {
+		h.position++
+	}
+	return h.at(h.position), true
+}
+
+// Next returns the next newer query; walking past the newest ends browsing
+// and returns an empty query
+func (h *SearchHistory) Next() (string, bool) {
+	if h.position < 0 {
+		return "", false
+	}
+	h.position--
+	if h.position < 0 {
+		return "", true
+	}
+	return h.at(h.position), true
+}
+
+// Browsing reports whether a query is currently recalled from the history
+func (h *SearchHistory) Browsing() bool {
+	return h.position >= 0
+}
+
+// StopBrowsing ends history navigation, typically because the query was edited
+func (h *SearchHistory) StopBrowsing() {
+	h.position = -1
+}
+
+// Entries returns the stored queries, oldest first
+func (h *SearchHistory) Entries() []string {
+	entries := make([]string, h.count)
+	for i := 0; i < h.count; i++ {
+		entries[i] = h.entries[(h.start+i)%len(h.entries)]
+	}
+	return entries
+}
+
+// at returns the entry at the given offset from the newest one
+func (h *SearchHistory) at(offset int) string {
+	return h.entries[(h.start+h.count-1-offset)%len(h.entries)]
+}
+
+// DefaultSearchHistoryPath returns the path of the search history dotfile
+func DefaultSearchHistoryPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".usm", searchHistoryFileName), nil
+}
+
+// LoadSearchHistory reads a persisted history; a missing file yields an empty history
+func LoadSearchHistory(fs io.FileSystem, path string) (*SearchHistory, error) {
+	history := NewSearchHistory(DefaultSearchHistorySize)
+	if !fs.Exists(path) {
+		return history, nil
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return history, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		history.Add(line)
+	}
+	return history, nil
+}
+
+// Save persists the history, one query per line
+func (h *SearchHistory) Save(fs io.FileSystem, path string) error {
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data := strings.Join(h.Entries(), "\n")
+	return fs.WriteFile(path, []byte(data), 0644)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/user-story-matrix/usm/internal/io"
+)
+
+func TestSearchHistory_AddSkipsEmptyAndConsecutiveDuplicates(t *testing.T) {
+	history := NewSearchHistory(5)
+	history.Add("login")
+	history.Add("  ")
+	history.Add("login")
+	history.Add("payment")
+
+	assert.Equal(t, []string{"login", "payment"}, history.Entries())
+}
+
+func TestSearchHistory_RingBufferDropsOldest(t *testing.T) {
+	history := NewSearchHistory(2)
+	history.Add("one")
+	history.Add("two")
+	history.Add("three")
+
+	assert.Equal(t, []string{"two", "three"}, history.Entries())
+}
+
+func TestSearchHistory_Navigation(t *testing.T) {
+	history := NewSearchHistory(5)
+
+	_, ok := history.Previous()
+	assert.False(t, ok, "Empty history has nothing to recall")
+
+	history.Add("one")
+	history.Add("two")
+
+	query, ok := history.Previous()
+	assert.True(t, ok)
+	assert.Equal(t, "two", query)
+	assert.True(t, history.Browsing())
+
+	query, _ = history.Previous()
+	assert.Equal(t, "one", query)
+
+	query, _ = history.Previous()
+	assert.Equal(t, "one", query, "Should stay on the oldest entry")
+
+	query, _ = history.Next()
+	assert.Equal(t, "two", query)
+
+	query, ok = history.Next()
+	assert.True(t, ok)
+	assert.Equal(t, "", query, "Walking past the newest entry returns an empty query")
+	assert.False(t, history.Browsing())
+}
+
+func TestSearchHistory_SaveAndLoad(t *testing.T) {
+	fs := io.NewMockFileSystem()
+	path := "home/.usm/search_history"
+
+	history := NewSearchHistory(5)
+	history.Add("login")
+	history.Add("payment")
+	require.NoError(t, history.Save(fs, path))
+
+	loaded, err := LoadSearchHistory(fs, path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"login", "payment"}, loaded.Entries())
+}
+
+func TestLoadSearchHistory_MissingFile(t *testing.T) {
+	loaded, err := LoadSearchHistory(io.NewMockFileSystem(), "missing")
+
+	assert.NoError(t, err)
+	assert.Empty(t, loaded.Entries())
+}
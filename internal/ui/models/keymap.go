@@ -21,11 +21,19 @@ type KeyMap struct {
 	Tab        key.Binding
 	Search     key.Binding
 	
+	// Search history (search mode only)
+	HistoryPrev key.Binding
+	HistoryNext key.Binding
+	
 	// Actions
 	Select     key.Binding
+	SelectAll  key.Binding
+	DeselectAll key.Binding
 	Done       key.Binding
 	Quit       key.Binding
 	ToggleFilter key.Binding
+	ToggleSort key.Binding
+	ToggleDetails key.Binding
 	Clear      key.Binding
 	Help       key.Binding
 }
@@ -61,11 +69,29 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("/", "search"),
 		),
 		
+		// Search history
+		HistoryPrev: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "previous search"),
+		),
+		HistoryNext: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "next search"),
+		),
+		
 		// Actions
 		Select: key.NewBinding(
 			key.WithKeys(" "),
 			key.WithHelp("Space", "select/deselect"),
 		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "select all visible"),
+		),
+		DeselectAll: key.NewBinding(
+			key.WithKeys("A", "ctrl+u"),
+			key.WithHelp("A/Ctrl+U", "deselect all visible"),
+		),
 		Done: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("Enter", "confirm"),
@@ -76,7 +102,15 @@ func DefaultKeyMap() KeyMap {
 		),
 		ToggleFilter: key.NewBinding(
 			key.WithKeys("ctrl+a"),
-			key.WithHelp("Ctrl+A", "toggle all/unimplemented"),
+			key.WithHelp("Ctrl+A", "cycle hide/show/dim implemented"),
+		),
+		ToggleSort: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("Ctrl+O", "sort by relevance/recently updated"),
+		),
+		ToggleDetails: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "show/hide descriptions"),
 		),
 		Clear: key.NewBinding(
 			key.WithKeys("ctrl+l"),
@@ -84,17 +118,34 @@ func DefaultKeyMap() KeyMap {
 		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
-			key.WithHelp("?", "toggle help"),
+			key.WithHelp("?", "show/hide this help"),
 		),
 	}
 }
 
+// HelpSection is a group of related bindings in the keybinding legend
+type HelpSection struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// HelpSections returns every binding, grouped for the keybinding legend
+func (k KeyMap) HelpSections() []HelpSection {
+	return []HelpSection{
+		{Title: "Navigation", Bindings: []key.Binding{k.Up, k.Down, k.PageUp, k.PageDown, k.Tab}},
+		{Title: "Search", Bindings: []key.Binding{k.Search, k.HistoryPrev, k.HistoryNext, k.Clear}},
+		{Title: "Selection", Bindings: []key.Binding{k.Select, k.SelectAll, k.DeselectAll}},
+		{Title: "View", Bindings: []key.Binding{k.ToggleFilter, k.ToggleSort, k.ToggleDetails}},
+		{Title: "General", Bindings: []key.Binding{k.Done, k.Quit, k.Help}},
+	}
+}
+
 // ListModeHelpView returns help view text for list mode
 func (k KeyMap) ListModeHelpView() string {
-	return "↑/↓: navigate | Space: select | Tab: search | Enter: confirm | Esc: quit"
+	return "↑/↓: navigate | Space: select | a/A: select/deselect visible | d: descriptions | Tab: search | Enter: confirm | Esc: quit | ?: help"
 }
 
 // SearchModeHelpView returns help view text for search mode
 func (k KeyMap) SearchModeHelpView() string {
-	return "Type to search | Esc: cancel | Enter: apply | Tab: list"
+	return "Type to search (tag:name, updated:<7d to filter) | ↑/↓: history | Esc: cancel | Enter: apply | Tab: list"
 } 
\ No newline at end of file
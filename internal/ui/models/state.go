@@ -7,6 +7,7 @@ package models
 
 import (
 	"github.com/user-story-matrix/usm/internal/models"
+	"github.com/user-story-matrix/usm/internal/search"
 )
 
 // UIState represents the current state of the TUI
@@ -16,10 +17,17 @@ type UIState struct {
 
 	// Filter state
 	FilterText     string
-	ShowImplemented bool
+	ShowImplemented bool               // Whether implemented stories are listed at all
+	ImplementedMode search.DisplayMode // How implemented stories are listed
+	SortMode        search.SortMode    // Order of the listed stories
+	ActiveFilters   []string           // Components of the search query, see search.FilterState.ActiveFilters
 
 	// Selection state
-	SelectedIDs map[string]bool // Map of story IDs to selection state
+	SelectedIDs   map[string]bool // Map of story IDs to selection state
+	MaxSelections int             // Maximum number of selected stories, 0 for unlimited
+
+	// StatusMessage is a transient notice shown in the status bar until the next key press
+	StatusMessage string
 
 	// Current view
 	VisibleStories  []models.UserStory
@@ -48,9 +56,21 @@ func (s *UIState) FocusList() {
 	s.SearchFocused = false
 }
 
-// ToggleImplementationFilter toggles whether to show implemented stories
+// ToggleImplementationFilter cycles implemented stories through hidden,
+// shown, and shown dimmed at the bottom of the list
 func (s *UIState) ToggleImplementationFilter() {
-	s.ShowImplemented = !s.ShowImplemented
+	s.SetImplementedMode(s.ImplementedMode.Next())
+}
+
+// SetImplementedMode sets how implemented stories are listed
+func (s *UIState) SetImplementedMode(mode search.DisplayMode) {
+	s.ImplementedMode = mode
+	s.ShowImplemented = mode != search.HideImplemented
+}
+
+// ToggleSortMode switches between relevance order and most recently updated first
+func (s *UIState) ToggleSortMode() {
+	s.SortMode = s.SortMode.Next()
 }
 
 // SetFilterText updates the filter text
@@ -71,6 +91,25 @@ func (s *UIState) ToggleSelection(id string) {
 	}
 }
 
+// SetSelection selects or deselects all the specified stories at once
+func (s *UIState) SetSelection(ids []string, selected bool) {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if selected {
+			s.SelectedIDs[id] = true
+		} else {
+			delete(s.SelectedIDs, id)
+		}
+	}
+}
+
+// CanSelect reports whether n more stories can be selected without exceeding MaxSelections
+func (s *UIState) CanSelect(n int) bool {
+	return s.MaxSelections <= 0 || s.SelectedCount()+n <= s.MaxSelections
+}
+
 // IsSelected returns whether the specified story is selected
 func (s *UIState) IsSelected(id string) bool {
 	if id == "" {
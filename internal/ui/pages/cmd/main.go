@@ -13,9 +13,8 @@ import (
 	"os"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/user-story-matrix/usm/internal/models"
-	"github.com/user-story-matrix/usm/internal/ui/pages"
+	"github.com/user-story-matrix/usm/internal/ui"
 )
 
 func main() {
@@ -63,28 +62,27 @@ func main() {
 		},
 	}
 
-	// Create selection page
-	p := pages.New(stories, false)
-
-	// Run the program
-	program := tea.NewProgram(p, tea.WithAltScreen())
-	model, err := program.Run()
+	// Run the selection page
+	selected, cancelled, err := ui.RunSelection(stories, false)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Get selected stories
-	selectionPage, ok := model.(*pages.SelectionPage)
-	if !ok {
-		fmt.Fprintf(os.Stderr, "Error: Could not convert model to SelectionPage\n")
-		os.Exit(1)
+	if cancelled {
+		fmt.Println("Selection canceled.")
+		return
 	}
-	selected := selectionPage.GetSelected()
-	
+
 	// Print selected stories
-	fmt.Println("\nSelected stories:")
-	for _, idx := range selected {
-		fmt.Printf("- %s\n", stories[idx].Title)
+	fmt.Println()
+	fmt.Println(ui.FormatSelectionSummary(selected, indices(selected)))
+}
+
+// indices returns the indices of all stories, for FormatSelectionSummary
+func indices(stories []models.UserStory) []int {
+	all := make([]int, len(stories))
+	for i := range all {
+		all[i] = i
 	}
-} 
\ No newline at end of file
+	return all
+}
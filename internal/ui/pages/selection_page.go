@@ -6,12 +6,14 @@
 package pages
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/user-story-matrix/usm/internal/models"
 	"github.com/user-story-matrix/usm/internal/search"
+	"github.com/user-story-matrix/usm/internal/ui/components/legend"
 	"github.com/user-story-matrix/usm/internal/ui/components/searchbox"
 	"github.com/user-story-matrix/usm/internal/ui/components/statusbar"
 	"github.com/user-story-matrix/usm/internal/ui/components/storylist"
@@ -25,6 +27,7 @@ type SelectionPage struct {
 	searchBox searchbox.SearchBox
 	storyList storylist.StoryList
 	statusBar statusbar.StatusBar
+	legend    legend.Legend
 	
 	// State
 	state      *uimodels.UIState
@@ -34,12 +37,15 @@ type SelectionPage struct {
 	// Data
 	stories    []models.UserStory
 	engine     *search.Engine
+	history    *uimodels.SearchHistory
 	
 	// UI state
 	width      int
 	height     int
 	quitting   bool
+	cancelled  bool // Quit without confirming the selection
 	ready      bool
+	showLegend bool // The keybinding legend covers the page
 	
 	// Cache fields for performance
 	lastView   string
@@ -55,11 +61,13 @@ func New(stories []models.UserStory, showAll bool) *SelectionPage {
 	
 	// Create state
 	state := uimodels.NewUIState()
-	state.ShowImplemented = showAll
+	if showAll {
+		state.SetImplementedMode(search.ShowImplemented)
+	}
 	
 	// Create search engine
 	engine := search.NewEngine(stories)
-	engine.SetShowAll(showAll)
+	engine.SetDisplayMode(state.ImplementedMode)
 	
 	// Create styles
 	styleSet := styles.DefaultStyles()
@@ -71,6 +79,7 @@ func New(stories []models.UserStory, showAll bool) *SelectionPage {
 	searchbox := searchbox.New(styleSet)
 	storylist := storylist.New(styleSet)
 	statusbar := statusbar.New(styleSet, keyMap)
+	legend := legend.New(styleSet, keyMap)
 	
 	// Set initial focus
 	if state.SearchFocused {
@@ -85,11 +94,13 @@ func New(stories []models.UserStory, showAll bool) *SelectionPage {
 		searchBox: searchbox,
 		storyList: storylist,
 		statusBar: statusbar,
+		legend:    legend,
 		state:     state,
 		keyMap:    keyMap,
 		styles:    styleSet,
 		stories:   stories,
 		engine:    engine,
+		history:   uimodels.NewSearchHistory(uimodels.DefaultSearchHistorySize),
 		width:     80,
 		height:    24,
 		quitting:  false,
@@ -119,11 +130,13 @@ func (p *SelectionPage) updateResults() tea.Cmd {
 	// Update the state
 	p.state.SetFilterText(searchText)
 	
-	// Set the show all flag in the engine
-	p.engine.SetShowAll(p.state.ShowImplemented)
+	// Set how implemented stories are listed in the engine, and in which order
+	p.engine.SetDisplayMode(p.state.ImplementedMode)
+	p.engine.SetSortMode(p.state.SortMode)
 	
 	// Get filtered stories
 	filtered := p.engine.Filter(searchText)
+	p.state.ActiveFilters = p.engine.GetState().ActiveFilters()
 	
 	// Update visible stories in state
 	p.state.SetVisibleStories(filtered, len(p.stories))
@@ -142,11 +155,80 @@ func (p *SelectionPage) updateResults() tea.Cmd {
 	return nil
 }
 
+// SetSearchHistory replaces the search history, e.g. with one loaded from disk
+func (p *SelectionPage) SetSearchHistory(history *uimodels.SearchHistory) {
+	if history != nil {
+		p.history = history
+	}
+}
+
+// SearchHistory returns the search history so it can be persisted
+func (p *SelectionPage) SearchHistory() *uimodels.SearchHistory {
+	return p.history
+}
+
+// recallSearch replaces the search text with a query recalled from the history
+func (p *SelectionPage) recallSearch(query string) tea.Cmd {
+	p.searchBox = p.searchBox.SetValue(query)
+	p.needsRender = true
+	return p.updateResults()
+}
+
+// SetMaxSelections caps how many stories can be selected; 0 means unlimited
+func (p *SelectionPage) SetMaxSelections(max int) {
+	if max < 0 {
+		max = 0
+	}
+	p.state.MaxSelections = max
+	p.needsRender = true
+}
+
+// SetStatusTemplate replaces the status line with a template; see
+// statusbar.StatusBar.SetTemplate for its placeholders. An empty template
+// restores the default status line.
+func (p *SelectionPage) SetStatusTemplate(template string) {
+	p.statusBar = p.statusBar.SetTemplate(template)
+	p.needsRender = true
+}
+
+// flashSelectionLimit tells the user that the selection cap has been reached
+func (p *SelectionPage) flashSelectionLimit() {
+	p.state.StatusMessage = fmt.Sprintf("Limit reached (max %d)", p.state.MaxSelections)
+	p.needsRender = true
+}
+
+// unselectedVisibleCount returns how many visible stories are not selected yet
+func (p *SelectionPage) unselectedVisibleCount() int {
+	count := 0
+	for _, story := range p.state.VisibleStories {
+		if !p.state.IsSelected(story.FilePath) {
+			count++
+		}
+	}
+	return count
+}
+
 // GetSelected returns the indices of the selected stories
 func (p *SelectionPage) GetSelected() []int {
 	return p.state.GetSelectedStoryIndices(p.stories)
 }
 
+// SelectedStories returns the selected stories, in the order of GetSelected
+func (p *SelectionPage) SelectedStories() []models.UserStory {
+	indices := p.GetSelected()
+	selected := make([]models.UserStory, 0, len(indices))
+	for _, idx := range indices {
+		selected = append(selected, p.stories[idx])
+	}
+	return selected
+}
+
+// WasCancelled reports whether the user quit with Esc or Ctrl+C rather than
+// confirming the selection with Enter
+func (p *SelectionPage) WasCancelled() bool {
+	return p.cancelled
+}
+
 // Update handles messages and updates the page
 func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -163,8 +245,24 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		p.searchBox = p.searchBox.SetWidth(msg.Width - 4)
 		p.storyList = p.storyList.SetSize(msg.Width, msg.Height-10) // Adjust for search box and status bar
 		p.statusBar = p.statusBar.SetWidth(msg.Width)
+		p.legend = p.legend.SetSize(msg.Width, msg.Height)
 		
 	case tea.KeyMsg:
+		// The legend takes every key until it is closed
+		if p.showLegend {
+			if key.Matches(msg, p.keyMap.Help) || key.Matches(msg, p.keyMap.Quit) {
+				p.showLegend = false
+				p.needsRender = true
+			}
+			return p, nil
+		}
+		
+		// Any key press dismisses a flashed status message
+		if p.state.StatusMessage != "" {
+			p.state.StatusMessage = ""
+			p.needsRender = true
+		}
+		
 		// Handle key presses
 		switch {
 		case p.state.SearchFocused:
@@ -185,12 +283,25 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					// If search is already empty, quit the application
 					p.quitting = true
+					p.cancelled = true
 					p.needsRender = true
 					return p, tea.Quit
 				}
 			
+			case key.Matches(msg, p.keyMap.HistoryPrev) && (p.searchBox.Value() == "" || p.history.Browsing()):
+				// Recall an older query, starting from an empty search box like a shell
+				if query, ok := p.history.Previous(); ok {
+					cmds = append(cmds, p.recallSearch(query))
+				}
+				
+			case key.Matches(msg, p.keyMap.HistoryNext) && p.history.Browsing():
+				// Recall a newer query, ending with an empty search box
+				query, _ := p.history.Next()
+				cmds = append(cmds, p.recallSearch(query))
+				
 			case key.Matches(msg, p.keyMap.Tab):
 				// Switch to list mode
+				p.history.Add(p.searchBox.Value())
 				p.state.FocusList()
 				p.searchBox = p.searchBox.Blur()
 				p.storyList = p.storyList.Focus()
@@ -198,6 +309,7 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				
 			case key.Matches(msg, p.keyMap.Done):
 				// Apply search and switch to list mode
+				p.history.Add(p.searchBox.Value())
 				p.state.FocusList()
 				p.searchBox = p.searchBox.Blur()
 				p.storyList = p.storyList.Focus()
@@ -209,6 +321,12 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.needsRender = true
 				cmds = append(cmds, p.updateResults())
 				
+			case key.Matches(msg, p.keyMap.ToggleSort):
+				// Toggle sorting the most recently updated stories first
+				p.state.ToggleSortMode()
+				p.needsRender = true
+				cmds = append(cmds, p.updateResults())
+				
 			case key.Matches(msg, p.keyMap.Clear):
 				// Clear search text
 				p.searchBox = p.searchBox.SetValue("")
@@ -216,8 +334,8 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, p.updateResults())
 				
 			case key.Matches(msg, p.keyMap.Help):
-				// Toggle help display
-				p.statusBar = p.statusBar.ToggleHelp()
+				// Show the keybinding legend over the page
+				p.showLegend = true
 				p.needsRender = true
 				
 			default:
@@ -230,6 +348,7 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				
 				// Update results if search text changed
 				if p.state.FilterText != p.searchBox.Value() {
+					p.history.StopBrowsing()
 					cmds = append(cmds, p.updateResults())
 					p.needsRender = true
 				}
@@ -241,6 +360,7 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, p.keyMap.Quit):
 				// Quit the application
 				p.quitting = true
+				p.cancelled = true
 				p.needsRender = true
 				return p, tea.Quit
 				
@@ -252,7 +372,11 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.needsRender = true
 				
 			case key.Matches(msg, p.keyMap.Select):
-				// Toggle selection of current item
+				// Toggle selection of current item, refusing new selections past the limit
+				if item, ok := p.storyList.CurrentItem(); ok && !item.IsSelected && !p.state.CanSelect(1) {
+					p.flashSelectionLimit()
+					break
+				}
 				var id string
 				p.storyList, id = p.storyList.ToggleSelection()
 				if id != "" {
@@ -260,6 +384,29 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					p.needsRender = true
 				}
 				
+			case key.Matches(msg, p.keyMap.ToggleDetails):
+				// Switch between compact and detailed list items
+				p.storyList = p.storyList.ToggleDetails()
+				p.needsRender = true
+				
+			case key.Matches(msg, p.keyMap.SelectAll):
+				// Select every story matching the current filter, unless that exceeds the limit
+				if !p.state.CanSelect(p.unselectedVisibleCount()) {
+					p.flashSelectionLimit()
+					break
+				}
+				var ids []string
+				p.storyList, ids = p.storyList.SetAllSelected(true)
+				p.state.SetSelection(ids, true)
+				p.needsRender = true
+				
+			case key.Matches(msg, p.keyMap.DeselectAll):
+				// Deselect every story matching the current filter, keeping hidden selections
+				var ids []string
+				p.storyList, ids = p.storyList.SetAllSelected(false)
+				p.state.SetSelection(ids, false)
+				p.needsRender = true
+				
 			case key.Matches(msg, p.keyMap.Up):
 				// Move cursor up
 				p.storyList = p.storyList.MoveUp()
@@ -286,9 +433,15 @@ func (p *SelectionPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.needsRender = true
 				cmds = append(cmds, p.updateResults())
 				
+			case key.Matches(msg, p.keyMap.ToggleSort):
+				// Toggle sorting the most recently updated stories first
+				p.state.ToggleSortMode()
+				p.needsRender = true
+				cmds = append(cmds, p.updateResults())
+				
 			case key.Matches(msg, p.keyMap.Help):
-				// Toggle help display
-				p.statusBar = p.statusBar.ToggleHelp()
+				// Show the keybinding legend over the page
+				p.showLegend = true
 				p.needsRender = true
 				
 			case key.Matches(msg, p.keyMap.Done):
@@ -310,15 +463,24 @@ func (p *SelectionPage) View() string {
 		return "Loading..."
 	}
 	
-	if p.quitting {
+	if p.cancelled {
 		return "Change request creation canceled by user."
 	}
+	if p.quitting {
+		return ""
+	}
 	
 	// If nothing has changed, return the cached view
 	if !p.needsRender && p.lastView != "" {
 		return p.lastView
 	}
 	
+	if p.showLegend {
+		p.lastView = p.legend.View()
+		p.needsRender = false
+		return p.lastView
+	}
+	
 	var sb strings.Builder
 	
 	// Render search box
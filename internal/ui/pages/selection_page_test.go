@@ -164,6 +164,33 @@ func TestExiting(t *testing.T) {
 
 	// Check if we're quitting
 	assert.True(t, page.quitting)
+	assert.True(t, page.WasCancelled())
+}
+
+// Test that confirming a selection, even an empty one, is not a cancellation
+func TestConfirmIsNotCancelled(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+
+	// Switch to the list and confirm without selecting anything
+	model, _ := page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page = model.(*SelectionPage)
+	model, cmd := page.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	page = model.(*SelectionPage)
+
+	assert.NotNil(t, cmd)
+	assert.True(t, page.quitting)
+	assert.False(t, page.WasCancelled())
+	assert.Empty(t, page.GetSelected())
+
+	// Ctrl+C from the list cancels
+	page = New(getTestStories(), false)
+	page.Init()
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page = model.(*SelectionPage)
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	page = model.(*SelectionPage)
+	assert.True(t, page.WasCancelled())
 }
 
 // Test auto-focus first result after search
@@ -465,4 +492,268 @@ func TestHelpToggle(t *testing.T) {
 		initialView != toggledView || 
 		finalView != toggledView,
 		"Toggling help should cause a visible difference in the UI")
-} 
\ No newline at end of file
+}
+
+// Test the keybinding legend shown with ?
+func TestHelpLegend(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+	page.Update(tea.WindowSizeMsg{Width: 100, Height: 50})
+
+	// Open the legend from the list
+	page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	view := page.View()
+
+	// Every binding is listed with its description, over the list
+	for _, section := range page.keyMap.HelpSections() {
+		assert.Contains(t, view, section.Title)
+		for _, binding := range section.Bindings {
+			assert.Contains(t, view, binding.Help().Key)
+			assert.Contains(t, view, binding.Help().Desc)
+		}
+	}
+	assert.NotContains(t, view, "Add login functionality")
+
+	// Other keys are ignored while the legend is shown
+	page.Update(tea.KeyMsg{Type: tea.KeySpace})
+	assert.Empty(t, page.GetSelected())
+
+	// Esc closes the legend without quitting
+	_, cmd := page.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.False(t, page.WasCancelled())
+	assert.Contains(t, page.View(), "Add login functionality")
+
+	// ? closes it too
+	page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	assert.Contains(t, page.View(), "Keyboard shortcuts")
+	page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	assert.NotContains(t, page.View(), "Keyboard shortcuts")
+} 
+// Test recalling previous queries from the search history
+func TestSearchHistoryRecall(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+
+	// Apply a search, which records it in the history
+	page.searchBox = page.searchBox.SetValue("login")
+	page.updateResults()
+	model, _ := page.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []string{"login"}, page.SearchHistory().Entries())
+
+	// Go back to an empty search box
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page = model.(*SelectionPage)
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	page = model.(*SelectionPage)
+	assert.Equal(t, "", page.searchBox.Value())
+
+	// Up recalls the previous query and filters the list
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyUp})
+	page = model.(*SelectionPage)
+	assert.Equal(t, "login", page.searchBox.Value())
+	assert.NotContains(t, page.View(), "Integrate payment provider")
+
+	// Down walks past the newest query back to an empty search
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page = model.(*SelectionPage)
+	assert.Equal(t, "", page.searchBox.Value())
+}
+
+// Test selecting and deselecting all visible stories
+func TestSelectAllVisible(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+
+	// Select the payment story, then narrow the list to the login story
+	page.state.ToggleSelection("docs/user-stories/payment/01-integrate-payment-provider.md")
+	page.searchBox = page.searchBox.SetValue("login")
+	page.updateResults()
+
+	// Switch focus to list
+	model, _ := page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page = model.(*SelectionPage)
+
+	// Select all visible stories
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []int{0, 1}, page.GetSelected())
+
+	// Deselect all visible stories; the hidden payment selection is kept
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []int{1}, page.GetSelected())
+
+	// Select again, then deselect with Ctrl+U
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	page = model.(*SelectionPage)
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []int{1}, page.GetSelected())
+}
+
+// Test that "a" is typed into the search box in search mode
+func TestSelectAllIgnoredInSearchMode(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+
+	model, _ := page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	page = model.(*SelectionPage)
+
+	assert.Empty(t, page.GetSelected())
+	assert.Equal(t, "a", page.searchBox.Value())
+}
+
+// Test cycling the implemented stories through hidden, shown and dimmed
+func TestCycleImplementationDisplayMode(t *testing.T) {
+	// Put the implemented story first so dimming has to move it
+	stories := getTestStories()
+	stories = append([]models.UserStory{stories[2]}, stories[:2]...)
+
+	page := New(stories, false)
+	page.Init()
+	assert.Contains(t, page.View(), "Filter: Unimplemented")
+	assert.Len(t, page.state.VisibleStories, 2)
+
+	// Ctrl+A shows implemented stories in their original position
+	model, _ := page.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	page = model.(*SelectionPage)
+	assert.Contains(t, page.View(), "Filter: All")
+	assert.Equal(t, "Export user data to CSV", page.state.VisibleStories[0].Title)
+
+	// Ctrl+A again keeps them visible, after the unimplemented ones
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	page = model.(*SelectionPage)
+	assert.Contains(t, page.View(), "Filter: All (implemented last)")
+	assert.Len(t, page.state.VisibleStories, 3)
+	assert.Equal(t, "Export user data to CSV", page.state.VisibleStories[2].Title)
+
+	// A third Ctrl+A hides them again
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	page = model.(*SelectionPage)
+	assert.Contains(t, page.View(), "Filter: Unimplemented")
+	assert.Len(t, page.state.VisibleStories, 2)
+}
+
+// Test sorting the most recently updated stories first
+func TestToggleSortMode(t *testing.T) {
+	stories := getTestStories()
+	stories[0].LastUpdated = time.Now().Add(-48 * time.Hour)
+	stories[1].LastUpdated = time.Now()
+
+	page := New(stories, false)
+	page.Init()
+	assert.Equal(t, "Add login functionality", page.state.VisibleStories[0].Title)
+	assert.NotContains(t, page.View(), "Sort:")
+
+	model, _ := page.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	page = model.(*SelectionPage)
+	assert.Contains(t, page.View(), "Sort: Recent")
+	assert.Equal(t, "Integrate payment provider", page.state.VisibleStories[0].Title)
+
+	// Recency filters apply on top of the sort
+	page.searchBox = page.searchBox.SetValue("updated:<1d")
+	page.updateResults()
+	assert.Len(t, page.state.VisibleStories, 1)
+}
+
+// Test switching the list between compact and detailed items
+func TestToggleDetails(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+	model, _ := page.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	page = model.(*SelectionPage)
+	assert.NotContains(t, page.View(), "log in with their credentials")
+
+	// "d" is typed into the search box in search mode
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	page = model.(*SelectionPage)
+	assert.False(t, page.storyList.Detailed())
+	page.searchBox = page.searchBox.SetValue("")
+	page.updateResults()
+
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page = model.(*SelectionPage)
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	page = model.(*SelectionPage)
+	assert.True(t, page.storyList.Detailed())
+	assert.Contains(t, page.View(), "log in with their credentials")
+	assert.Contains(t, page.View(), "d: descriptions")
+
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	page = model.(*SelectionPage)
+	assert.NotContains(t, page.View(), "log in with their credentials")
+}
+
+// Test that the status bar lists every component of the search query
+func TestStatusBarShowsActiveFilters(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.Init()
+	model, _ := page.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+	page = model.(*SelectionPage)
+
+	page.searchBox = page.searchBox.SetValue("login -csv tag:security")
+	page.updateResults()
+	assert.Contains(t, page.View(), "| login | -csv | tag:security | Filter: Unimplemented")
+
+	page.searchBox = page.searchBox.SetValue("")
+	page.updateResults()
+	assert.NotContains(t, page.View(), "tag:security |")
+}
+
+// Test that selections past the limit are refused with a status message
+func TestMaxSelections(t *testing.T) {
+	page := New(getTestStories(), false)
+	page.SetMaxSelections(1)
+	page.Init()
+	model, _ := page.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+	page = model.(*SelectionPage)
+
+	// Switch focus to list and select the first story
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyTab})
+	page = model.(*SelectionPage)
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeySpace})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []int{0}, page.GetSelected())
+
+	// A second selection is refused
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page = model.(*SelectionPage)
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeySpace})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []int{0}, page.GetSelected())
+	assert.Contains(t, page.View(), "Limit reached (max 1)")
+	assert.Contains(t, page.View(), "1/1 selected")
+
+	// So is selecting everything visible
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	page = model.(*SelectionPage)
+	assert.Equal(t, []int{0}, page.GetSelected())
+
+	// Deselecting still works and clears the message
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeyUp})
+	page = model.(*SelectionPage)
+	assert.NotContains(t, page.View(), "Limit reached (max 1)")
+	model, _ = page.Update(tea.KeyMsg{Type: tea.KeySpace})
+	page = model.(*SelectionPage)
+	assert.Empty(t, page.GetSelected())
+}
+
+// Test a custom status line template
+func TestSetStatusTemplate(t *testing.T) {
+	page := New(getTestStories(), true)
+	page.Init()
+	page.SetStatusTemplate("${selected} picked, ${visible} of ${total} shown (${mode})")
+
+	view := page.View()
+	assert.Contains(t, view, "0 picked, 3 of 3 shown (All)")
+	assert.NotContains(t, view, "visible /", "the default status line should be replaced")
+
+	// An empty template restores the default status line
+	page.SetStatusTemplate("")
+	view = page.View()
+	assert.Contains(t, view, "0 selected")
+	assert.Contains(t, view, "3 visible / 3 total")
+}
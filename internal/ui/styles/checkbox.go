@@ -0,0 +1,69 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package styles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckboxGlyphs are the marks shown before each story of the picker, for
+// stories that are selected and for the others. They only change the text of
+// the checkbox; the colors of selected and implemented stories come from the
+// theme.
+type CheckboxGlyphs struct {
+	Checked   string
+	Unchecked string
+}
+
+// The checkbox presets ParseCheckboxGlyphs accepts by name
+var (
+	// DefaultCheckbox is the original "[✓]" and "[ ]"
+	DefaultCheckbox = CheckboxGlyphs{Checked: "[✓]", Unchecked: "[ ]"}
+	// ASCIICheckbox only uses ASCII characters, for fonts without "✓"
+	ASCIICheckbox = CheckboxGlyphs{Checked: "[x]", Unchecked: "[ ]"}
+	// RadioCheckbox looks like radio buttons
+	RadioCheckbox = CheckboxGlyphs{Checked: "(*)", Unchecked: "( )"}
+	// EmojiCheckbox uses emoji, for terminals with good Unicode support
+	EmojiCheckbox = CheckboxGlyphs{Checked: "✅", Unchecked: "⬜"}
+)
+
+// ParseCheckboxGlyphs parses the name of a preset, "default", "ascii",
+// "radio" or "emoji", or custom glyphs given as "checked,unchecked", e.g.
+// "[+],[-]". An empty value is the default preset.
+func ParseCheckboxGlyphs(value string) (CheckboxGlyphs, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "default":
+		return DefaultCheckbox, nil
+	case "ascii":
+		return ASCIICheckbox, nil
+	case "radio":
+		return RadioCheckbox, nil
+	case "emoji":
+		return EmojiCheckbox, nil
+	}
+
+	checked, unchecked, ok := strings.Cut(value, ",")
+	checked, unchecked = strings.TrimSpace(checked), strings.TrimSpace(unchecked)
+	if !ok || checked == "" || unchecked == "" || strings.Contains(unchecked, ",") {
+		return DefaultCheckbox, fmt.Errorf("unknown checkbox style %q, use default, ascii, radio, emoji or \"checked,unchecked\"", value)
+	}
+	return CheckboxGlyphs{Checked: checked, Unchecked: unchecked}, nil
+}
+
+// currentCheckbox holds the glyphs of DefaultStyles
+var currentCheckbox = DefaultCheckbox
+
+// SetCheckboxGlyphs sets the checkbox glyphs of the styles returned by
+// DefaultStyles and StylesFor
+func SetCheckboxGlyphs(glyphs CheckboxGlyphs) {
+	currentCheckbox = glyphs
+}
+
+// CurrentCheckboxGlyphs returns the glyphs set with SetCheckboxGlyphs
+func CurrentCheckboxGlyphs() CheckboxGlyphs {
+	return currentCheckbox
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package styles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckboxGlyphs(t *testing.T) {
+	tests := map[string]CheckboxGlyphs{
+		"":         DefaultCheckbox,
+		"default":  DefaultCheckbox,
+		" ASCII ":  ASCIICheckbox,
+		"radio":    RadioCheckbox,
+		"emoji":    EmojiCheckbox,
+		"[+], [-]": {Checked: "[+]", Unchecked: "[-]"},
+		"(*),( )":  {Checked: "(*)", Unchecked: "( )"},
+	}
+	for value, want := range tests {
+		got, err := ParseCheckboxGlyphs(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"square", "[+],", ",[-]", "a,b,c"} {
+		_, err := ParseCheckboxGlyphs(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestSetCheckboxGlyphs(t *testing.T) {
+	defer SetCheckboxGlyphs(DefaultCheckbox)
+
+	SetCheckboxGlyphs(ASCIICheckbox)
+	for _, theme := range []Theme{DefaultTheme, MonochromeTheme} {
+		s := StylesFor(theme)
+		assert.Equal(t, "[x]", s.GetCheckbox(true), theme.String())
+		assert.Equal(t, "[ ]", s.GetCheckbox(false), theme.String())
+	}
+
+	// The glyphs do not change the styling of selected stories
+	assert.Equal(t, StylesFor(DefaultTheme).Selected.GetBackground(), PaletteFor(DefaultTheme).Selected)
+
+	// Host applications may also set the glyphs of their own styles
+	s := DefaultStyles()
+	s.CheckboxGlyphs = EmojiCheckbox
+	assert.Equal(t, "✅", s.GetCheckbox(true))
+	assert.Equal(t, "[x]", DefaultStyles().GetCheckbox(true))
+}
@@ -28,6 +28,8 @@ type Styles struct {
 	SearchCursor lipgloss.Style
 	SearchText   lipgloss.Style
 	SearchPlaceholder lipgloss.Style
+	SearchDimmed lipgloss.Style // Label and query of the unfocused search box
+	SearchHint   lipgloss.Style // Instruction shown in the empty unfocused search box
 	
 	StatusBar    lipgloss.Style
 	Checkbox     lipgloss.Style
@@ -37,83 +39,100 @@ type Styles struct {
 	Container    lipgloss.Style
 	Border       lipgloss.Style
 	FocusedBorder lipgloss.Style
+
+	// CursorMarker starts the line of the story under the cursor
+	CursorMarker string
+	// CheckboxGlyphs are the checkbox texts of selected and other stories
+	CheckboxGlyphs CheckboxGlyphs
 }
 
-// DefaultStyles returns the default styles
+// DefaultStyles returns the styles of the theme set with SetTheme
 func DefaultStyles() *Styles {
-	return &Styles{
+	return StylesFor(currentTheme)
+}
+
+// StylesFor returns the styles of a theme
+func StylesFor(theme Theme) *Styles {
+	p := PaletteFor(theme)
+	s := &Styles{
 		// General text styles
 		Title: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
+			Foreground(p.Accent).
 			Bold(true),
 			
 		Selected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("15")). // Bright white
-			Background(lipgloss.Color("4")). // Dark blue
+			Foreground(p.Strong).
+			Background(p.Selected).
 			Bold(true),
 			
 		Highlighted: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("15")). // White text
-			Background(lipgloss.Color("8")). // Dark gray background
+			Foreground(p.Strong).
+			Background(p.Highlight).
 			Bold(false),
 			
 		Normal: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252")),
+			Foreground(p.Text),
 			
 		Implemented: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")), // Dim gray
+			Foreground(p.Muted),
 			
 		Unimplemented: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252")), // Bright white
+			Foreground(p.Text),
 			
 		Error: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")). // Bright red
+			Foreground(p.Error).
 			Bold(true),
 			
 		Subtle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")), // Dim gray
+			Foreground(p.Muted),
 			
 		Success: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("78")), // Green
+			Foreground(p.Success),
 			
 		// Component styles
 		SearchBox: lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()). // Use rounded borders
-			BorderForeground(lipgloss.Color("205")). // Pink border
+			BorderForeground(p.Accent).
 			Padding(0, 1).
 			MarginTop(1).
 			MarginBottom(1),
 			
 		SearchLabel: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")). // Pink
+			Foreground(p.Accent).
 			Bold(true).
 			MarginBottom(0).
 			MarginTop(1),
 			
 		SearchCursor: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
-			Background(lipgloss.Color("236")).
+			Foreground(p.Accent).
+			Background(p.Cursor).
 			Bold(true),
 			
 		SearchText: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")). // Pink
+			Foreground(p.Accent).
 			Bold(true),
 			
 		SearchPlaceholder: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")). // Gray
+			Foreground(p.Muted).
 			Italic(true),
 			
+		SearchDimmed: lipgloss.NewStyle().
+			Foreground(p.Muted),
+			
+		SearchHint: lipgloss.NewStyle().
+			Foreground(p.Faint),
+			
 		StatusBar: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("15")). // Bright white
-			Background(lipgloss.Color("25")). // Blue background
+			Foreground(p.Strong).
+			Background(p.StatusBar).
 			Bold(true).
 			Padding(0, 1),
 			
 		Checkbox: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")),
+			Foreground(p.Muted),
 			
 		CheckboxChecked: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("43")). // Green
+			Foreground(p.Checked).
 			Bold(true),
 			
 		// Containers
@@ -122,11 +141,28 @@ func DefaultStyles() *Styles {
 			
 		Border: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("205")),
+			BorderForeground(p.Accent),
 			
 		FocusedBorder: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")), // Pink color to match search focus
+			Foreground(p.Accent), // Matches the search focus
+
+		CursorMarker: " ",
+
+		CheckboxGlyphs: currentCheckbox,
+	}
+
+	// Without colors, states are told apart by text attributes and the
+	// story under the cursor gets a marker of its own
+	if theme == MonochromeTheme {
+		s.Selected = s.Selected.Underline(true)
+		s.Highlighted = s.Highlighted.Reverse(true)
+		s.StatusBar = s.StatusBar.Reverse(true)
+		s.SearchCursor = s.SearchCursor.Reverse(true)
+		s.Implemented = s.Implemented.Faint(true)
+		s.Subtle = s.Subtle.Faint(true)
+		s.CursorMarker = ">"
 	}
+	return s
 }
 
 // ItemStyles returns styles for specific indices
@@ -145,12 +181,12 @@ func (s *Styles) ItemStyles(selected, implemented, focused bool) lipgloss.Style
 	}
 }
 
-// GetCheckbox returns a styled checkbox based on state
+// GetCheckbox returns the checkbox glyph of a state
 func (s *Styles) GetCheckbox(checked bool) string {
 	if checked {
-		return "[✓]"
+		return s.CheckboxGlyphs.Checked
 	}
-	return "[ ]"
+	return s.CheckboxGlyphs.Unchecked
 }
 
 // GetImplementationStatus returns a styled implementation status
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package styles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme selects the colors of the interactive screens
+type Theme int
+
+const (
+	// DefaultTheme is the original pink and blue palette
+	DefaultTheme Theme = iota
+	// HighContrastTheme uses bright colors on dark backgrounds and tells
+	// states apart with blue and orange rather than red and green
+	HighContrastTheme
+	// MonochromeTheme uses no colors at all; states are shown with bold,
+	// underlined and reversed text and with text markers
+	MonochromeTheme
+)
+
+// String returns the name ParseTheme accepts for the theme
+func (t Theme) String() string {
+	switch t {
+	case HighContrastTheme:
+		return "high-contrast"
+	case MonochromeTheme:
+		return "monochrome"
+	default:
+		return "default"
+	}
+}
+
+// ParseTheme parses a theme name. An empty value is the default theme.
+func ParseTheme(value string) (Theme, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "default":
+		return DefaultTheme, nil
+	case "high-contrast", "contrast":
+		return HighContrastTheme, nil
+	case "monochrome", "mono", "none":
+		return MonochromeTheme, nil
+	default:
+		return DefaultTheme, fmt.Errorf("unknown theme %q, use default, high-contrast or monochrome", value)
+	}
+}
+
+// currentTheme is the theme of DefaultStyles and CurrentPalette
+var currentTheme = DefaultTheme
+
+// SetTheme sets the theme of the interactive screens. The monochrome theme
+// also turns off the colors of every other output.
+func SetTheme(theme Theme) {
+	currentTheme = theme
+	if theme == MonochromeTheme {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// CurrentTheme returns the theme set with SetTheme
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// Palette holds the colors of a theme, by role
+type Palette struct {
+	Accent    lipgloss.TerminalColor // Titles, borders and the search box
+	Text      lipgloss.TerminalColor // Regular text
+	Strong    lipgloss.TerminalColor // Text on colored backgrounds
+	Muted     lipgloss.TerminalColor // Implemented stories, hints, placeholders
+	Faint     lipgloss.TerminalColor // Instructions in unfocused fields
+	Success   lipgloss.TerminalColor
+	Error     lipgloss.TerminalColor
+	Info      lipgloss.TerminalColor // The active field of forms
+	Checked   lipgloss.TerminalColor // Checked checkboxes
+	Selected  lipgloss.TerminalColor // Background of selected stories
+	Highlight lipgloss.TerminalColor // Background of the story under the cursor
+	Cursor    lipgloss.TerminalColor // Background of the search cursor
+	StatusBar lipgloss.TerminalColor // Background of the status bar
+}
+
+// PaletteFor returns the colors of a theme; the monochrome theme has none
+func PaletteFor(theme Theme) Palette {
+	switch theme {
+	case HighContrastTheme:
+		return Palette{
+			Accent:    lipgloss.Color("226"), // Yellow
+			Text:      lipgloss.Color("15"),
+			Strong:    lipgloss.Color("15"),
+			Muted:     lipgloss.Color("250"),
+			Faint:     lipgloss.Color("246"),
+			Success:   lipgloss.Color("39"),  // Blue
+			Error:     lipgloss.Color("208"), // Orange
+			Info:      lipgloss.Color("51"),  // Cyan
+			Checked:   lipgloss.Color("39"),
+			Selected:  lipgloss.Color("19"), // Deep blue
+			Highlight: lipgloss.Color("238"),
+			Cursor:    lipgloss.Color("238"),
+			StatusBar: lipgloss.Color("19"),
+		}
+	case MonochromeTheme:
+		none := lipgloss.NoColor{}
+		return Palette{
+			Accent: none, Text: none, Strong: none, Muted: none, Faint: none,
+			Success: none, Error: none, Info: none, Checked: none,
+			Selected: none, Highlight: none, Cursor: none, StatusBar: none,
+		}
+	default:
+		return Palette{
+			Accent:    lipgloss.Color("205"), // Pink
+			Text:      lipgloss.Color("252"),
+			Strong:    lipgloss.Color("15"),  // Bright white
+			Muted:     lipgloss.Color("240"), // Dim gray
+			Faint:     lipgloss.Color("237"),
+			Success:   lipgloss.Color("78"),  // Green
+			Error:     lipgloss.Color("196"), // Bright red
+			Info:      lipgloss.Color("12"),
+			Checked:   lipgloss.Color("43"), // Green
+			Selected:  lipgloss.Color("4"),  // Dark blue
+			Highlight: lipgloss.Color("8"),  // Dark gray
+			Cursor:    lipgloss.Color("236"),
+			StatusBar: lipgloss.Color("25"), // Blue
+		}
+	}
+}
+
+// CurrentPalette returns the colors of the theme set with SetTheme
+func CurrentPalette() Palette {
+	return PaletteFor(currentTheme)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package styles
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTheme(t *testing.T) {
+	tests := map[string]Theme{
+		"":              DefaultTheme,
+		"default":       DefaultTheme,
+		"High-Contrast": HighContrastTheme,
+		"monochrome":    MonochromeTheme,
+		" mono ":        MonochromeTheme,
+	}
+	for value, want := range tests {
+		got, err := ParseTheme(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+		if value != "" {
+			// Every theme name round-trips
+			parsed, _ := ParseTheme(got.String())
+			assert.Equal(t, got, parsed)
+		}
+	}
+
+	_, err := ParseTheme("rainbow")
+	assert.Error(t, err)
+}
+
+func TestStylesFor_Monochrome(t *testing.T) {
+	s := StylesFor(MonochromeTheme)
+
+	// No colors at all
+	assert.Equal(t, lipgloss.NoColor{}, s.Title.GetForeground())
+	assert.Equal(t, lipgloss.NoColor{}, s.Selected.GetBackground())
+	assert.Equal(t, lipgloss.NoColor{}, s.Error.GetForeground())
+
+	// States stay distinguishable through text attributes and markers
+	assert.True(t, s.Selected.GetUnderline())
+	assert.True(t, s.Highlighted.GetReverse())
+	assert.Equal(t, ">", s.CursorMarker)
+	assert.Equal(t, "[✓]", s.GetCheckbox(true))
+	assert.Equal(t, "[ ]", s.GetCheckbox(false))
+}
+
+func TestStylesFor_ColorThemes(t *testing.T) {
+	for _, theme := range []Theme{DefaultTheme, HighContrastTheme} {
+		s := StylesFor(theme)
+		assert.NotEqual(t, lipgloss.NoColor{}, s.Title.GetForeground(), theme.String())
+		assert.NotEqual(t, s.Success.GetForeground(), s.Error.GetForeground(), theme.String())
+		assert.Equal(t, " ", s.CursorMarker, theme.String())
+	}
+
+	// The high-contrast theme avoids red and green for success and errors
+	highContrast := PaletteFor(HighContrastTheme)
+	assert.NotEqual(t, PaletteFor(DefaultTheme).Success, highContrast.Success)
+	assert.NotEqual(t, PaletteFor(DefaultTheme).Error, highContrast.Error)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+// FormatSelectionSummary renders the stories chosen in the picker, one
+// "- title (path)" line each, so callers can print the result once the
+// alt-screen is gone. Indices outside stories are ignored.
+func FormatSelectionSummary(stories []models.UserStory, selected []int) string {
+	var lines []string
+	for _, idx := range selected {
+		if idx < 0 || idx >= len(stories) {
+			continue
+		}
+		story := stories[idx]
+		lines = append(lines, fmt.Sprintf("- %s (%s)", story.Title, story.FilePath))
+	}
+
+	if len(lines) == 0 {
+		return "No user stories selected."
+	}
+
+	noun := "stories"
+	if len(lines) == 1 {
+		noun = "story"
+	}
+	return fmt.Sprintf("Selected %d user %s:\n%s", len(lines), noun, strings.Join(lines, "\n"))
+}
+
+// FormatSelectionAsPaths lists the file paths of the chosen stories for other
+// tools to consume. Paths are newline-delimited and shell-quoted when needed,
+// which suits plain xargs, or written as-is and NUL-terminated for xargs -0
+// when nullDelimited is set. Indices outside stories are ignored.
+func FormatSelectionAsPaths(stories []models.UserStory, selected []int, nullDelimited bool) string {
+	var b strings.Builder
+	for _, idx := range selected {
+		if idx < 0 || idx >= len(stories) {
+			continue
+		}
+		path := stories[idx].FilePath
+		if nullDelimited {
+			b.WriteString(path)
+			b.WriteByte(0)
+			continue
+		}
+		b.WriteString(shellQuote(path))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// shellQuote wraps s in single quotes unless it only holds characters that
+// are safe unquoted in a POSIX shell
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./+,:@%=", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/models"
+)
+
+func TestFormatSelectionSummary(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login", FilePath: "docs/user-stories/01-login.md"},
+		{Title: "Logout", FilePath: "docs/user-stories/02-logout.md"},
+		{Title: "Signup", FilePath: "docs/user-stories/03-signup.md"},
+	}
+
+	assert.Equal(t,
+		"Selected 2 user stories:\n- Signup (docs/user-stories/03-signup.md)\n- Login (docs/user-stories/01-login.md)",
+		FormatSelectionSummary(stories, []int{2, 0}))
+
+	assert.Equal(t,
+		"Selected 1 user story:\n- Logout (docs/user-stories/02-logout.md)",
+		FormatSelectionSummary(stories, []int{1, 7, -1}))
+
+	assert.Equal(t, "No user stories selected.", FormatSelectionSummary(stories, nil))
+}
+
+func TestFormatSelectionAsPaths(t *testing.T) {
+	stories := []models.UserStory{
+		{Title: "Login", FilePath: "docs/user-stories/01-login.md"},
+		{Title: "Logout", FilePath: "docs/user-stories/my stories/02-logout.md"},
+		{Title: "Signup", FilePath: "docs/user-stories/it's-03-signup.md"},
+	}
+
+	assert.Equal(t,
+		"docs/user-stories/01-login.md\n'docs/user-stories/my stories/02-logout.md'\n'docs/user-stories/it'\\''s-03-signup.md'\n",
+		FormatSelectionAsPaths(stories, []int{0, 1, 2}, false))
+
+	assert.Equal(t,
+		"docs/user-stories/my stories/02-logout.md\x00docs/user-stories/01-login.md\x00",
+		FormatSelectionAsPaths(stories, []int{1, 9, 0, -1}, true))
+
+	assert.Equal(t, "", FormatSelectionAsPaths(stories, nil, false))
+}
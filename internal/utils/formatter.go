@@ -8,12 +8,18 @@ package utils
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/user-story-matrix/usm/internal/metadata"
 	"github.com/user-story-matrix/usm/internal/models"
 )
 
+// hashDisplayLength is the number of hash characters shown in tables
+const hashDisplayLength = 8
+
 // Colors and styles
 var (
 	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
@@ -55,9 +61,9 @@ func FormatUserStoryDetail(story models.UserStory) string {
 		builder.WriteString(dateStyle.Render(fmt.Sprintf("Updated: %s\n", story.LastUpdated.Format("2006-01-02 15:04:05"))))
 	}
 
-	// Content preview (first few lines)
-	if story.Content != "" {
-		lines := strings.Split(story.Content, "\n")
+	// Content preview (first few lines, without the front matter)
+	if body := story.Body(); body != "" {
+		lines := strings.Split(body, "\n")
 		contentPreview := lines
 		if len(lines) > 10 {
 			contentPreview = lines[:10]
@@ -148,6 +154,75 @@ func FormatChangeRequestTable(requests []models.ChangeRequest) ([]string, [][]st
 	return headers, rows
 }
 
+// FormatContentChangeTable formats content hash changes as a table for the CLI.
+// Rows are sorted by path so the output is stable across runs.
+func FormatContentChangeTable(changes metadata.ContentChangeMap) ([]string, [][]string) {
+	headers := []string{"Path", "Old Hash", "New Hash", "Status"}
+
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	rows := make([][]string, len(paths))
+	for i, path := range paths {
+		change := changes[path]
+
+		status := "metadata only"
+		if change.Changed {
+			status = "content changed"
+		}
+
+		rows[i] = []string{
+			shortPath(path),
+			truncateHash(change.OldHash),
+			truncateHash(change.NewHash),
+			status,
+		}
+	}
+
+	return headers, rows
+}
+
+// FormatReferenceCountTable formats the number of references updated in each
+// change request as a table for the CLI, sorted by path
+func FormatReferenceCountTable(counts map[string]int) ([]string, [][]string) {
+	headers := []string{"Change Request", "References Updated"}
+
+	paths := make([]string, 0, len(counts))
+	for path := range counts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	rows := make([][]string, len(paths))
+	for i, path := range paths {
+		rows[i] = []string{shortPath(path), strconv.Itoa(counts[path])}
+	}
+
+	return headers, rows
+}
+
+// FormatReferenceChange describes the update of a user story reference for a
+// human reviewing it, e.g.
+// `"User login" (docs/user-stories/auth/login.md): body changed (1a2b3c4d → 5e6f7a8b)`
+func FormatReferenceChange(change metadata.ReferenceChange) string {
+	return fmt.Sprintf("%q (%s): body changed (%s → %s)",
+		change.Title, change.FilePath, truncateHash(change.OldHash), truncateHash(change.NewHash))
+}
+
+// truncateHash shortens a hash for display, using a dash for missing hashes
+func truncateHash(hash string) string {
+	if hash == "" {
+		return "-"
+	}
+	if len(hash) > hashDisplayLength {
+		return hash[:hashDisplayLength]
+	}
+	return hash
+}
+
 // shortPath returns a shortened version of a file path for display
 func shortPath(path string) string {
 	// If the path is not too long, return it as is
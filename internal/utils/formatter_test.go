@@ -0,0 +1,76 @@
+// Copyright (c) 2025 User Story Matrix
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/user-story-matrix/usm/internal/metadata"
+)
+
+func TestFormatContentChangeTable(t *testing.T) {
+	changes := metadata.ContentChangeMap{
+		"docs/user-stories/b.md": {
+			FilePath: "docs/user-stories/b.md",
+			OldHash:  "abcdef0123456789",
+			NewHash:  "abcdef0123456789",
+			Changed:  false,
+		},
+		"docs/user-stories/a.md": {
+			FilePath: "docs/user-stories/a.md",
+			OldHash:  "",
+			NewHash:  "0123456789abcdef",
+			Changed:  true,
+		},
+	}
+
+	headers, rows := FormatContentChangeTable(changes)
+
+	assert.Equal(t, []string{"Path", "Old Hash", "New Hash", "Status"}, headers)
+	assert.Equal(t, [][]string{
+		{"docs/user-stories/a.md", "-", "01234567", "content changed"},
+		{"docs/user-stories/b.md", "abcdef01", "abcdef01", "metadata only"},
+	}, rows)
+}
+
+func TestFormatReferenceCountTable(t *testing.T) {
+	headers, rows := FormatReferenceCountTable(map[string]int{
+		"docs/changes-request/cr2.blueprint.md": 1,
+		"docs/changes-request/cr1.blueprint.md": 2,
+	})
+
+	assert.Equal(t, []string{"Change Request", "References Updated"}, headers)
+	assert.Equal(t, [][]string{
+		{"docs/changes-request/cr1.blueprint.md", "2"},
+		{"docs/changes-request/cr2.blueprint.md", "1"},
+	}, rows)
+}
+
+func TestFormatReferenceChange(t *testing.T) {
+	line := FormatReferenceChange(metadata.ReferenceChange{
+		ChangeRequest: "docs/changes-request/cr1.blueprint.md",
+		Title:         "User login",
+		FilePath:      "docs/user-stories/auth/login.md",
+		OldHash:       "1a2b3c4d5e6f",
+		NewHash:       "5e6f7a8b9c0d",
+	})
+
+	assert.Equal(t, `"User login" (docs/user-stories/auth/login.md): body changed (1a2b3c4d → 5e6f7a8b)`, line)
+}
+
+func TestFormatContentChangeTable_Empty(t *testing.T) {
+	headers, rows := FormatContentChangeTable(metadata.ContentChangeMap{})
+
+	assert.Len(t, headers, 4)
+	assert.Empty(t, rows)
+}
+
+func TestTruncateHash(t *testing.T) {
+	assert.Equal(t, "-", truncateHash(""))
+	assert.Equal(t, "abc", truncateHash("abc"))
+	assert.Equal(t, "12345678", truncateHash("123456789"))
+}
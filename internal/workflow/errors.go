@@ -16,6 +16,8 @@ var (
 	ErrState                 = errors.New("state error")
 	ErrExecution             = errors.New("execution error")
 	ErrValidation            = errors.New("validation error")
+	ErrStepOutputNotFound    = errors.New("step output not found")
+	ErrWorkflowLocked        = errors.New("workflow locked")
 	
 	// Step validation specific errors
 	ErrStepMissingID         = errors.New("step missing ID")
@@ -7,32 +7,73 @@ package workflow
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"strings"
+
+	"github.com/user-story-matrix/usm/internal/config"
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/metadata"
 )
 
 // StepExecutor handles the execution of workflow steps
 type StepExecutor struct {
 	fs FileSystem
 	io UserOutput
+	// inlineUserStories enables resolution of ${user_stories_content}; prompts
+	// relying on the cat-user-stories script are unaffected when disabled
+	inlineUserStories bool
+	// promptsDir holds the prompt files referenced by WorkflowStep.PromptFile
+	promptsDir string
 }
 
 // NewStepExecutor creates a new step executor instance
 func NewStepExecutor(fs FileSystem, io UserOutput) *StepExecutor {
 	return &StepExecutor{
-		fs: fs,
-		io: io,
+		fs:         fs,
+		io:         io,
+		promptsDir: config.PromptsDir(),
 	}
 }
 
+// SetPromptsDir sets the directory the prompt files of steps are read from
+func (e *StepExecutor) SetPromptsDir(dir string) {
+	e.promptsDir = dir
+}
+
+// SetInlineUserStories enables or disables inlining of referenced user stories
+func (e *StepExecutor) SetInlineUserStories(enabled bool) {
+	e.inlineUserStories = enabled
+}
+
 // ExecuteStep executes a workflow step and outputs the processed prompt to stdout.
 // The outputFile parameter is only used for backward compatibility with the existing API,
 // but no file is actually written.
 func (e *StepExecutor) ExecuteStep(changeRequestPath string, step WorkflowStep, outputFile string) (bool, error) {
-	// Print progress message only in debug mode
-	if e.io.IsDebugEnabled() {
+	// Print progress message only in verbose mode
+	if e.io.Verbosity() >= io.VerbosityVerbose {
 		e.io.PrintProgress(fmt.Sprintf(ProgressExecutingStep, step.ID, step.Description))
 	}
 
+	// A prompt file replaces the inline prompt, and a prompt override next to
+	// the change request replaces both
+	step, promptFile, err := e.loadPromptFile(step)
+	if err != nil {
+		e.io.PrintError(err.Error())
+		return false, err
+	}
+	if promptFile != "" && e.io.Verbosity() >= io.VerbosityVerbose {
+		e.io.PrintProgress(fmt.Sprintf(ProgressUsingPromptFile, promptFile))
+	}
+	step, overridePath, err := e.applyPromptOverride(changeRequestPath, step)
+	if err != nil {
+		e.io.PrintError(err.Error())
+		return false, err
+	}
+	if overridePath != "" && e.io.Verbosity() >= io.VerbosityVerbose {
+		e.io.PrintProgress(fmt.Sprintf(ProgressUsingPromptOverride, overridePath))
+	}
+
 	// Validate the prompt for syntax errors
 	if step.Prompt != "" {
 		if err := ValidatePrompt(step.Prompt); err != nil {
@@ -46,10 +87,19 @@ func (e *StepExecutor) ExecuteStep(changeRequestPath string, step WorkflowStep,
 		return false, fmt.Errorf(ErrFileNotFound, changeRequestPath)
 	}
 
-	// Process the prompt with variable interpolation
-	processedPrompt, missingVars := InterpolatePromptWithMissingVars(step.Prompt, PromptVariables{
+	variables := PromptVariables{
 		ChangeRequestFilePath: changeRequestPath,
-	})
+	}
+	if e.inlineUserStories && strings.Contains(step.Prompt, UserStoriesContentVariable) {
+		var warnings []string
+		variables.UserStoriesContent, warnings = e.loadUserStoriesContent(changeRequestPath)
+		for _, warning := range warnings {
+			e.io.PrintWarning(warning)
+		}
+	}
+
+	// Process the prompt with variable interpolation
+	processedPrompt, missingVars := InterpolatePromptWithMissingVars(step.Prompt, variables)
 
 	// Warn about missing variables
 	if len(missingVars) > 0 {
@@ -62,6 +112,175 @@ func (e *StepExecutor) ExecuteStep(changeRequestPath string, step WorkflowStep,
 	return true, nil
 }
 
+// ExplainStep describes what executing step would do without printing or
+// writing anything: the output file, the substituted variables, any prompt
+// warnings, the prompt as a numbered checklist, and the interpolated prompt.
+func (e *StepExecutor) ExplainStep(changeRequestPath string, step WorkflowStep) (string, error) {
+	if !e.fs.Exists(changeRequestPath) {
+		return "", fmt.Errorf(ErrFileNotFound, changeRequestPath)
+	}
+
+	step, promptFile, err := e.loadPromptFile(step)
+	if err != nil {
+		return "", err
+	}
+	step, overridePath, err := e.applyPromptOverride(changeRequestPath, step)
+	if err != nil {
+		return "", err
+	}
+
+	var warnings []string
+	if step.Prompt != "" {
+		if err := ValidatePrompt(step.Prompt); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Prompt validation warning: %v", err))
+		}
+	}
+
+	variables := PromptVariables{
+		ChangeRequestFilePath: changeRequestPath,
+	}
+	if e.inlineUserStories && strings.Contains(step.Prompt, UserStoriesContentVariable) {
+		var loadWarnings []string
+		variables.UserStoriesContent, loadWarnings = e.loadUserStoriesContent(changeRequestPath)
+		warnings = append(warnings, loadWarnings...)
+	}
+
+	processedPrompt, missingVars := InterpolatePromptWithMissingVars(step.Prompt, variables)
+	if len(missingVars) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Undefined variables: %v", missingVars))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Step %s: %s\n\n", step.ID, step.Description))
+	sb.WriteString(fmt.Sprintf("Output file: %s\n", GenerateOutputFilePath(changeRequestPath, step)))
+	if overridePath != "" {
+		sb.WriteString(fmt.Sprintf("Prompt override: %s\n", overridePath))
+	} else if promptFile != "" {
+		sb.WriteString(fmt.Sprintf("Prompt file: %s\n", promptFile))
+	}
+
+	sb.WriteString("Substituted variables:\n")
+	substituted := substitutedVariables(step.Prompt, missingVars, variables)
+	if len(substituted) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for _, line := range substituted {
+		sb.WriteString("  - " + line + "\n")
+	}
+
+	if len(warnings) > 0 {
+		sb.WriteString("Warnings:\n")
+		for _, warning := range warnings {
+			sb.WriteString("  - " + warning + "\n")
+		}
+	}
+
+	sb.WriteString("\nInstructions:\n")
+	sb.WriteString(formatPromptAsInstructions(processedPrompt))
+	sb.WriteString("\nPrompt:\n")
+	sb.WriteString(processedPrompt)
+
+	return sb.String(), nil
+}
+
+// loadPromptFile returns step with its prompt replaced by the content of its
+// prompt file in the prompts directory, along with the path of that file.
+// The inline prompt is kept, and the path is empty, when the step has no
+// prompt file or the file does not exist.
+func (e *StepExecutor) loadPromptFile(step WorkflowStep) (WorkflowStep, string, error) {
+	if step.PromptFile == "" {
+		return step, "", nil
+	}
+	path := filepath.Join(e.promptsDir, step.PromptFile)
+	if !e.fs.Exists(path) {
+		return step, "", nil
+	}
+
+	content, err := e.fs.ReadFile(path)
+	if err != nil {
+		return step, "", fmt.Errorf("failed to read prompt file %s: %w", path, err)
+	}
+	step.Prompt = string(content)
+	return step, path, nil
+}
+
+// applyPromptOverride returns step with its prompt replaced by the content of
+// the prompt override file of the change request, if there is one, along with
+// the path of that file. Without an override file step is returned unchanged
+// and the path is empty.
+func (e *StepExecutor) applyPromptOverride(changeRequestPath string, step WorkflowStep) (WorkflowStep, string, error) {
+	overridePath := GeneratePromptOverridePath(changeRequestPath, step)
+	if !e.fs.Exists(overridePath) {
+		return step, "", nil
+	}
+
+	content, err := e.fs.ReadFile(overridePath)
+	if err != nil {
+		return step, "", fmt.Errorf("failed to read prompt override %s: %w", overridePath, err)
+	}
+	step.Prompt = string(content)
+	return step, overridePath, nil
+}
+
+// promptVariableRegex matches the ${variable_name} variables of a prompt
+var promptVariableRegex = regexp.MustCompile(`\${([a-zA-Z0-9_-]+)}`)
+
+// substitutedVariables lists the variables of prompt that interpolation
+// replaced, with the value used; long values are summarized by their length
+func substitutedVariables(prompt string, missingVars []string, variables PromptVariables) []string {
+	missing := make(map[string]bool, len(missingVars))
+	for _, name := range missingVars {
+		missing[name] = true
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, match := range promptVariableRegex.FindAllStringSubmatch(prompt, -1) {
+		name := match[1]
+		if missing[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case "change_request_file_path":
+			lines = append(lines, fmt.Sprintf("${%s} = %s", name, variables.ChangeRequestFilePath))
+		case "user_stories_content":
+			lines = append(lines, fmt.Sprintf("${%s} = %d characters of inlined user stories", name, len(variables.UserStoriesContent)))
+		}
+	}
+	return lines
+}
+
+// loadUserStoriesContent concatenates the bodies of the user stories referenced
+// by the change request. Stories that cannot be read are skipped and reported
+// in the returned warnings so that a single stale reference does not block the
+// whole step.
+func (e *StepExecutor) loadUserStoriesContent(changeRequestPath string) (string, []string) {
+	content, err := e.fs.ReadFile(changeRequestPath)
+	if err != nil {
+		return "", []string{fmt.Sprintf("Could not read change request %s: %v", changeRequestPath, err)}
+	}
+
+	var warnings []string
+	var sb strings.Builder
+	for _, ref := range metadata.ExtractReferences(string(content)) {
+		storyContent, err := e.fs.ReadFile(ref.FilePath)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Could not read user story %s: %v", ref.FilePath, err))
+			continue
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(fmt.Sprintf("<!-- %s -->\n", ref.FilePath))
+		sb.WriteString(strings.TrimSpace(metadata.GetContentWithoutMetadata(string(storyContent))))
+	}
+
+	return sb.String(), warnings
+}
+
 // formatPromptAsInstructions formats the prompt text as numbered instructions
 func formatPromptAsInstructions(prompt string) string {
 	if prompt == "" {
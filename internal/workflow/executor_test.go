@@ -8,7 +8,10 @@ package workflow
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+
+	ioLib "github.com/user-story-matrix/usm/internal/io"
 )
 
 // testFileSystem is a mock implementation of FileSystem for testing
@@ -105,6 +108,13 @@ func (t *testUserOutput) PrintTable(headers []string, rows [][]string) {
 	// Not needed for these tests
 }
 
+func (t *testUserOutput) Verbosity() ioLib.Verbosity {
+	if t.debugEnabled {
+		return ioLib.VerbosityDebug
+	}
+	return ioLib.VerbosityNormal
+}
+
 func (t *testUserOutput) IsDebugEnabled() bool {
 	return t.debugEnabled
 }
@@ -310,6 +320,216 @@ func TestStepExecutor_ExecuteStep_PromptValidation(t *testing.T) {
 	}
 }
 
+// TestStepExecutor_ExecuteStep_InlineUserStories verifies that ${user_stories_content} is opt-in
+func TestStepExecutor_ExecuteStep_InlineUserStories(t *testing.T) {
+	changeRequest := `---
+name: Inline
+user-stories:
+  - title: First story
+    file: docs/user-stories/01-first.md
+    content-hash: abc
+  - title: Missing story
+    file: docs/user-stories/02-missing.md
+    content-hash: def
+---
+`
+	story := "---\nfile_path: docs/user-stories/01-first.md\n---\n\n# First story\nAs a user\n"
+	step := WorkflowStep{
+		ID:          "01-test",
+		Description: "Test step",
+		Prompt:      "Stories:\n${user_stories_content}",
+	}
+
+	tests := []struct {
+		name           string
+		inline         bool
+		expectedOutput string
+		expectWarnings int
+	}{
+		{
+			name:           "Disabled leaves the variable untouched",
+			inline:         false,
+			expectedOutput: "Stories:\n${user_stories_content}",
+			expectWarnings: 1, // undefined variable warning
+		},
+		{
+			name:           "Enabled inlines readable stories",
+			inline:         true,
+			expectedOutput: "Stories:\n<!-- docs/user-stories/01-first.md -->\n# First story\nAs a user",
+			expectWarnings: 1, // missing story warning
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newTestFileSystem()
+			io := newTestUserOutput()
+			fs.files["change-request.md"] = []byte(changeRequest)
+			fs.exists["change-request.md"] = true
+			fs.files["docs/user-stories/01-first.md"] = []byte(story)
+
+			executor := NewStepExecutor(fs, io)
+			executor.SetInlineUserStories(tt.inline)
+
+			success, err := executor.ExecuteStep("change-request.md", step, "output.md")
+			if !success || err != nil {
+				t.Fatalf("ExecuteStep() failed: success=%v, error=%v", success, err)
+			}
+
+			if len(io.messages) != 1 || io.messages[0] != tt.expectedOutput {
+				t.Errorf("ExecuteStep() output = %q, want %q", io.messages, tt.expectedOutput)
+			}
+			if len(io.warningMessages) != tt.expectWarnings {
+				t.Errorf("ExecuteStep() warnings = %v, want %d", io.warningMessages, tt.expectWarnings)
+			}
+		})
+	}
+}
+
+func TestStepExecutor_ExplainStep(t *testing.T) {
+	fs := newTestFileSystem()
+	io := newTestUserOutput()
+	fs.files["docs/changes-request/feature.blueprint.md"] = []byte("---\nname: Feature\n---\n")
+	fs.exists["docs/changes-request/feature.blueprint.md"] = true
+
+	step := WorkflowStep{
+		ID:          "01-laying-foundation",
+		Description: "Laying the foundation",
+		Prompt:      "Read ${change_request_file_path}. Then use ${unknown_var}.",
+		OutputFile:  "%s.01-laying-foundation.output.md",
+	}
+
+	executor := NewStepExecutor(fs, io)
+	explanation, err := executor.ExplainStep("docs/changes-request/feature.blueprint.md", step)
+	if err != nil {
+		t.Fatalf("ExplainStep() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Step 01-laying-foundation: Laying the foundation",
+		"Output file: docs/changes-request/feature.01-laying-foundation.output.md",
+		"${change_request_file_path} = docs/changes-request/feature.blueprint.md",
+		"Undefined variables: [unknown_var]",
+		"1. Read docs/changes-request/feature.blueprint.md.",
+		"Prompt:\nRead docs/changes-request/feature.blueprint.md. Then use ${unknown_var}.",
+	} {
+		if !strings.Contains(explanation, want) {
+			t.Errorf("ExplainStep() missing %q in:\n%s", want, explanation)
+		}
+	}
+
+	if len(io.messages)+len(io.warningMessages)+len(io.progressMessages) != 0 {
+		t.Errorf("ExplainStep() printed output: %v %v %v", io.messages, io.warningMessages, io.progressMessages)
+	}
+	if len(fs.files) != 1 {
+		t.Errorf("ExplainStep() wrote files: %v", fs.files)
+	}
+}
+
+func TestStepExecutor_ExplainStep_MissingChangeRequest(t *testing.T) {
+	executor := NewStepExecutor(newTestFileSystem(), newTestUserOutput())
+
+	if _, err := executor.ExplainStep("missing.md", WorkflowStep{ID: "01-test"}); err == nil {
+		t.Error("ExplainStep() expected an error for a missing change request")
+	}
+}
+
+func TestStepExecutor_ExecuteStep_PromptOverride(t *testing.T) {
+	fs := newTestFileSystem()
+	io := newTestUserOutput()
+	crPath := "docs/changes-request/feature.blueprint.md"
+	step := WorkflowStep{
+		ID:         "01-laying-foundation",
+		Prompt:     "Builtin prompt for ${change_request_file_path}",
+		OutputFile: "%s.01-laying-foundation.md",
+	}
+	overridePath := GeneratePromptOverridePath(crPath, step)
+	if overridePath != crPath+".01-laying-foundation.prompt.md" {
+		t.Fatalf("GeneratePromptOverridePath() = %q", overridePath)
+	}
+
+	fs.files[crPath] = []byte("---\nname: Feature\n---\n")
+	fs.exists[crPath] = true
+	fs.files[overridePath] = []byte("Custom prompt for ${change_request_file_path} with ${broken")
+	fs.exists[overridePath] = true
+
+	executor := NewStepExecutor(fs, io)
+	if _, err := executor.ExecuteStep(crPath, step, ""); err != nil {
+		t.Fatalf("ExecuteStep() error = %v", err)
+	}
+
+	want := "Custom prompt for docs/changes-request/feature.blueprint.md with ${broken"
+	if len(io.messages) != 1 || io.messages[0] != want {
+		t.Errorf("ExecuteStep() printed %v, want %q", io.messages, want)
+	}
+	// The override is validated like a builtin prompt
+	if len(io.warningMessages) == 0 {
+		t.Error("ExecuteStep() expected a validation warning for the override")
+	}
+
+	explanation, err := executor.ExplainStep(crPath, step)
+	if err != nil {
+		t.Fatalf("ExplainStep() error = %v", err)
+	}
+	if !strings.Contains(explanation, "Prompt override: "+overridePath) {
+		t.Errorf("ExplainStep() does not mention the override:\n%s", explanation)
+	}
+}
+
+func TestStepExecutor_ExecuteStep_PromptFile(t *testing.T) {
+	fs := newTestFileSystem()
+	io := newTestUserOutput()
+	crPath := "docs/changes-request/feature.blueprint.md"
+	fs.files[crPath] = []byte("---\nname: Feature\n---\n")
+	fs.exists[crPath] = true
+
+	step := WorkflowStep{
+		ID:         "02-mvi",
+		Prompt:     "Inline prompt",
+		PromptFile: "02-mvi.md",
+		OutputFile: "%s.02-mvi.md",
+	}
+	executor := NewStepExecutor(fs, io)
+	executor.SetPromptsDir("team/prompts")
+
+	// Without the file the inline prompt is used
+	if _, err := executor.ExecuteStep(crPath, step, ""); err != nil {
+		t.Fatalf("ExecuteStep() error = %v", err)
+	}
+	if len(io.messages) != 1 || io.messages[0] != "Inline prompt" {
+		t.Errorf("ExecuteStep() printed %v, want the inline prompt", io.messages)
+	}
+
+	fs.files["team/prompts/02-mvi.md"] = []byte("Prompt from file for ${change_request_file_path}")
+	fs.exists["team/prompts/02-mvi.md"] = true
+	if _, err := executor.ExecuteStep(crPath, step, ""); err != nil {
+		t.Fatalf("ExecuteStep() error = %v", err)
+	}
+	want := "Prompt from file for " + crPath
+	if len(io.messages) != 2 || io.messages[1] != want {
+		t.Errorf("ExecuteStep() printed %v, want %q", io.messages, want)
+	}
+
+	explanation, err := executor.ExplainStep(crPath, step)
+	if err != nil {
+		t.Fatalf("ExplainStep() error = %v", err)
+	}
+	if !strings.Contains(explanation, "Prompt file: team/prompts/02-mvi.md") {
+		t.Errorf("ExplainStep() does not mention the prompt file:\n%s", explanation)
+	}
+
+	// A per-request override still wins over the prompt file
+	overridePath := GeneratePromptOverridePath(crPath, step)
+	fs.files[overridePath] = []byte("Override")
+	fs.exists[overridePath] = true
+	if _, err := executor.ExecuteStep(crPath, step, ""); err != nil {
+		t.Fatalf("ExecuteStep() error = %v", err)
+	}
+	if io.messages[len(io.messages)-1] != "Override" {
+		t.Errorf("ExecuteStep() printed %q, want the override", io.messages[len(io.messages)-1])
+	}
+}
+
 // Test formatPromptAsInstructions function
 func TestFormatPromptAsInstructions(t *testing.T) {
 	tests := []struct {
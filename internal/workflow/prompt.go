@@ -14,9 +14,12 @@ import (
 // PromptVariables contains variables that can be interpolated into a prompt
 type PromptVariables struct {
 	ChangeRequestFilePath string
-	// Additional variables can be added here in the future
+	UserStoriesContent    string // Bodies of the stories referenced by the change request
 }
 
+// UserStoriesContentVariable is the placeholder resolved with the inlined user stories
+const UserStoriesContentVariable = "${user_stories_content}"
+
 // InterpolationError represents an error during prompt interpolation
 // It provides detailed information about malformed and missing variables
 type InterpolationError struct {
@@ -116,6 +119,8 @@ func interpolateWithDetails(prompt string, variables PromptVariables) (string, [
 			if varName == "change_request_file_path" && variables.ChangeRequestFilePath != "" {
 				// Replace ${change_request_file_path} with the actual path
 				result = strings.ReplaceAll(result, "${"+varName+"}", variables.ChangeRequestFilePath)
+			} else if varName == "user_stories_content" && variables.UserStoriesContent != "" {
+				result = strings.ReplaceAll(result, UserStoriesContentVariable, variables.UserStoriesContent)
 			} else {
 				// Mark as missing
 				missingVars = append(missingVars, varName)
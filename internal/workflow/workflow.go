@@ -7,11 +7,15 @@ package workflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/user-story-matrix/usm/internal/io"
+	"github.com/user-story-matrix/usm/internal/models"
 )
 
 // WorkflowStep represents a single step in the implementation workflow
@@ -19,21 +23,48 @@ type WorkflowStep struct {
 	ID          string // Unique identifier (e.g., "01-laying-the-foundation")
 	Description string // Human-readable description
 	Prompt      string // AI agent instructions with variable interpolation
+	PromptFile  string // Optional file in the prompts directory replacing Prompt
 	OutputFile  string // Template for output filename
 }
 
+// IsTestStep reports whether the step tests the work of the step before it
+func (s WorkflowStep) IsTestStep() bool {
+	return strings.HasSuffix(s.ID, "-test")
+}
+
+// StepOutcome is the result of a completed workflow step
+type StepOutcome int
+
+const (
+	// StepPassed advances the workflow to the following step
+	StepPassed StepOutcome = iota
+	// StepFailed keeps the workflow on the step, or sends it back to the
+	// preceding implementation step when a test step fails
+	StepFailed
+)
+
 // WorkflowState tracks the current state of a workflow for a specific change request
 type WorkflowState struct {
-	ChangeRequestPath string    // Path to the change request file
-	CurrentStepIndex  int       // Index of the current step (0-based)
-	LastModified      time.Time // When the state was last updated
-	CompletedSteps    []string  // List of completed step IDs
+	ChangeRequestPath   string    // Path to the change request file
+	CurrentStepIndex    int       // Index of the current step (0-based)
+	LastModified        time.Time // When the state was last updated
+	CompletedSteps      []string  // List of completed step IDs
+	StartedAt           time.Time // When the state was first saved
+	CompletedAt         time.Time // When the last step was completed, zero while in progress
+	FailedStep          string    // ID of the last step that failed, cleared when a step passes
+	Failures            int       // Number of step failures recorded
+	models.WorkflowLock           // Set by LockWorkflow, the state then refuses changes
 }
 
+// CompletionHandler is called once when a workflow completes its last step,
+// with the time elapsed since the workflow state was first saved
+type CompletionHandler func(changeRequestPath string, duration time.Duration)
+
 // WorkflowManager handles workflow-related operations
 type WorkflowManager struct {
-	fs FileSystem
-	io UserOutput
+	fs         FileSystem
+	io         UserOutput
+	onComplete CompletionHandler
 }
 
 // FileSystem defines the file system operations needed by the workflow manager
@@ -52,6 +83,7 @@ type UserOutput interface {
 	PrintWarning(message string)
 	PrintProgress(message string)
 	PrintStep(stepNumber int, totalSteps int, description string)
+	Verbosity() io.Verbosity
 	IsDebugEnabled() bool
 }
 
@@ -63,6 +95,7 @@ const (
 	ErrStepExecutionFailed     = "❌ Error: Failed to execute step: %s"
 	ErrUnrecognizedStep        = "⚠️ Warning: Unrecognized step in %s. Consider resetting the workflow with --reset."
 	ErrStateFileCorrupted      = "⚠️ Warning: State file for %s appears to be corrupted. Starting from step 1."
+	ErrInconsistentSteps       = "⚠️ Warning: Completed steps in %s did not match the current step and were repaired."
 	ErrOutputFileCreateFailed  = "❌ Error: Failed to create output file: %s"
 	ErrNegativeStepIndex       = "invalid step index: negative value"
 	ErrExceedingStepIndex      = "invalid step index: exceeds number of steps"
@@ -76,19 +109,35 @@ const (
 	SuccessStepCompleted     = "✅ Completed step %d of %d: %s"
 	SuccessWorkflowCompleted = "🎉 All steps completed successfully for change request: %s"
 	SuccessStateReset        = "🔄 Workflow for %s has been reset to the beginning."
+	SuccessWorkflowLocked    = "🔒 Workflow for %s is locked."
+	SuccessWorkflowUnlocked  = "🔓 Workflow for %s is unlocked."
+)
+
+// Failure message templates
+const (
+	WarningStepFailed   = "⚠️ Step %d of %d failed: %s. Back to step %d: %s"
+	WarningRetryingStep = "🔁 Retrying after step %s failed (%d failure(s) so far)"
+)
+
+// Confirmation message templates
+const (
+	ConfirmResetProgress = "⚠️ Resetting will discard progress through step %d of %d: %s. Continue? [y/N]"
 )
 
 // Progress message templates
 const (
-	ProgressExecutingStep = "⏳ Executing step %s: %s"
-	ProgressSavingState   = "💾 Saving workflow state..."
-	ProgressValidating    = "🔍 Validating workflow state..."
+	ProgressExecutingStep       = "⏳ Executing step %s: %s"
+	ProgressSavingState         = "💾 Saving workflow state..."
+	ProgressValidating          = "🔍 Validating workflow state..."
+	ProgressUsingPromptOverride = "📝 Using prompt override %s"
+	ProgressUsingPromptFile     = "📝 Using prompt file %s"
 )
 
 // StandardWorkflowSteps defines the predefined sequence of steps in the implementation workflow
 var StandardWorkflowSteps = []WorkflowStep{
 	{
 		ID:          "01-laying-the-foundation",
+		PromptFile:  "01-laying-the-foundation.md",
 		Description: "Laying the foundation - Setting up the architecture and structure",
 		Prompt:      `You are a senior software engineer about to begin a new iteration of software development based on a set of user stories described in a blueprint document. 
 
@@ -165,12 +214,14 @@ Read the blueprint using cat ${change_request_file_path}
 	},
 	{
 		ID:          "01-laying-the-foundation-test",
+		PromptFile:  "01-laying-the-foundation-test.md",
 		Description: "Laying the foundation testing - Verifying the foundational changes",
 		Prompt:      "Ensure all the tests are passing for the foundational changes implemented based on the blueprint at ${change_request_file_path}. Verify that the structure is appropriate and tests are in place.",
 		OutputFile:  "%s.01-laying-the-foundation-test.md",
 	},
 	{
 		ID:          "02-mvi",
+		PromptFile:  "02-mvi.md",
 		Description: "Minimum Viable Implementation - Building the core functionality",
 		Prompt:      `You are about to continue a development iteration of software based on a set of user stories described in a blueprint document. 
 
@@ -243,12 +294,14 @@ Ensure to include a user story implementation section:
 	},
 	{
 		ID:          "02-mvi-test",
+		PromptFile:  "02-mvi-test.md",
 		Description: "Minimum Viable Implementation testing - Verifying the core functionality",
 		Prompt:      "Ensure all the tests are passing for the minimum viable implementation based on the blueprint at ${change_request_file_path}. Ensure all basic functionality works as expected.",
 		OutputFile:  "%s.02-mvi-test.md",
 	},
 	{
 		ID:          "03-extend-functionalities",
+		PromptFile:  "03-extend-functionalities.md",
 		Description: "Extending functionalities - Adding additional features and improvements",
 		Prompt:      `You are about to continue a development iteration of software based on a set of user stories described in a blueprint document. 
 
@@ -319,12 +372,14 @@ Your task now is to proceed to **expand the implementation** to cover additional
 	},
 	{
 		ID:          "03-extend-functionalities-test",
+		PromptFile:  "03-extend-functionalities-test.md",
 		Description: "Extending functionalities testing - Verifying the additional features",
 		Prompt:      "Ensure all the tests are passing for the extended functionality implemented based on the blueprint at ${change_request_file_path}. Verify all features work correctly.",
 		OutputFile:  "%s.03-extend-functionalities-test.md",
 	},
 	{
 		ID:          "04-final-iteration",
+		PromptFile:  "04-final-iteration.md",
 		Description: "Final iteration - Polishing and final adjustments",
 		Prompt:      `Read a set of user stories using the command: ./cat-user-stories-in-change-request.sh ${change_request_file_path}
 
@@ -405,12 +460,33 @@ Proceed with the **Refinement & Stabilization** phase now.
 	},
 	{
 		ID:          "04-final-iteration-test",
+		PromptFile:  "04-final-iteration-test.md",
 		Description: "Final iteration testing - Final verification and validation",
 		Prompt:      "Ensure all the tests are passing for the final iteration based on the blueprint at ${change_request_file_path}. Ensure all requirements are met.",
 		OutputFile:  "%s.04-final-iteration-test.md",
 	},
 }
 
+// WorkflowStepIDs returns the IDs of the workflow steps, in execution order.
+func WorkflowStepIDs() []string {
+	ids := make([]string, 0, len(StandardWorkflowSteps))
+	for _, step := range StandardWorkflowSteps {
+		ids = append(ids, step.ID)
+	}
+	return ids
+}
+
+// WorkflowStepByID returns the workflow step with the given ID, reporting
+// false when no step has that ID.
+func WorkflowStepByID(id string) (WorkflowStep, bool) {
+	for _, step := range StandardWorkflowSteps {
+		if step.ID == id {
+			return step, true
+		}
+	}
+	return WorkflowStep{}, false
+}
+
 // NewWorkflowManager creates a new workflow manager instance
 func NewWorkflowManager(fs FileSystem, io UserOutput) *WorkflowManager {
 	return &WorkflowManager{
@@ -419,11 +495,45 @@ func NewWorkflowManager(fs FileSystem, io UserOutput) *WorkflowManager {
 	}
 }
 
+// SetCompletionHandler registers a function called when a workflow becomes
+// complete. Passing nil removes it.
+func (wm *WorkflowManager) SetCompletionHandler(handler CompletionHandler) {
+	wm.onComplete = handler
+}
+
+// markCompleted records the completion time on state and notifies the
+// completion handler, if any. It reports false for states already marked complete.
+func (wm *WorkflowManager) markCompleted(state *WorkflowState) bool {
+	if !state.CompletedAt.IsZero() {
+		return false
+	}
+	state.CompletedAt = time.Now()
+
+	if wm.onComplete != nil {
+		var duration time.Duration
+		if !state.StartedAt.IsZero() {
+			duration = state.CompletedAt.Sub(state.StartedAt)
+		}
+		wm.onComplete(state.ChangeRequestPath, duration)
+	}
+	return true
+}
+
+// showAt reports whether output meant for the given verbosity should be shown
+func (wm *WorkflowManager) showAt(level io.Verbosity) bool {
+	return wm.io.Verbosity() >= level
+}
+
 // GenerateStateFilePath generates the path for the state file based on the change request path
 func GenerateStateFilePath(changeRequestPath string) string {
-	dir := filepath.Dir(changeRequestPath)
-	base := filepath.Base(changeRequestPath)
-	return filepath.Join(dir, "."+base+".step")
+	return models.WorkflowStateFilePath(changeRequestPath)
+}
+
+// GeneratePromptOverridePath returns the path of the file that replaces the
+// prompt of step for one change request, e.g.
+// login.blueprint.md.01-laying-the-foundation.prompt.md
+func GeneratePromptOverridePath(changeRequestPath string, step WorkflowStep) string {
+	return changeRequestPath + "." + step.ID + ".prompt.md"
 }
 
 // LoadState loads the workflow state from the state file
@@ -441,14 +551,14 @@ func (wm *WorkflowManager) LoadState(changeRequestPath string) (WorkflowState, e
 	}
 
 	// Only print progress message in debug mode
-	if wm.io.IsDebugEnabled() {
+	if wm.showAt(io.VerbosityDebug) {
 		wm.io.PrintProgress(ProgressValidating)
 	}
 
 	data, err := wm.fs.ReadFile(stateFilePath)
 	if err != nil {
 		// Only print warning in debug mode
-		if wm.io.IsDebugEnabled() {
+		if wm.showAt(io.VerbosityDebug) {
 			wm.io.PrintWarning(fmt.Sprintf(ErrStateFileCorrupted, changeRequestPath))
 		}
 		return state, err
@@ -456,7 +566,7 @@ func (wm *WorkflowManager) LoadState(changeRequestPath string) (WorkflowState, e
 
 	if err := json.Unmarshal(data, &state); err != nil {
 		// Only print warning in debug mode
-		if wm.io.IsDebugEnabled() {
+		if wm.showAt(io.VerbosityDebug) {
 			wm.io.PrintWarning(fmt.Sprintf(ErrInvalidStateFile, changeRequestPath))
 		}
 		return state, err
@@ -465,24 +575,58 @@ func (wm *WorkflowManager) LoadState(changeRequestPath string) (WorkflowState, e
 	// Validate the state
 	if state.CurrentStepIndex < 0 || state.CurrentStepIndex > len(StandardWorkflowSteps) {
 		// Only print warning in debug mode
-		if wm.io.IsDebugEnabled() {
+		if wm.showAt(io.VerbosityDebug) {
 			wm.io.PrintWarning(fmt.Sprintf(ErrUnrecognizedStep, stateFilePath))
 		}
 		state.CurrentStepIndex = 0
 		state.CompletedSteps = []string{}
 	}
 
+	// Completed steps are derived from the current step, so reports never
+	// show contradictory progress
+	if expected := completedStepIDs(state.CurrentStepIndex); !equalStepIDs(state.CompletedSteps, expected) {
+		if wm.showAt(io.VerbosityNormal) {
+			wm.io.PrintWarning(fmt.Sprintf(ErrInconsistentSteps, stateFilePath))
+		}
+		state.CompletedSteps = expected
+	}
+
 	return state, nil
 }
 
+// completedStepIDs returns the IDs of the steps before stepIndex
+func completedStepIDs(stepIndex int) []string {
+	ids := make([]string, 0, stepIndex)
+	for i := 0; i < stepIndex && i < len(StandardWorkflowSteps); i++ {
+		ids = append(ids, StandardWorkflowSteps[i].ID)
+	}
+	return ids
+}
+
+// equalStepIDs reports whether two step ID lists hold the same IDs in the same order
+func equalStepIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // SaveState saves the workflow state to the state file
 func (wm *WorkflowManager) SaveState(state WorkflowState) error {
 	// Only print progress message in debug mode
-	if wm.io.IsDebugEnabled() {
+	if wm.showAt(io.VerbosityDebug) {
 		wm.io.PrintProgress(ProgressSavingState)
 	}
 	
 	state.LastModified = time.Now()
+	if state.StartedAt.IsZero() {
+		state.StartedAt = state.LastModified
+	}
 	
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
@@ -500,14 +644,14 @@ func (wm *WorkflowManager) SaveState(state WorkflowState) error {
 // DetermineNextStep determines the next step to execute based on the state
 func (wm *WorkflowManager) DetermineNextStep(changeRequestPath string) (int, error) {
 	// Only print progress message in debug mode
-	if wm.io.IsDebugEnabled() {
+	if wm.showAt(io.VerbosityDebug) {
 		wm.io.PrintProgress(ProgressValidating)
 	}
 	
 	state, err := wm.LoadState(changeRequestPath)
 	if err != nil {
 		// Only print warning in debug mode
-		if wm.io.IsDebugEnabled() {
+		if wm.showAt(io.VerbosityDebug) {
 			wm.io.PrintWarning(fmt.Sprintf(ErrInvalidStateFile, changeRequestPath))
 		}
 		return 0, nil // Still start from beginning despite the error
@@ -515,25 +659,61 @@ func (wm *WorkflowManager) DetermineNextStep(changeRequestPath string) (int, err
 
 	// If we've completed all steps, return a special indicator
 	if state.CurrentStepIndex >= len(StandardWorkflowSteps) {
-		// Only print success in debug mode
-		if wm.io.IsDebugEnabled() {
+		// Only print success in verbose mode
+		if wm.showAt(io.VerbosityVerbose) {
 			wm.io.PrintSuccess(fmt.Sprintf(SuccessWorkflowCompleted, changeRequestPath))
 		}
+		// States completed before completion was tracked are reported once
+		// here; a locked state is left as it is
+		if !state.Locked && wm.markCompleted(&state) {
+			if err := wm.SaveState(state); err != nil && wm.showAt(io.VerbosityDebug) {
+				wm.io.PrintWarning(err.Error())
+			}
+		}
 		return -1, nil
 	}
 
-	// Print current step information only in debug mode
-	if wm.io.IsDebugEnabled() {
+	// Print current step information only in verbose mode
+	if wm.showAt(io.VerbosityVerbose) {
+		if state.FailedStep != "" {
+			wm.io.PrintWarning(fmt.Sprintf(WarningRetryingStep, state.FailedStep, state.Failures))
+		}
 		wm.io.PrintStep(state.CurrentStepIndex+1, len(StandardWorkflowSteps), StandardWorkflowSteps[state.CurrentStepIndex].Description)
 	}
 	
 	return state.CurrentStepIndex, nil
 }
 
+// CurrentStep returns the step DetermineNextStep would execute next for a
+// change request. complete is true, with a zero step, once every step is
+// completed. A step index outside the workflow is an error wrapping ErrState.
+func (wm *WorkflowManager) CurrentStep(changeRequestPath string) (step WorkflowStep, complete bool, err error) {
+	stepIndex, err := wm.DetermineNextStep(changeRequestPath)
+	if err != nil {
+		return WorkflowStep{}, false, err
+	}
+	if stepIndex == -1 {
+		return WorkflowStep{}, true, nil
+	}
+	if stepIndex < 0 || stepIndex >= len(StandardWorkflowSteps) {
+		return WorkflowStep{}, false, fmt.Errorf("%w: %s", ErrState, ErrExceedingStepIndex)
+	}
+	return StandardWorkflowSteps[stepIndex], false, nil
+}
+
 // UpdateState updates the workflow state after completing a step
 func (wm *WorkflowManager) UpdateState(changeRequestPath string, newStepIndex int) error {
+	return wm.UpdateStateWithOutcome(changeRequestPath, newStepIndex, StepPassed)
+}
+
+// UpdateStateWithOutcome updates the workflow state after the step before
+// newStepIndex has been carried out. A passed step moves the workflow to
+// newStepIndex, like UpdateState. A failed step is recorded in the state and
+// does not advance the workflow: it stays on the failed step or, for a test
+// step, goes back to the implementation step it tests.
+func (wm *WorkflowManager) UpdateStateWithOutcome(changeRequestPath string, newStepIndex int, outcome StepOutcome) error {
 	// Only print progress message in debug mode
-	if wm.io.IsDebugEnabled() {
+	if wm.showAt(io.VerbosityDebug) {
 		wm.io.PrintProgress(ProgressSavingState)
 	}
 	
@@ -541,6 +721,9 @@ func (wm *WorkflowManager) UpdateState(changeRequestPath string, newStepIndex in
 	if err != nil {
 		return fmt.Errorf(ErrStateUpdateFailed, err)
 	}
+	if state.Locked {
+		return lockedError(changeRequestPath)
+	}
 
 	// Validate new step index
 	if newStepIndex < 0 {
@@ -551,19 +734,29 @@ func (wm *WorkflowManager) UpdateState(changeRequestPath string, newStepIndex in
 		return fmt.Errorf(ErrStateUpdateFailed, ErrExceedingStepIndex)
 	}
 
+	if outcome == StepFailed {
+		if newStepIndex == 0 {
+			return fmt.Errorf(ErrStateUpdateFailed, ErrNegativeStepIndex)
+		}
+		return wm.recordFailure(state, newStepIndex-1)
+	}
+	state.FailedStep = ""
+
 	// Update the state
 	state.CurrentStepIndex = newStepIndex
 	
 	// Update completed steps
-	state.CompletedSteps = make([]string, 0, newStepIndex)
-	for i := 0; i < newStepIndex; i++ {
-		if i < len(StandardWorkflowSteps) {
-			state.CompletedSteps = append(state.CompletedSteps, StandardWorkflowSteps[i].ID)
-		}
+	state.CompletedSteps = completedStepIDs(newStepIndex)
+
+	// Notify once when the last step is completed
+	if newStepIndex == len(StandardWorkflowSteps) {
+		wm.markCompleted(&state)
+	} else {
+		state.CompletedAt = time.Time{}
 	}
 		
-	// Print success message for the completed step only in debug mode
-	if wm.io.IsDebugEnabled() {
+	// Print success message for the completed step only in verbose mode
+	if wm.showAt(io.VerbosityVerbose) {
 		if newStepIndex > 0 && newStepIndex <= len(StandardWorkflowSteps) {
 			completedStep := StandardWorkflowSteps[newStepIndex-1]
 			wm.io.PrintSuccess(fmt.Sprintf(SuccessStepCompleted, newStepIndex, len(StandardWorkflowSteps), completedStep.Description))
@@ -574,8 +767,67 @@ func (wm *WorkflowManager) UpdateState(changeRequestPath string, newStepIndex in
 	return wm.SaveState(state)
 }
 
+// recordFailure saves state with the step at failedIndex failed, moving the
+// workflow back to the step to carry out again
+func (wm *WorkflowManager) recordFailure(state WorkflowState, failedIndex int) error {
+	failed := StandardWorkflowSteps[failedIndex]
+	retryIndex := failedIndex
+	if failed.IsTestStep() && retryIndex > 0 {
+		retryIndex--
+	}
+
+	state.CurrentStepIndex = retryIndex
+	state.CompletedSteps = completedStepIDs(retryIndex)
+	state.CompletedAt = time.Time{}
+	state.FailedStep = failed.ID
+	state.Failures++
+
+	if wm.showAt(io.VerbosityVerbose) {
+		wm.io.PrintWarning(fmt.Sprintf(WarningStepFailed, failedIndex+1, len(StandardWorkflowSteps), failed.Description,
+			retryIndex+1, StandardWorkflowSteps[retryIndex].Description))
+	}
+
+	return wm.SaveState(state)
+}
+
+// RunNext executes the next step of the workflow of a change request and, if
+// the step succeeds, advances the state past it. done is true once every step
+// is completed, whether by this call or earlier, in which case nothing is
+// executed. The state is left untouched when the step fails.
+func (wm *WorkflowManager) RunNext(changeRequestPath string, executor *StepExecutor) (done bool, err error) {
+	stepIndex, err := wm.DetermineNextStep(changeRequestPath)
+	if err != nil {
+		return false, err
+	}
+	if stepIndex == -1 {
+		return true, nil
+	}
+	if stepIndex >= len(StandardWorkflowSteps) {
+		return false, fmt.Errorf("%w: %s", ErrState, ErrExceedingStepIndex)
+	}
+
+	step := StandardWorkflowSteps[stepIndex]
+	success, err := executor.ExecuteStep(changeRequestPath, step, wm.GenerateOutputFilename(changeRequestPath, step))
+	if err != nil {
+		return false, fmt.Errorf("%w: step %s: %v", ErrExecution, step.ID, err)
+	}
+	if !success {
+		return false, fmt.Errorf("%w: step %s did not complete", ErrExecution, step.ID)
+	}
+
+	if err := wm.UpdateState(changeRequestPath, stepIndex+1); err != nil {
+		return false, err
+	}
+	return stepIndex+1 >= len(StandardWorkflowSteps), nil
+}
+
 // GenerateOutputFilename generates the output filename for a step
 func (wm *WorkflowManager) GenerateOutputFilename(changeRequestPath string, step WorkflowStep) string {
+	return GenerateOutputFilePath(changeRequestPath, step)
+}
+
+// GenerateOutputFilePath returns the path of the file a step writes for a change request
+func GenerateOutputFilePath(changeRequestPath string, step WorkflowStep) string {
 	dir := filepath.Dir(changeRequestPath)
 	base := filepath.Base(changeRequestPath)
 	
@@ -588,6 +840,28 @@ func (wm *WorkflowManager) GenerateOutputFilename(changeRequestPath string, step
 	return filepath.Join(dir, filename)
 }
 
+// StepOutputExists reports whether the output file of a step has been written
+// for a change request
+func (wm *WorkflowManager) StepOutputExists(changeRequestPath string, step WorkflowStep) bool {
+	return wm.fs.Exists(wm.GenerateOutputFilename(changeRequestPath, step))
+}
+
+// ReadStepOutput returns the content of the output file of a step for a
+// change request. It returns an error wrapping ErrStepOutputNotFound when the
+// step has not written its output yet.
+func (wm *WorkflowManager) ReadStepOutput(changeRequestPath string, step WorkflowStep) (string, error) {
+	outputFile := wm.GenerateOutputFilename(changeRequestPath, step)
+	if !wm.fs.Exists(outputFile) {
+		return "", fmt.Errorf("%w: step %s has no output at %s", ErrStepOutputNotFound, step.ID, outputFile)
+	}
+
+	data, err := wm.fs.ReadFile(outputFile)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrFile, err)
+	}
+	return string(data), nil
+}
+
 // IsWorkflowComplete checks if all workflow steps have been completed
 func (wm *WorkflowManager) IsWorkflowComplete(changeRequestPath string) (bool, error) {
 	state, err := wm.LoadState(changeRequestPath)
@@ -598,8 +872,103 @@ func (wm *WorkflowManager) IsWorkflowComplete(changeRequestPath string) (bool, e
 	return state.CurrentStepIndex >= len(StandardWorkflowSteps), nil
 }
 
-// ResetWorkflow resets the workflow to the beginning
+// IsWorkflowLocked reports whether the workflow of a change request has been
+// locked with LockWorkflow
+func (wm *WorkflowManager) IsWorkflowLocked(changeRequestPath string) (bool, error) {
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		return false, fmt.Errorf(ErrFailedToLoadState, err)
+	}
+	return state.Locked, nil
+}
+
+// LockWorkflow locks the complete workflow of a change request, so a finished
+// deliverable cannot be changed by accident: until UnlockWorkflow is called,
+// the state updates and resets return an error wrapping ErrWorkflowLocked,
+// and the references of the change request are not updated. The workflow can
+// still be read: DetermineNextStep and RunNext report it complete without
+// writing its state file. Only a complete workflow can be locked.
+func (wm *WorkflowManager) LockWorkflow(changeRequestPath string) error {
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		return fmt.Errorf(ErrFailedToLoadState, err)
+	}
+	if state.CurrentStepIndex < len(StandardWorkflowSteps) {
+		return fmt.Errorf("%w: only a complete workflow can be locked, %s is at step %d of %d",
+			ErrState, changeRequestPath, state.CurrentStepIndex+1, len(StandardWorkflowSteps))
+	}
+	if state.Locked {
+		return nil
+	}
+
+	state.Locked = true
+	if err := wm.SaveState(state); err != nil {
+		return err
+	}
+	if wm.showAt(io.VerbosityVerbose) {
+		wm.io.PrintSuccess(fmt.Sprintf(SuccessWorkflowLocked, changeRequestPath))
+	}
+	return nil
+}
+
+// UnlockWorkflow lifts the lock set by LockWorkflow. Unlocking a workflow
+// that is not locked does nothing.
+func (wm *WorkflowManager) UnlockWorkflow(changeRequestPath string) error {
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		return fmt.Errorf(ErrFailedToLoadState, err)
+	}
+	if !state.Locked {
+		return nil
+	}
+
+	state.Locked = false
+	if err := wm.SaveState(state); err != nil {
+		return err
+	}
+	if wm.showAt(io.VerbosityVerbose) {
+		wm.io.PrintSuccess(fmt.Sprintf(SuccessWorkflowUnlocked, changeRequestPath))
+	}
+	return nil
+}
+
+// lockedError is the error returned when changing a locked workflow
+func lockedError(changeRequestPath string) error {
+	return fmt.Errorf("%w: %s is locked, unlock it first", ErrWorkflowLocked, changeRequestPath)
+}
+
+// CompletionPercent returns the share of the workflow steps completed for a
+// change request, from 0 to 100. A completed workflow is at 100 and a state
+// file that is not valid JSON counts as not started; only failing to read the
+// state file is an error.
+func (wm *WorkflowManager) CompletionPercent(changeRequestPath string) (float64, error) {
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	switch {
+	case state.CurrentStepIndex <= 0:
+		return 0, nil
+	case state.CurrentStepIndex >= len(StandardWorkflowSteps):
+		return 100, nil
+	default:
+		return float64(state.CurrentStepIndex) / float64(len(StandardWorkflowSteps)) * 100, nil
+	}
+}
+
+// ResetWorkflow resets the workflow to the beginning. A locked workflow is
+// not reset.
 func (wm *WorkflowManager) ResetWorkflow(changeRequestPath string) error {
+	if current, err := wm.LoadState(changeRequestPath); err == nil && current.Locked {
+		return lockedError(changeRequestPath)
+	}
+
 	state := WorkflowState{
 		ChangeRequestPath: changeRequestPath,
 		CurrentStepIndex:  0,
@@ -611,13 +980,58 @@ func (wm *WorkflowManager) ResetWorkflow(changeRequestPath string) error {
 		return err
 	}
 	
-	// Only show success message in debug mode
-	if wm.io.IsDebugEnabled() {
+	// Only show success message in verbose mode
+	if wm.showAt(io.VerbosityVerbose) {
 		wm.io.PrintSuccess(fmt.Sprintf(SuccessStateReset, changeRequestPath))
 	}
 	return nil
 }
 
+// ResetConfirmation decides whether a reset may discard the given progress
+type ResetConfirmation func(state WorkflowState) (bool, error)
+
+// ResetWorkflowWithConfirmation resets the workflow only after confirm accepts
+// the current state. Workflows without progress are reset without asking.
+// It reports whether the reset was performed.
+func (wm *WorkflowManager) ResetWorkflowWithConfirmation(changeRequestPath string, confirm ResetConfirmation) (bool, error) {
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		return false, fmt.Errorf(ErrFailedToLoadState, err)
+	}
+	if state.Locked {
+		return false, lockedError(changeRequestPath)
+	}
+
+	if state.CurrentStepIndex > 0 {
+		ok, err := confirm(state)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	if err := wm.ResetWorkflow(changeRequestPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResetConfirmationMessage describes the progress a reset of state would discard
+func ResetConfirmationMessage(state WorkflowState) string {
+	total := len(StandardWorkflowSteps)
+	completed := state.CurrentStepIndex
+	if completed > total {
+		completed = total
+	}
+	description := ""
+	if completed > 0 {
+		description = StandardWorkflowSteps[completed-1].Description
+	}
+	return fmt.Sprintf(ConfirmResetProgress, completed, total, description)
+}
+
 // ValidateWorkflowSteps validates all steps in a workflow
 func (wm *WorkflowManager) ValidateWorkflowSteps(steps []WorkflowStep) []error {
 	var errors []error
@@ -7,6 +7,7 @@ package workflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -26,6 +27,7 @@ type MockIO struct {
 	progressMessages []string
 	stepMessages    []string
 	debugEnabled    bool
+	verbosity       ioLib.Verbosity
 }
 
 // NewMockIO creates a new MockIO instance
@@ -38,6 +40,7 @@ func NewMockIO() *MockIO {
 		progressMessages: []string{},
 		stepMessages:    []string{},
 		debugEnabled:    false,
+		verbosity:       ioLib.VerbosityNormal,
 	}
 }
 
@@ -77,6 +80,14 @@ func (m *MockIO) PrintTable(headers []string, rows [][]string) {
 	// Not needed for these tests
 }
 
+// Verbosity implements UserOutput.Verbosity
+func (m *MockIO) Verbosity() ioLib.Verbosity {
+	if m.debugEnabled {
+		return ioLib.VerbosityDebug
+	}
+	return m.verbosity
+}
+
 // IsDebugEnabled implements UserOutput.IsDebugEnabled
 func (m *MockIO) IsDebugEnabled() bool {
 	return m.debugEnabled
@@ -738,6 +749,99 @@ func TestWorkflowManager_IsWorkflowComplete_LoadStateError(t *testing.T) {
 	}
 }
 
+func TestWorkflowManager_CompletionPercent(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	stateFilePath := GenerateStateFilePath(changeRequestPath)
+
+	// No state file yet
+	got, err := wm.CompletionPercent(changeRequestPath)
+	if err != nil || got != 0 {
+		t.Errorf("CompletionPercent() without state = %v, %v, want 0, nil", got, err)
+	}
+
+	tests := []struct {
+		name      string
+		stepIndex int
+		want      float64
+	}{
+		{name: "Half way", stepIndex: len(StandardWorkflowSteps) / 2, want: 50},
+		{name: "Complete", stepIndex: len(StandardWorkflowSteps), want: 100},
+		{name: "Out of range", stepIndex: len(StandardWorkflowSteps) + 3, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateData, err := json.Marshal(WorkflowState{ChangeRequestPath: changeRequestPath, CurrentStepIndex: tt.stepIndex})
+			if err != nil {
+				t.Fatalf("Failed to marshal test state: %v", err)
+			}
+			fs.AddFile(stateFilePath, stateData)
+
+			got, err := wm.CompletionPercent(changeRequestPath)
+			if err != nil {
+				t.Errorf("CompletionPercent() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CompletionPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A corrupt state file counts as not started
+	fs.AddFile(stateFilePath, []byte("invalid json"))
+	got, err = wm.CompletionPercent(changeRequestPath)
+	if err != nil || got != 0 {
+		t.Errorf("CompletionPercent() with corrupt state = %v, %v, want 0, nil", got, err)
+	}
+}
+
+func TestWorkflowManager_CurrentStep(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	stateFilePath := GenerateStateFilePath(changeRequestPath)
+
+	// No state file yet: the first step
+	step, complete, err := wm.CurrentStep(changeRequestPath)
+	if err != nil || complete || step.ID != StandardWorkflowSteps[0].ID {
+		t.Errorf("CurrentStep() without state = %v, %v, %v, want %s, false, nil", step.ID, complete, err, StandardWorkflowSteps[0].ID)
+	}
+
+	tests := []struct {
+		name         string
+		stepIndex    int
+		wantID       string
+		wantComplete bool
+	}{
+		{name: "Second step", stepIndex: 1, wantID: StandardWorkflowSteps[1].ID},
+		{name: "Last step", stepIndex: len(StandardWorkflowSteps) - 1, wantID: StandardWorkflowSteps[len(StandardWorkflowSteps)-1].ID},
+		{name: "Complete", stepIndex: len(StandardWorkflowSteps), wantComplete: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateData, err := json.Marshal(WorkflowState{ChangeRequestPath: changeRequestPath, CurrentStepIndex: tt.stepIndex})
+			if err != nil {
+				t.Fatalf("Failed to marshal test state: %v", err)
+			}
+			fs.AddFile(stateFilePath, stateData)
+
+			step, complete, err := wm.CurrentStep(changeRequestPath)
+			if err != nil {
+				t.Errorf("CurrentStep() error = %v", err)
+			}
+			if complete != tt.wantComplete {
+				t.Errorf("CurrentStep() complete = %v, want %v", complete, tt.wantComplete)
+			}
+			if step.ID != tt.wantID {
+				t.Errorf("CurrentStep() step = %q, want %q", step.ID, tt.wantID)
+			}
+		})
+	}
+}
+
 func TestWorkflowManager_DetermineNextStep_ErrorConditions(t *testing.T) {
 	// Create mocks
 	fs := ioLib.NewMockFileSystem()
@@ -889,4 +993,591 @@ func TestWorkflowManager_ValidateWorkflowSteps(t *testing.T) {
 			}
 		})
 	}
-} 
\ No newline at end of file
+} 
+func TestWorkflowManager_ResetWorkflowWithConfirmation(t *testing.T) {
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	stateFilePath := GenerateStateFilePath(changeRequestPath)
+
+	// setup creates a workflow manager whose state has two completed steps
+	setup := func() *WorkflowManager {
+		fs := ioLib.NewMockFileSystem()
+		state := WorkflowState{
+			ChangeRequestPath: changeRequestPath,
+			CurrentStepIndex:  2,
+			CompletedSteps:    []string{"01-laying-the-foundation", "01-laying-the-foundation-test"},
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			t.Fatalf("Failed to marshal state: %v", err)
+		}
+		fs.AddFile(stateFilePath, data)
+		return NewWorkflowManager(fs, NewMockIO())
+	}
+
+	tests := []struct {
+		name          string
+		confirm       ResetConfirmation
+		wantReset     bool
+		wantErr       bool
+		wantStepIndex int
+	}{
+		{
+			name:          "declined keeps progress",
+			confirm:       func(WorkflowState) (bool, error) { return false, nil },
+			wantReset:     false,
+			wantStepIndex: 2,
+		},
+		{
+			name:          "confirmed resets",
+			confirm:       func(WorkflowState) (bool, error) { return true, nil },
+			wantReset:     true,
+			wantStepIndex: 0,
+		},
+		{
+			name:          "confirmation error keeps progress",
+			confirm:       func(WorkflowState) (bool, error) { return false, fmt.Errorf("no terminal") },
+			wantErr:       true,
+			wantStepIndex: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wm := setup()
+			var seen WorkflowState
+			reset, err := wm.ResetWorkflowWithConfirmation(changeRequestPath, func(state WorkflowState) (bool, error) {
+				seen = state
+				return tt.confirm(state)
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResetWorkflowWithConfirmation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reset != tt.wantReset {
+				t.Errorf("ResetWorkflowWithConfirmation() reset = %v, want %v", reset, tt.wantReset)
+			}
+			if seen.CurrentStepIndex != 2 {
+				t.Errorf("confirmation received CurrentStepIndex = %v, want 2", seen.CurrentStepIndex)
+			}
+
+			state, err := wm.LoadState(changeRequestPath)
+			if err != nil {
+				t.Fatalf("LoadState() error = %v", err)
+			}
+			if state.CurrentStepIndex != tt.wantStepIndex {
+				t.Errorf("state CurrentStepIndex = %v, want %v", state.CurrentStepIndex, tt.wantStepIndex)
+			}
+		})
+	}
+}
+
+func TestWorkflowManager_ResetWorkflowWithConfirmation_NoProgress(t *testing.T) {
+	wm := NewWorkflowManager(ioLib.NewMockFileSystem(), NewMockIO())
+
+	reset, err := wm.ResetWorkflowWithConfirmation("/path/to/new.blueprint.md", func(WorkflowState) (bool, error) {
+		t.Error("confirmation should not be requested without progress")
+		return false, nil
+	})
+
+	if err != nil {
+		t.Errorf("ResetWorkflowWithConfirmation() error = %v, want nil", err)
+	}
+	if !reset {
+		t.Errorf("ResetWorkflowWithConfirmation() reset = false, want true")
+	}
+}
+
+func TestResetConfirmationMessage(t *testing.T) {
+	got := ResetConfirmationMessage(WorkflowState{CurrentStepIndex: 2})
+	want := fmt.Sprintf(ConfirmResetProgress, 2, len(StandardWorkflowSteps), StandardWorkflowSteps[1].Description)
+	if got != want {
+		t.Errorf("ResetConfirmationMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowManager_UpdateState_Verbosity(t *testing.T) {
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+
+	tests := []struct {
+		name         string
+		verbosity    ioLib.Verbosity
+		wantSuccess  bool
+		wantProgress bool
+	}{
+		{"quiet", ioLib.VerbosityQuiet, false, false},
+		{"normal", ioLib.VerbosityNormal, false, false},
+		{"verbose", ioLib.VerbosityVerbose, true, false},
+		{"debug", ioLib.VerbosityDebug, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIO := NewMockIO()
+			mockIO.verbosity = tt.verbosity
+			wm := NewWorkflowManager(ioLib.NewMockFileSystem(), mockIO)
+
+			if err := wm.UpdateState(changeRequestPath, 1); err != nil {
+				t.Fatalf("UpdateState() error = %v", err)
+			}
+
+			if got := len(mockIO.successMessages) > 0; got != tt.wantSuccess {
+				t.Errorf("success messages = %v, want shown = %v", mockIO.successMessages, tt.wantSuccess)
+			}
+			if got := len(mockIO.progressMessages) > 0; got != tt.wantProgress {
+				t.Errorf("progress messages = %v, want shown = %v", mockIO.progressMessages, tt.wantProgress)
+			}
+		})
+	}
+}
+
+func TestWorkflowManager_CompletionHandler(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+
+	var completed []string
+	var durations []time.Duration
+	wm.SetCompletionHandler(func(path string, duration time.Duration) {
+		completed = append(completed, path)
+		durations = append(durations, duration)
+	})
+
+	for i := 1; i < len(StandardWorkflowSteps); i++ {
+		if err := wm.UpdateState(changeRequestPath, i); err != nil {
+			t.Fatalf("UpdateState(%d) error = %v", i, err)
+		}
+	}
+	if len(completed) != 0 {
+		t.Fatalf("completion handler called before the last step: %v", completed)
+	}
+
+	if err := wm.UpdateState(changeRequestPath, len(StandardWorkflowSteps)); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+	if len(completed) != 1 || completed[0] != changeRequestPath {
+		t.Fatalf("completion handler calls = %v, want [%s]", completed, changeRequestPath)
+	}
+	if durations[0] < 0 {
+		t.Errorf("duration = %v, want a non-negative duration", durations[0])
+	}
+
+	// Neither completing again nor checking the next step notifies twice
+	if err := wm.UpdateState(changeRequestPath, len(StandardWorkflowSteps)); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+	if _, err := wm.DetermineNextStep(changeRequestPath); err != nil {
+		t.Fatalf("DetermineNextStep() error = %v", err)
+	}
+	if len(completed) != 1 {
+		t.Errorf("completion handler called %d times, want 1", len(completed))
+	}
+
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if state.CompletedAt.IsZero() || state.StartedAt.IsZero() {
+		t.Errorf("state StartedAt = %v, CompletedAt = %v, want both set", state.StartedAt, state.CompletedAt)
+	}
+}
+
+func TestWorkflowManager_CompletionHandler_UntrackedCompleteState(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+
+	// A state completed before completion times were recorded
+	stateData, err := json.Marshal(WorkflowState{
+		ChangeRequestPath: changeRequestPath,
+		CurrentStepIndex:  len(StandardWorkflowSteps),
+		LastModified:      time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test state: %v", err)
+	}
+	fs.AddFile(GenerateStateFilePath(changeRequestPath), stateData)
+
+	calls := 0
+	wm.SetCompletionHandler(func(path string, duration time.Duration) {
+		calls++
+		if duration != 0 {
+			t.Errorf("duration = %v, want 0 without a start time", duration)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		stepIndex, err := wm.DetermineNextStep(changeRequestPath)
+		if err != nil || stepIndex != -1 {
+			t.Fatalf("DetermineNextStep() = %d, %v, want -1, nil", stepIndex, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("completion handler called %d times, want 1", calls)
+	}
+}
+
+func TestWorkflowManager_LoadState_RepairsCompletedSteps(t *testing.T) {
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	stateFilePath := GenerateStateFilePath(changeRequestPath)
+
+	tests := []struct {
+		name           string
+		completedSteps []string
+	}{
+		{"too many steps", []string{StandardWorkflowSteps[0].ID, StandardWorkflowSteps[1].ID, StandardWorkflowSteps[2].ID, StandardWorkflowSteps[3].ID}},
+		{"too few steps", []string{StandardWorkflowSteps[0].ID}},
+		{"wrong steps", []string{StandardWorkflowSteps[2].ID, StandardWorkflowSteps[1].ID, StandardWorkflowSteps[0].ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := ioLib.NewMockFileSystem()
+			mockIO := NewMockIO()
+			wm := NewWorkflowManager(fs, mockIO)
+
+			stateData, err := json.Marshal(WorkflowState{
+				ChangeRequestPath: changeRequestPath,
+				CurrentStepIndex:  3,
+				LastModified:      time.Now(),
+				CompletedSteps:    tt.completedSteps,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal test state: %v", err)
+			}
+			fs.AddFile(stateFilePath, stateData)
+
+			state, err := wm.LoadState(changeRequestPath)
+			if err != nil {
+				t.Fatalf("LoadState() error = %v", err)
+			}
+
+			want := []string{StandardWorkflowSteps[0].ID, StandardWorkflowSteps[1].ID, StandardWorkflowSteps[2].ID}
+			if !equalStepIDs(state.CompletedSteps, want) {
+				t.Errorf("LoadState() CompletedSteps = %v, want %v", state.CompletedSteps, want)
+			}
+			if state.CurrentStepIndex != 3 {
+				t.Errorf("LoadState() CurrentStepIndex = %d, want 3", state.CurrentStepIndex)
+			}
+
+			wantWarning := fmt.Sprintf(ErrInconsistentSteps, stateFilePath)
+			if len(mockIO.warningMessages) != 1 || mockIO.warningMessages[0] != wantWarning {
+				t.Errorf("LoadState() warnings = %v, want [%s]", mockIO.warningMessages, wantWarning)
+			}
+		})
+	}
+}
+
+func TestWorkflowManager_LoadState_ConsistentStepsNoWarning(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	mockIO := NewMockIO()
+	mockIO.verbosity = ioLib.VerbosityVerbose
+	wm := NewWorkflowManager(fs, mockIO)
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+
+	if err := wm.UpdateState(changeRequestPath, 2); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+	if _, err := wm.LoadState(changeRequestPath); err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if len(mockIO.warningMessages) != 0 {
+		t.Errorf("LoadState() warnings = %v, want none", mockIO.warningMessages)
+	}
+}
+
+func TestWorkflowManager_RunNext(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	mockIO := NewMockIO()
+	wm := NewWorkflowManager(fs, mockIO)
+	executor := NewStepExecutor(fs, mockIO)
+
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	fs.AddFile(changeRequestPath, []byte("# Test Change Request"))
+
+	for i := range StandardWorkflowSteps {
+		done, err := wm.RunNext(changeRequestPath, executor)
+		if err != nil {
+			t.Fatalf("RunNext() step %d error = %v, want nil", i, err)
+		}
+		wantDone := i == len(StandardWorkflowSteps)-1
+		if done != wantDone {
+			t.Errorf("RunNext() step %d done = %v, want %v", i, done, wantDone)
+		}
+
+		state, err := wm.LoadState(changeRequestPath)
+		if err != nil {
+			t.Fatalf("LoadState() error = %v", err)
+		}
+		if state.CurrentStepIndex != i+1 {
+			t.Errorf("CurrentStepIndex = %d, want %d", state.CurrentStepIndex, i+1)
+		}
+	}
+
+	// Running again on a completed workflow reports done without executing
+	messages := len(mockIO.messages)
+	done, err := wm.RunNext(changeRequestPath, executor)
+	if err != nil || !done {
+		t.Errorf("RunNext() on completed workflow = (%v, %v), want (true, nil)", done, err)
+	}
+	if len(mockIO.messages) != messages {
+		t.Errorf("RunNext() on completed workflow should not execute a step")
+	}
+}
+
+func TestWorkflowManager_RunNext_ExecutionError(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	mockIO := NewMockIO()
+	wm := NewWorkflowManager(fs, mockIO)
+	executor := NewStepExecutor(fs, mockIO)
+
+	// The change request file is missing, so the step cannot be executed
+	changeRequestPath := "/path/to/missing.blueprint.md"
+
+	done, err := wm.RunNext(changeRequestPath, executor)
+	if done {
+		t.Errorf("RunNext() done = true, want false")
+	}
+	if err == nil || !errors.Is(err, ErrExecution) {
+		t.Errorf("RunNext() error = %v, want wrapping %v", err, ErrExecution)
+	}
+
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if state.CurrentStepIndex != 0 {
+		t.Errorf("CurrentStepIndex = %d, want 0 after a failed step", state.CurrentStepIndex)
+	}
+}
+
+func TestWorkflowStepIDs(t *testing.T) {
+	ids := WorkflowStepIDs()
+	if len(ids) != len(StandardWorkflowSteps) {
+		t.Fatalf("WorkflowStepIDs() returned %d IDs, want %d", len(ids), len(StandardWorkflowSteps))
+	}
+	for i, step := range StandardWorkflowSteps {
+		if ids[i] != step.ID {
+			t.Errorf("WorkflowStepIDs()[%d] = %s, want %s", i, ids[i], step.ID)
+		}
+	}
+
+	// The returned slice is a copy
+	ids[0] = "changed"
+	if StandardWorkflowSteps[0].ID == "changed" {
+		t.Errorf("WorkflowStepIDs() should not expose the standard steps")
+	}
+}
+
+func TestWorkflowStepByID(t *testing.T) {
+	step, ok := WorkflowStepByID("02-mvi")
+	if !ok {
+		t.Fatalf("WorkflowStepByID(02-mvi) not found")
+	}
+	if step.ID != "02-mvi" || step.Description == "" {
+		t.Errorf("WorkflowStepByID(02-mvi) = %+v", step)
+	}
+
+	if _, ok := WorkflowStepByID("99-unknown"); ok {
+		t.Errorf("WorkflowStepByID(99-unknown) should not be found")
+	}
+}
+
+func TestWorkflowManager_UpdateStateWithOutcome_TestStepFails(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	mockIO := NewMockIO()
+	mockIO.verbosity = ioLib.VerbosityVerbose
+	wm := NewWorkflowManager(fs, mockIO)
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+
+	// Steps 0 to 3 are done, step 3 being the MVI testing step
+	if err := wm.UpdateState(changeRequestPath, 4); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+	if !StandardWorkflowSteps[3].IsTestStep() || StandardWorkflowSteps[2].IsTestStep() {
+		t.Fatalf("unexpected standard steps: %s, %s", StandardWorkflowSteps[2].ID, StandardWorkflowSteps[3].ID)
+	}
+
+	if err := wm.UpdateStateWithOutcome(changeRequestPath, 4, StepFailed); err != nil {
+		t.Fatalf("UpdateStateWithOutcome() error = %v", err)
+	}
+
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if state.CurrentStepIndex != 2 {
+		t.Errorf("CurrentStepIndex = %d, want 2 (the implementation step)", state.CurrentStepIndex)
+	}
+	if !reflect.DeepEqual(state.CompletedSteps, completedStepIDs(2)) {
+		t.Errorf("CompletedSteps = %v, want %v", state.CompletedSteps, completedStepIDs(2))
+	}
+	if state.FailedStep != StandardWorkflowSteps[3].ID || state.Failures != 1 {
+		t.Errorf("FailedStep = %q, Failures = %d, want %q, 1", state.FailedStep, state.Failures, StandardWorkflowSteps[3].ID)
+	}
+	if len(mockIO.warningMessages) != 1 {
+		t.Errorf("expected one failure warning, got %v", mockIO.warningMessages)
+	}
+
+	// The retried step is reported as such
+	if _, err := wm.DetermineNextStep(changeRequestPath); err != nil {
+		t.Fatalf("DetermineNextStep() error = %v", err)
+	}
+	want := fmt.Sprintf(WarningRetryingStep, StandardWorkflowSteps[3].ID, 1)
+	if last := mockIO.warningMessages[len(mockIO.warningMessages)-1]; last != want {
+		t.Errorf("DetermineNextStep() warning = %q, want %q", last, want)
+	}
+
+	// Passing the step again clears the failure but keeps the count
+	if err := wm.UpdateState(changeRequestPath, 3); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+	state, _ = wm.LoadState(changeRequestPath)
+	if state.FailedStep != "" || state.Failures != 1 {
+		t.Errorf("FailedStep = %q, Failures = %d, want \"\", 1", state.FailedStep, state.Failures)
+	}
+}
+
+func TestWorkflowManager_UpdateStateWithOutcome_ImplementationStepFails(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+
+	if err := wm.UpdateState(changeRequestPath, 2); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+
+	// A failed implementation step stays current
+	if err := wm.UpdateStateWithOutcome(changeRequestPath, 3, StepFailed); err != nil {
+		t.Fatalf("UpdateStateWithOutcome() error = %v", err)
+	}
+	state, _ := wm.LoadState(changeRequestPath)
+	if state.CurrentStepIndex != 2 {
+		t.Errorf("CurrentStepIndex = %d, want 2", state.CurrentStepIndex)
+	}
+
+	if err := wm.UpdateStateWithOutcome(changeRequestPath, 0, StepFailed); err == nil {
+		t.Errorf("UpdateStateWithOutcome() with no step before index 0 should fail")
+	}
+}
+
+func TestWorkflowManager_ReadStepOutput(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	step := StandardWorkflowSteps[0]
+
+	if wm.StepOutputExists(changeRequestPath, step) {
+		t.Errorf("StepOutputExists() = true before the step ran")
+	}
+	if _, err := wm.ReadStepOutput(changeRequestPath, step); !errors.Is(err, ErrStepOutputNotFound) {
+		t.Errorf("ReadStepOutput() error = %v, want %v", err, ErrStepOutputNotFound)
+	}
+
+	fs.AddFile(wm.GenerateOutputFilename(changeRequestPath, step), []byte("Foundation laid"))
+
+	if !wm.StepOutputExists(changeRequestPath, step) {
+		t.Errorf("StepOutputExists() = false after the output was written")
+	}
+	output, err := wm.ReadStepOutput(changeRequestPath, step)
+	if err != nil || output != "Foundation laid" {
+		t.Errorf("ReadStepOutput() = (%q, %v), want (%q, nil)", output, err, "Foundation laid")
+	}
+}
+
+func TestWorkflowManager_LockWorkflow(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	mockIO := NewMockIO()
+	wm := NewWorkflowManager(fs, mockIO)
+	executor := NewStepExecutor(fs, mockIO)
+
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	fs.AddFile(changeRequestPath, []byte("# Test Change Request"))
+
+	// Only a complete workflow can be locked
+	if err := wm.LockWorkflow(changeRequestPath); !errors.Is(err, ErrState) {
+		t.Errorf("LockWorkflow() on a new workflow error = %v, want wrapping %v", err, ErrState)
+	}
+
+	if err := wm.UpdateState(changeRequestPath, len(StandardWorkflowSteps)); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+	if err := wm.LockWorkflow(changeRequestPath); err != nil {
+		t.Fatalf("LockWorkflow() error = %v", err)
+	}
+	if locked, err := wm.IsWorkflowLocked(changeRequestPath); err != nil || !locked {
+		t.Errorf("IsWorkflowLocked() = (%v, %v), want (true, nil)", locked, err)
+	}
+
+	// Reading the workflow still works and finds it complete
+	if _, complete, err := wm.CurrentStep(changeRequestPath); err != nil || !complete {
+		t.Errorf("CurrentStep() = (%v, %v), want (true, nil)", complete, err)
+	}
+	if done, err := wm.RunNext(changeRequestPath, executor); err != nil || !done {
+		t.Errorf("RunNext() = (%v, %v), want (true, nil)", done, err)
+	}
+
+	// Changing it does not
+	if err := wm.UpdateStateWithOutcome(changeRequestPath, len(StandardWorkflowSteps), StepFailed); !errors.Is(err, ErrWorkflowLocked) {
+		t.Errorf("UpdateStateWithOutcome() error = %v, want wrapping %v", err, ErrWorkflowLocked)
+	}
+	if err := wm.ResetWorkflow(changeRequestPath); !errors.Is(err, ErrWorkflowLocked) {
+		t.Errorf("ResetWorkflow() error = %v, want wrapping %v", err, ErrWorkflowLocked)
+	}
+	confirm := func(WorkflowState) (bool, error) { return true, nil }
+	if _, err := wm.ResetWorkflowWithConfirmation(changeRequestPath, confirm); !errors.Is(err, ErrWorkflowLocked) {
+		t.Errorf("ResetWorkflowWithConfirmation() error = %v, want wrapping %v", err, ErrWorkflowLocked)
+	}
+
+	state, err := wm.LoadState(changeRequestPath)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if state.CurrentStepIndex != len(StandardWorkflowSteps) || !state.Locked {
+		t.Errorf("locked state changed: step %d, locked %v", state.CurrentStepIndex, state.Locked)
+	}
+
+	// Unlocking allows changes again
+	if err := wm.UnlockWorkflow(changeRequestPath); err != nil {
+		t.Fatalf("UnlockWorkflow() error = %v", err)
+	}
+	if locked, _ := wm.IsWorkflowLocked(changeRequestPath); locked {
+		t.Errorf("IsWorkflowLocked() = true after UnlockWorkflow")
+	}
+	if err := wm.ResetWorkflow(changeRequestPath); err != nil {
+		t.Errorf("ResetWorkflow() after unlock error = %v", err)
+	}
+}
+
+func TestWorkflowManager_DetermineNextStep_LockedStateNotWritten(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+
+	// A locked state completed before completion was tracked
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	stateJSON := fmt.Sprintf(`{"CurrentStepIndex": %d, "Locked": true}`, len(StandardWorkflowSteps))
+	fs.AddFile(GenerateStateFilePath(changeRequestPath), []byte(stateJSON))
+
+	if stepIndex, err := wm.DetermineNextStep(changeRequestPath); err != nil || stepIndex != -1 {
+		t.Errorf("DetermineNextStep() = (%d, %v), want (-1, nil)", stepIndex, err)
+	}
+	data, err := fs.ReadFile(GenerateStateFilePath(changeRequestPath))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != stateJSON {
+		t.Errorf("DetermineNextStep() rewrote the locked state file: %s", data)
+	}
+}
+
+func TestWorkflowManager_UnlockWorkflow_NotLocked(t *testing.T) {
+	fs := ioLib.NewMockFileSystem()
+	wm := NewWorkflowManager(fs, NewMockIO())
+
+	changeRequestPath := "/path/to/change-request.blueprint.md"
+	if err := wm.UnlockWorkflow(changeRequestPath); err != nil {
+		t.Errorf("UnlockWorkflow() error = %v, want nil", err)
+	}
+	if fs.Exists(GenerateStateFilePath(changeRequestPath)) {
+		t.Errorf("UnlockWorkflow() should not write a state file for a workflow that is not locked")
+	}
+}